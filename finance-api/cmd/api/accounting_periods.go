@@ -0,0 +1,70 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+func (s *Server) getAccountingPeriods(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	data, err := s.service.GetAccountingPeriods(ctx)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(data)
+}
+
+func (s *Server) closeAccountingPeriod(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	var body shared.CloseAccountingPeriod
+	defer unchecked(r.Body.Close)
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return err
+	}
+
+	validationError := s.validator.ValidateStruct(body)
+
+	if len(validationError.Errors) != 0 {
+		return validationError
+	}
+
+	err := s.service.CloseAccountingPeriod(ctx, body)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *Server) reopenAccountingPeriod(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	var body shared.ReopenAccountingPeriod
+	defer unchecked(r.Body.Close)
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return err
+	}
+
+	validationError := s.validator.ValidateStruct(body)
+
+	if len(validationError.Errors) != 0 {
+		return validationError
+	}
+
+	err := s.service.ReopenAccountingPeriod(ctx, body)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/validation"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_getAccountingPeriods(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/accounting-periods", nil)
+	w := httptest.NewRecorder()
+
+	closedAt := shared.NewDate("2026-10-05")
+	mock := &mockService{accountingPeriods: shared.AccountingPeriods{
+		{Period: shared.NewDate("2026-10-01"), Status: shared.AccountingPeriodStatusOpen},
+		{Period: shared.NewDate("2026-09-01"), Status: shared.AccountingPeriodStatusClosed, ClosedAt: &closedAt, ClosedBy: 3},
+	}}
+	server := NewServer(mock, nil, nil, nil, nil, nil, nil)
+	err := server.getAccountingPeriods(w, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"GetAccountingPeriods"}, mock.called)
+
+	expected := `[{"period":"01\/10\/2026","status":"OPEN"},{"period":"01\/09\/2026","status":"CLOSED","closedAt":"05\/10\/2026","closedBy":3}]`
+	assert.Equal(t, expected, strings.TrimSpace(w.Body.String()))
+}
+
+func TestServer_closeAccountingPeriod(t *testing.T) {
+	var b bytes.Buffer
+
+	period := shared.NewDate("2026-09-01")
+	_ = json.NewEncoder(&b).Encode(shared.CloseAccountingPeriod{Period: &period})
+	req := httptest.NewRequest(http.MethodPost, "/accounting-periods/close", &b)
+	w := httptest.NewRecorder()
+
+	validator, _ := validation.New()
+
+	mock := &mockService{}
+	server := NewServer(mock, nil, nil, nil, nil, validator, nil)
+	err := server.closeAccountingPeriod(w, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, w.Result().StatusCode)
+	assert.Equal(t, []interface{}{shared.CloseAccountingPeriod{Period: &period}}, mock.lastCalledParams)
+}
+
+func TestServer_closeAccountingPeriodValidationErrors(t *testing.T) {
+	var b bytes.Buffer
+
+	_ = json.NewEncoder(&b).Encode(shared.CloseAccountingPeriod{})
+	req := httptest.NewRequest(http.MethodPost, "/accounting-periods/close", &b)
+	w := httptest.NewRecorder()
+
+	validator, _ := validation.New()
+
+	mock := &mockService{}
+	server := NewServer(mock, nil, nil, nil, nil, validator, nil)
+	err := server.closeAccountingPeriod(w, req)
+
+	expected := apierror.ValidationError{Errors: apierror.ValidationErrors{
+		"Period": {"required": "This field Period needs to be looked at required"},
+	}}
+	assert.Equal(t, expected, err)
+	assert.Len(t, mock.called, 0)
+}
+
+func TestServer_reopenAccountingPeriod(t *testing.T) {
+	var b bytes.Buffer
+
+	period := shared.NewDate("2026-09-01")
+	_ = json.NewEncoder(&b).Encode(shared.ReopenAccountingPeriod{Period: &period})
+	req := httptest.NewRequest(http.MethodPost, "/accounting-periods/reopen", &b)
+	w := httptest.NewRecorder()
+
+	validator, _ := validation.New()
+
+	mock := &mockService{}
+	server := NewServer(mock, nil, nil, nil, nil, validator, nil)
+	err := server.reopenAccountingPeriod(w, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, w.Result().StatusCode)
+	assert.Equal(t, []interface{}{shared.ReopenAccountingPeriod{Period: &period}}, mock.lastCalledParams)
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+func (s *Server) addManualPayment(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	var payment shared.AddManualPayment
+	defer unchecked(r.Body.Close)
+
+	if err := json.NewDecoder(r.Body).Decode(&payment); err != nil {
+		return err
+	}
+
+	validationError := s.validator.ValidateStruct(payment)
+
+	if len(validationError.Errors) != 0 {
+		return validationError
+	}
+
+	clientId, err := s.getPathID(r, "clientId")
+	if err != nil {
+		return err
+	}
+
+	err = s.service.AddManualPayment(ctx, clientId, payment)
+
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	return nil
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/validation"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_addManualPayment(t *testing.T) {
+	var b bytes.Buffer
+
+	bankDate := shared.NewDate("2024-04-12")
+	receivedDate := shared.NewDate("2024-04-11")
+
+	payment := shared.AddManualPayment{
+		PaymentType:  shared.TransactionTypeSupervisionChequePayment,
+		Amount:       12345,
+		BankDate:     &bankDate,
+		ReceivedDate: &receivedDate,
+		PisNumber:    100023,
+	}
+	_ = json.NewEncoder(&b).Encode(payment)
+	req := httptest.NewRequest(http.MethodPost, "/clients/1/payments", &b)
+	req.SetPathValue("clientId", "1")
+	w := httptest.NewRecorder()
+
+	validator, _ := validation.New()
+
+	mock := &mockService{}
+	server := NewServer(mock, nil, nil, nil, nil, validator, nil)
+	err := server.addManualPayment(w, req)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, []string{"AddManualPayment"}, mock.called)
+	assert.Equal(t, []int{1}, mock.expectedIds)
+	assert.Equal(t, payment, mock.manualPayment)
+}
+
+func TestServer_addManualPaymentValidationErrors(t *testing.T) {
+	var b bytes.Buffer
+
+	_ = json.NewEncoder(&b).Encode(shared.AddManualPayment{})
+	req := httptest.NewRequest(http.MethodPost, "/clients/1/payments", &b)
+	req.SetPathValue("clientId", "1")
+	w := httptest.NewRecorder()
+
+	validator, _ := validation.New()
+
+	mock := &mockService{}
+	server := NewServer(mock, nil, nil, nil, nil, validator, nil)
+	err := server.addManualPayment(w, req)
+
+	expected := apierror.ValidationError{Errors: apierror.ValidationErrors{
+		"Amount": {
+			"required": "This field Amount needs to be looked at required",
+		},
+		"BankDate": {
+			"required": "This field BankDate needs to be looked at required",
+		},
+		"PaymentType": {
+			"required": "This field PaymentType needs to be looked at required",
+		},
+		"ReceivedDate": {
+			"required": "This field ReceivedDate needs to be looked at required",
+		},
+	}}
+	assert.Equal(t, expected, err)
+	assert.Empty(t, mock.called)
+}
@@ -16,13 +16,7 @@ func (s *Server) checkDownload(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
-	var exists bool
-
-	if downloadRequest.Key == "Fee_Accrual.csv" {
-		exists = s.fileStorage.FileExists(ctx, s.envs.ReportsBucket, downloadRequest.Key)
-	} else {
-		exists = s.fileStorage.FileExistsWithVersion(ctx, s.envs.ReportsBucket, downloadRequest.Key, downloadRequest.VersionId)
-	}
+	exists := s.fileStorage.FileExistsWithVersion(ctx, s.envs.ReportsBucket, downloadRequest.Key, downloadRequest.VersionId)
 
 	if !exists {
 		return apierror.NotFound{}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+func (s *Server) addCreditTransfer(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	var transfer shared.AddCreditTransfer
+	defer unchecked(r.Body.Close)
+
+	if err := json.NewDecoder(r.Body).Decode(&transfer); err != nil {
+		return err
+	}
+
+	validationError := s.validator.ValidateStruct(transfer)
+
+	if len(validationError.Errors) != 0 {
+		return validationError
+	}
+
+	clientId, err := s.getPathID(r, "clientId")
+	if err != nil {
+		return err
+	}
+
+	err = s.service.AddCreditTransfer(ctx, clientId, transfer)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	return nil
+}
+
+func (s *Server) getPendingCreditTransfers(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	data, err := s.service.GetPendingCreditTransfers(ctx)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(data)
+}
+
+func (s *Server) updateCreditTransferDecision(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	var body shared.UpdateCreditTransfer
+	defer unchecked(r.Body.Close)
+
+	creditTransferId, err := s.getPathID(r, "creditTransferId")
+	if err != nil {
+		return err
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return err
+	}
+
+	validationError := s.validator.ValidateStruct(body)
+
+	if len(validationError.Errors) != 0 {
+		return validationError
+	}
+
+	err = s.service.UpdateCreditTransferDecision(ctx, creditTransferId, body.Status)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/validation"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_addCreditTransfer(t *testing.T) {
+	var b bytes.Buffer
+
+	transfer := shared.AddCreditTransfer{ToCourtRef: "12345678", Amount: 5000, TransferNotes: "Paid to the wrong client"}
+	_ = json.NewEncoder(&b).Encode(transfer)
+	req := httptest.NewRequest(http.MethodPost, "/clients/1/credit-transfers", &b)
+	req.SetPathValue("clientId", "1")
+	w := httptest.NewRecorder()
+
+	validator, _ := validation.New()
+
+	mock := &mockService{}
+	server := NewServer(mock, nil, nil, nil, nil, validator, nil)
+	err := server.addCreditTransfer(w, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+	assert.Equal(t, []int{1}, mock.expectedIds)
+	assert.Equal(t, transfer, mock.creditTransfer)
+}
+
+func TestServer_addCreditTransferValidationErrors(t *testing.T) {
+	var b bytes.Buffer
+
+	_ = json.NewEncoder(&b).Encode(shared.AddCreditTransfer{})
+	req := httptest.NewRequest(http.MethodPost, "/clients/1/credit-transfers", &b)
+	req.SetPathValue("clientId", "1")
+	w := httptest.NewRecorder()
+
+	validator, _ := validation.New()
+
+	mock := &mockService{}
+	server := NewServer(mock, nil, nil, nil, nil, validator, nil)
+	err := server.addCreditTransfer(w, req)
+
+	expected := apierror.ValidationError{Errors: apierror.ValidationErrors{
+		"Amount":        {"required": "This field Amount needs to be looked at required"},
+		"ToCourtRef":    {"required": "This field ToCourtRef needs to be looked at required"},
+		"TransferNotes": {"required": "This field TransferNotes needs to be looked at required"},
+	}}
+	assert.Equal(t, expected, err)
+	assert.Len(t, mock.called, 0)
+}
+
+func TestServer_getPendingCreditTransfers(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/credit-transfers", nil)
+	w := httptest.NewRecorder()
+
+	mock := &mockService{creditTransfers: shared.CreditTransfers{
+		{
+			ID:           1,
+			FromClientId: 2,
+			FromCourtRef: "11111111",
+			ToClientId:   3,
+			ToCourtRef:   "22222222",
+			Amount:       5000,
+			Notes:        "Paid to the wrong client",
+			CreatedDate:  shared.NewDate("2025-01-02"),
+			CreatedBy:    4,
+		},
+	}}
+	server := NewServer(mock, nil, nil, nil, nil, nil, nil)
+	err := server.getPendingCreditTransfers(w, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"GetPendingCreditTransfers"}, mock.called)
+
+	expected := `[{"id":1,"fromClientId":2,"fromCourtRef":"11111111","toClientId":3,"toCourtRef":"22222222","amount":5000,"notes":"Paid to the wrong client","createdDate":"02\/01\/2025","createdBy":4}]`
+	assert.Equal(t, expected, strings.TrimSpace(w.Body.String()))
+}
+
+func TestServer_updateCreditTransferDecision(t *testing.T) {
+	var b bytes.Buffer
+
+	_ = json.NewEncoder(&b).Encode(shared.UpdateCreditTransfer{Status: shared.AdjustmentStatusApproved})
+	req := httptest.NewRequest(http.MethodPut, "/credit-transfers/3", &b)
+	req.SetPathValue("creditTransferId", "3")
+	w := httptest.NewRecorder()
+
+	validator, _ := validation.New()
+
+	mock := &mockService{}
+	server := NewServer(mock, nil, nil, nil, nil, validator, nil)
+	err := server.updateCreditTransferDecision(w, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, w.Result().StatusCode)
+	assert.Equal(t, []int{3}, mock.expectedIds)
+	assert.Equal(t, []interface{}{shared.AdjustmentStatusApproved}, mock.lastCalledParams)
+}
+
+func TestServer_updateCreditTransferDecisionInvalidStatus(t *testing.T) {
+	var b bytes.Buffer
+
+	_ = json.NewEncoder(&b).Encode(shared.UpdateCreditTransfer{Status: shared.AdjustmentStatusPending})
+	req := httptest.NewRequest(http.MethodPut, "/credit-transfers/3", &b)
+	req.SetPathValue("creditTransferId", "3")
+	w := httptest.NewRecorder()
+
+	validator, _ := validation.New()
+
+	mock := &mockService{}
+	server := NewServer(mock, nil, nil, nil, nil, validator, nil)
+	err := server.updateCreditTransferDecision(w, req)
+
+	var e apierror.ValidationError
+	assert.ErrorAs(t, err, &e)
+	assert.Len(t, mock.called, 0)
+}
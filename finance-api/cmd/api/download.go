@@ -21,14 +21,7 @@ func (s *Server) download(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
-	var result io.ReadCloser
-
-	if downloadRequest.Key == "Fee_Accrual.csv" {
-		result, err = s.fileStorage.GetFile(ctx, s.envs.ReportsBucket, downloadRequest.Key)
-	} else {
-		result, err = s.fileStorage.GetFileWithVersion(ctx, s.envs.ReportsBucket, downloadRequest.Key, downloadRequest.VersionId)
-	}
-
+	result, err := s.fileStorage.GetFileWithVersion(ctx, s.envs.ReportsBucket, downloadRequest.Key, downloadRequest.VersionId)
 	if err != nil {
 		var apiErr smithy.APIError
 		if errors.As(err, &apiErr) {
@@ -0,0 +1,23 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+func (s *Server) getAuditLogs(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	clientId, err := s.getPathID(r, "clientId")
+	if err != nil {
+		return err
+	}
+
+	auditLogs, err := s.service.GetAuditLogs(ctx, clientId)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(auditLogs)
+}
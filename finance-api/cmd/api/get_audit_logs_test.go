@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_getAuditLogs(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/clients/1/audit", nil)
+	req.SetPathValue("clientId", "1")
+	w := httptest.NewRecorder()
+
+	auditLogs := shared.AuditLogs{
+		{
+			ID:         1,
+			Action:     shared.AuditActionRefundDecision,
+			EntityType: shared.AuditEntityRefund,
+			EntityID:   2,
+			Before:     map[string]string{"status": "PENDING"},
+			After:      map[string]string{"status": "APPROVED"},
+			CreatedAt:  time.Date(2025, 6, 4, 9, 30, 0, 0, time.UTC),
+			CreatedBy:  10,
+		},
+	}
+
+	mock := &mockService{auditLogs: auditLogs}
+	server := NewServer(mock, nil, nil, nil, nil, nil, nil)
+	_ = server.getAuditLogs(w, req)
+
+	res := w.Result()
+	defer unchecked(res.Body.Close)
+
+	expected := `[{"id":1,"action":"REFUND DECISION","entityType":"REFUND","entityId":2,"before":{"status":"PENDING"},"after":{"status":"APPROVED"},"createdAt":"2025-06-04T09:30:00Z","createdBy":10}]`
+
+	assert.Equal(t, expected, strings.TrimSpace(w.Body.String()))
+	assert.Equal(t, 1, mock.expectedIds[0])
+	assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+}
+
+func TestServer_getAuditLogs_error(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/clients/1/audit", nil)
+	req.SetPathValue("clientId", "1")
+	w := httptest.NewRecorder()
+
+	mock := &mockService{errs: map[string]error{"GetAuditLogs": pgx.ErrTooManyRows}}
+	server := NewServer(mock, nil, nil, nil, nil, nil, nil)
+	err := server.getAuditLogs(w, req)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+)
+
+func (s *Server) getUploadJobs(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	jobs, err := s.service.GetUploadJobs(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		formatUploadJobFailedLines(job.FailedLines)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(jobs)
+}
+
+func (s *Server) getUploadJob(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	id, err := s.getPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	job, err := s.service.GetUploadJob(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apierror.NotFoundError(err)
+	} else if err != nil {
+		return err
+	}
+
+	formatUploadJobFailedLines(job.FailedLines)
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(job)
+}
+
+// formatUploadJobFailedLines replaces the stored error codes with the messages sent in the failed lines email
+func formatUploadJobFailedLines(failedLines map[int]string) {
+	for line, code := range failedLines {
+		failedLines[line] = uploadErrorMessage(code)
+	}
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/validation"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_getUploadJobs(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/uploads", nil)
+	w := httptest.NewRecorder()
+
+	mock := &mockService{uploadJobs: shared.UploadJobs{
+		{
+			ID:                 1,
+			UploadType:         shared.ReportTypeUploadPaymentsMOTOCard,
+			Filename:           "file.csv",
+			FileHash:           "abc",
+			Status:             shared.UploadJobStatusCompleted,
+			LineCount:          shared.Nillable[int]{Value: 2, Valid: true},
+			FailedLineCount:    shared.Nillable[int]{Value: 1, Valid: true},
+			FailedLines:        map[int]string{2: validation.UploadErrorClientNotFound},
+			StartedAt:          time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC),
+			EndedAt:            shared.Nillable[time.Time]{Value: time.Date(2025, 1, 2, 9, 1, 0, 0, time.UTC), Valid: true},
+			CreatedBy:          3,
+			UploadDate:         shared.Nillable[shared.Date]{Value: shared.NewDate("2025-01-02"), Valid: true},
+			EmailAddress:       "test@example.com",
+			ProcessedLineCount: shared.Nillable[int]{Value: 2, Valid: true},
+		},
+	}}
+	server := NewServer(mock, nil, nil, nil, nil, nil, nil)
+	err := server.getUploadJobs(w, req)
+	assert.NoError(t, err)
+
+	expected := `[{"id":1,"uploadType":"PAYMENTS_MOTO_CARD","filename":"file.csv","fileHash":"abc","status":"COMPLETED","lineCount":{"Value":2,"Valid":true},"failedLineCount":{"Value":1,"Valid":true},"failedLines":{"2":"Could not find a client with this court reference"},"startedAt":"2025-01-02T09:00:00Z","endedAt":{"Value":"2025-01-02T09:01:00Z","Valid":true},"createdBy":3,"uploadDate":{"Value":"02\/01\/2025","Valid":true},"emailAddress":"test@example.com","processedLineCount":{"Value":2,"Valid":true}}]`
+
+	assert.Equal(t, expected, strings.TrimSpace(w.Body.String()))
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+}
+
+func TestServer_getUploadJob(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/uploads/1", nil)
+	req.SetPathValue("id", "1")
+	w := httptest.NewRecorder()
+
+	mock := &mockService{uploadJob: &shared.UploadJob{
+		ID:         1,
+		UploadType: shared.ReportTypeUploadFulfilledRefunds,
+		Filename:   "refunds.csv",
+		Status:     shared.UploadJobStatusProcessing,
+		StartedAt:  time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC),
+	}}
+	server := NewServer(mock, nil, nil, nil, nil, nil, nil)
+	err := server.getUploadJob(w, req)
+	assert.NoError(t, err)
+
+	expected := `{"id":1,"uploadType":"FULFILLED_REFUNDS","filename":"refunds.csv","fileHash":"","status":"PROCESSING","lineCount":{"Value":0,"Valid":false},"failedLineCount":{"Value":0,"Valid":false},"startedAt":"2025-01-02T09:00:00Z","endedAt":{"Value":"0001-01-01T00:00:00Z","Valid":false},"createdBy":0,"uploadDate":{"Value":"01\/01\/0001","Valid":false},"emailAddress":"","processedLineCount":{"Value":0,"Valid":false}}`
+
+	assert.Equal(t, expected, strings.TrimSpace(w.Body.String()))
+	assert.Equal(t, 1, mock.expectedIds[0])
+}
+
+func TestServer_getUploadJob_notFound(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/uploads/1", nil)
+	req.SetPathValue("id", "1")
+	w := httptest.NewRecorder()
+
+	mock := &mockService{errs: map[string]error{"GetUploadJob": pgx.ErrNoRows}}
+	server := NewServer(mock, nil, nil, nil, nil, nil, nil)
+	err := server.getUploadJob(w, req)
+
+	expected := apierror.NotFoundError(pgx.ErrNoRows)
+	assert.ErrorAs(t, err, &expected)
+}
@@ -1,12 +1,16 @@
 package api
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/service"
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
 )
 
@@ -16,13 +20,64 @@ func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) error {
 	var event shared.Event
 	defer unchecked(r.Body.Close)
 
-	if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return apierror.BadRequestError("event", "unable to read event", err)
+	}
+
+	if err := json.Unmarshal(body, &event); err != nil {
 		return apierror.BadRequestError("event", "unable to parse event", err)
 	}
 
+	id, process, err := s.service.StartProcessedEvent(ctx, service.InboundEvent{
+		Source:     event.Source,
+		DetailType: event.DetailType,
+		Key:        inboundEventKey(event, body),
+		Payload:    body,
+	})
+	if err != nil {
+		return err
+	}
+
+	if process {
+		err = s.processEvent(r, event)
+		if completeErr := s.service.CompleteProcessedEvent(ctx, id, err); err == nil {
+			// the event is left processing, so it is not acknowledged until it can be retried
+			err = completeErr
+		}
+		if err != nil {
+			return err
+		}
+	} else {
+		s.Logger(ctx).Info("acknowledging duplicate event", "source", event.Source, "detailType", event.DetailType)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+// inboundEventKey identifies an event for de-duplication. EventBridge assigns each event an ID, which is kept when it is
+// redelivered, but events posted without one are identified by their content instead.
+func inboundEventKey(event shared.Event, body []byte) string {
+	if event.ID != "" {
+		return event.ID
+	}
+	hash := sha256.Sum256(body)
+	return hex.EncodeToString(hash[:])
+}
+
+// processEvent carries out the side effects of an inbound event
+func (s *Server) processEvent(r *http.Request, event shared.Event) error {
+	ctx := r.Context()
+
 	if event.Source == shared.EventSourceSirius && event.DetailType == shared.DetailTypeInvoiceCreated {
 		if detail, ok := event.Detail.(shared.InvoiceCreatedEvent); ok {
-			err := s.service.PostLedgerActions(ctx, detail.ClientID, nil)
+			err := s.service.ApplyInvoiceFeeReduction(ctx, detail.ClientID, detail.InvoiceID)
+			if err != nil {
+				return err
+			}
+			err = s.service.PostLedgerActions(ctx, detail.ClientID, nil)
 			if err != nil {
 				return err
 			}
@@ -49,6 +104,13 @@ func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) error {
 				return err
 			}
 		}
+	} else if event.Source == shared.EventSourceSirius && event.DetailType == shared.DetailTypeOrderCreated {
+		if detail, ok := event.Detail.(shared.OrderCreatedEvent); ok {
+			err := s.service.ProvisionFinanceClient(ctx, detail)
+			if err != nil {
+				return err
+			}
+		}
 	} else if event.Source == shared.EventSourceFinance && event.DetailType == shared.DetailTypeScheduleToRemove {
 		if detail, ok := event.Detail.(shared.ScheduleToRemoveEvent); ok {
 			allPayCustomer := shared.AllPayCustomer{
@@ -63,6 +125,19 @@ func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) error {
 			if err != nil {
 				return err
 			}
+			if detail.Reschedule {
+				err = s.service.RescheduleDirectDebit(ctx, int32(detail.ClientID))
+				if err != nil {
+					return err
+				}
+			}
+		}
+	} else if event.Source == shared.EventSourceFinanceAdmin && event.DetailType == shared.DetailTypeFinanceAdminUpload {
+		if detail, ok := event.Detail.(shared.FinanceAdminUploadEvent); ok {
+			err := s.processFinanceAdminUpload(r, detail)
+			if err != nil {
+				return err
+			}
 		}
 	} else if event.Source == shared.EventSourceFinanceAdhoc && event.DetailType == shared.DetailTypeFinanceAdhoc {
 		if detail, ok := event.Detail.(shared.AdhocEvent); ok {
@@ -82,7 +157,5 @@ func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) error {
 		return apierror.BadRequestError("event", fmt.Sprintf("could not match event: %s %s", event.Source, event.DetailType), errors.New("no match"))
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
 	return nil
 }
@@ -2,7 +2,10 @@ package api
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -12,6 +15,7 @@ import (
 
 	"github.com/ministryofjustice/opg-go-common/telemetry"
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/service"
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
 	"github.com/stretchr/testify/assert"
 )
@@ -32,7 +36,7 @@ func TestServer_handleEvents(t *testing.T) {
 				Detail:     shared.InvoiceCreatedEvent{ClientID: 1, InvoiceID: 2, InvoiceType: shared.InvoiceTypeB2},
 			},
 			expectedErr:     nil,
-			expectedHandler: "ReapplyCredit",
+			expectedHandler: "ApplyInvoiceFeeReduction",
 		},
 		{
 			name: "client made inactive event",
@@ -44,6 +48,16 @@ func TestServer_handleEvents(t *testing.T) {
 			expectedErr:     nil,
 			expectedHandler: "CancelDirectDebitMandate",
 		},
+		{
+			name: "order created event",
+			event: shared.Event{
+				Source:     "opg.supervision.sirius",
+				DetailType: "order-created",
+				Detail:     shared.OrderCreatedEvent{ClientID: 1, OrderID: 2, CourtRef: "12345678", SopNumber: "123456"},
+			},
+			expectedErr:     nil,
+			expectedHandler: "ProvisionFinanceClient",
+		},
 		{
 			name: "adhoc event",
 			event: shared.Event{
@@ -118,3 +132,124 @@ func TestServer_handleEvents(t *testing.T) {
 		})
 	}
 }
+
+func TestServer_handleEvents_invoiceCreated(t *testing.T) {
+	mock := &mockService{}
+	server := NewServer(mock, nil, nil, nil, nil, nil, nil)
+
+	var body bytes.Buffer
+	_ = json.NewEncoder(&body).Encode(shared.Event{
+		Source:     "opg.supervision.sirius",
+		DetailType: "invoice-created",
+		Detail:     shared.InvoiceCreatedEvent{ClientID: 1, InvoiceID: 2, InvoiceType: shared.InvoiceTypeAD},
+	})
+	r := httptest.NewRequest(http.MethodPost, "/events", &body)
+	ctx := telemetry.ContextWithLogger(r.Context(), telemetry.NewLogger("test"))
+	r = r.WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	err := server.handleEvents(w, r)
+	assert.Nil(t, err)
+
+	// the fee reduction is applied before any credit is reapplied or Direct Debit schedule calculated
+	assert.Equal(t, []string{"ApplyInvoiceFeeReduction", "ReapplyCredit", "CreateDirectDebitSchedule"}, mock.called)
+}
+
+func TestServer_handleEvents_scheduleToRemove_reschedule(t *testing.T) {
+	mock := &mockService{}
+	server := NewServer(mock, nil, nil, nil, nil, nil, nil)
+
+	var body bytes.Buffer
+	_ = json.NewEncoder(&body).Encode(shared.Event{
+		Source:     "opg.supervision.finance",
+		DetailType: "schedule-to-remove",
+		Detail:     shared.ScheduleToRemoveEvent{CourtRef: "12345678", Surname: "Smith", Amount: 1000, ClientID: 1, Reschedule: true},
+	})
+	r := httptest.NewRequest(http.MethodPost, "/events", &body)
+	ctx := telemetry.ContextWithLogger(r.Context(), telemetry.NewLogger("test"))
+	r = r.WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	err := server.handleEvents(w, r)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"RemoveDirectDebitSchedule", "RescheduleDirectDebit"}, mock.called)
+}
+
+func TestServer_handleEvents_duplicate(t *testing.T) {
+	mock := &mockService{duplicateEvent: true}
+	server := NewServer(mock, nil, nil, nil, nil, nil, nil)
+
+	var body bytes.Buffer
+	_ = json.NewEncoder(&body).Encode(shared.Event{
+		ID:         "a1b2c3",
+		Source:     "opg.supervision.sirius",
+		DetailType: "client-made-inactive",
+		Detail:     shared.ClientMadeInactiveEvent{ClientID: 1, CourtRef: "12345678", Surname: "Smith"},
+	})
+	r := httptest.NewRequest(http.MethodPost, "/events", &body)
+	ctx := telemetry.ContextWithLogger(r.Context(), telemetry.NewLogger("test"))
+	r = r.WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	err := server.handleEvents(w, r)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	assert.Equal(t, "a1b2c3", mock.inboundEvent.Key)
+	assert.Empty(t, mock.called)
+	assert.Empty(t, mock.completedEvents)
+}
+
+func TestServer_handleEvents_recordsOutcome(t *testing.T) {
+	processErr := errors.New("allpay unavailable")
+	mock := &mockService{errs: map[string]error{"CancelDirectDebitMandate": processErr}}
+	server := NewServer(mock, nil, nil, nil, nil, nil, nil)
+
+	var body bytes.Buffer
+	_ = json.NewEncoder(&body).Encode(shared.Event{
+		Source:     "opg.supervision.sirius",
+		DetailType: "client-made-inactive",
+		Detail:     shared.ClientMadeInactiveEvent{ClientID: 1, CourtRef: "12345678", Surname: "Smith"},
+	})
+	payload := body.Bytes()
+	hash := sha256.Sum256(payload)
+
+	r := httptest.NewRequest(http.MethodPost, "/events", bytes.NewReader(payload))
+	ctx := telemetry.ContextWithLogger(r.Context(), telemetry.NewLogger("test"))
+	r = r.WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	err := server.handleEvents(w, r)
+	assert.Equal(t, processErr, err)
+
+	// events without an ID are identified by their content
+	assert.Equal(t, service.InboundEvent{
+		Source:     "opg.supervision.sirius",
+		DetailType: "client-made-inactive",
+		Key:        hex.EncodeToString(hash[:]),
+		Payload:    payload,
+	}, mock.inboundEvent)
+	assert.Equal(t, map[int32]error{1: processErr}, mock.completedEvents)
+}
+
+func TestServer_handleEvents_completeError(t *testing.T) {
+	completeErr := errors.New("connection reset")
+	mock := &mockService{errs: map[string]error{"CompleteProcessedEvent": completeErr}}
+	server := NewServer(mock, nil, nil, nil, nil, nil, nil)
+
+	var body bytes.Buffer
+	_ = json.NewEncoder(&body).Encode(shared.Event{
+		Source:     "opg.supervision.sirius",
+		DetailType: "client-made-inactive",
+		Detail:     shared.ClientMadeInactiveEvent{ClientID: 1, CourtRef: "12345678", Surname: "Smith"},
+	})
+	r := httptest.NewRequest(http.MethodPost, "/events", &body)
+	ctx := telemetry.ContextWithLogger(r.Context(), telemetry.NewLogger("test"))
+	r = r.WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	err := server.handleEvents(w, r)
+	assert.Equal(t, completeErr, err)
+	assert.Equal(t, map[int32]error{1: nil}, mock.completedEvents)
+}
@@ -0,0 +1,18 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+func (s *Server) getDeadLetteredOutboxEvents(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	events, err := s.service.GetDeadLetteredOutboxEvents(ctx)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(events)
+}
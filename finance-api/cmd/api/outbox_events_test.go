@@ -0,0 +1,38 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_getDeadLetteredOutboxEvents(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/events/outbox/dead-lettered", nil)
+	w := httptest.NewRecorder()
+
+	mock := &mockService{deadLetteredEvents: shared.OutboxEvents{
+		{
+			ID:             1,
+			EventType:      "credit-on-account",
+			OrderingKey:    "client-1",
+			Attempts:       20,
+			Error:          "event bus unavailable",
+			CreatedAt:      time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC),
+			DeadLetteredAt: time.Date(2025, 1, 2, 17, 0, 0, 0, time.UTC),
+		},
+	}}
+	server := NewServer(mock, nil, nil, nil, nil, nil, nil)
+	err := server.getDeadLetteredOutboxEvents(w, req)
+	assert.NoError(t, err)
+
+	expected := `[{"id":1,"eventType":"credit-on-account","orderingKey":"client-1","attempts":20,"error":"event bus unavailable","createdAt":"2025-01-02T09:00:00Z","deadLetteredAt":"2025-01-02T17:00:00Z"}]`
+
+	assert.Equal(t, expected, strings.TrimSpace(w.Body.String()))
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Equal(t, []string{"GetDeadLetteredOutboxEvents"}, mock.called)
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/service"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+func (s *Server) getPendingInvoiceAdjustments(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	var filter service.PendingInvoiceAdjustmentsFilter
+
+	if query.Get("adjustmentType") != "" {
+		filter.AdjustmentType = shared.ParseAdjustmentType(query.Get("adjustmentType"))
+		if !filter.AdjustmentType.Valid() {
+			return apierror.BadRequestError("adjustmentType", "Unknown adjustment type", nil)
+		}
+	}
+
+	if query.Get("createdBy") != "" {
+		createdBy, err := strconv.Atoi(query.Get("createdBy"))
+		if err != nil {
+			return apierror.BadRequestError("createdBy", "Unable to parse value to int", err)
+		}
+		filter.CreatedBy = int32(createdBy)
+	}
+
+	if query.Get("olderThanDays") != "" {
+		olderThanDays, err := strconv.Atoi(query.Get("olderThanDays"))
+		if err != nil || olderThanDays < 0 {
+			return apierror.BadRequestError("olderThanDays", "Age must be zero or a positive number of days", err)
+		}
+		filter.OlderThanDays = olderThanDays
+	}
+
+	data, err := s.service.GetPendingInvoiceAdjustments(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(data)
+}
+
+func (s *Server) updatePendingInvoiceAdjustments(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	var body shared.UpdateInvoiceAdjustments
+	defer unchecked(r.Body.Close)
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return err
+	}
+
+	validationError := s.validator.ValidateStruct(body)
+
+	if len(validationError.Errors) != 0 {
+		return validationError
+	}
+
+	data := s.service.UpdatePendingInvoiceAdjustments(ctx, body.Adjustments, body.Status)
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(data)
+}
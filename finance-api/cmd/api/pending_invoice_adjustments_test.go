@@ -0,0 +1,123 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/service"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/validation"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_getPendingInvoiceAdjustments(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/invoice-adjustments?adjustmentType=CREDIT+WRITE+OFF&createdBy=3&olderThanDays=30", nil)
+	w := httptest.NewRecorder()
+
+	mock := &mockService{pendingAdjustments: shared.PendingInvoiceAdjustments{
+		{
+			Id:             1,
+			ClientId:       2,
+			CourtRef:       "12345678",
+			InvoiceRef:     "S203531/19",
+			RaisedDate:     shared.NewDate("2024-01-01"),
+			AdjustmentType: shared.AdjustmentTypeWriteOff,
+			Amount:         12300,
+			Notes:          "Write off",
+			CreatedBy:      3,
+		},
+	}}
+	server := NewServer(mock, nil, nil, nil, nil, nil, nil)
+	err := server.getPendingInvoiceAdjustments(w, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{service.PendingInvoiceAdjustmentsFilter{
+		AdjustmentType: shared.AdjustmentTypeWriteOff,
+		CreatedBy:      3,
+		OlderThanDays:  30,
+	}}, mock.lastCalledParams)
+
+	expected := `[{"id":1,"clientId":2,"courtRef":"12345678","invoiceRef":"S203531/19","raisedDate":"01\/01\/2024","adjustmentType":"CREDIT WRITE OFF","amount":12300,"notes":"Write off","createdBy":3}]`
+	assert.Equal(t, expected, strings.TrimSpace(w.Body.String()))
+}
+
+func TestServer_getPendingInvoiceAdjustments_invalidFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		field string
+	}{
+		{name: "unknown type", query: "adjustmentType=CHEQUE", field: "adjustmentType"},
+		{name: "invalid creator", query: "createdBy=abc", field: "createdBy"},
+		{name: "negative age", query: "olderThanDays=-1", field: "olderThanDays"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/invoice-adjustments?"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			mock := &mockService{}
+			server := NewServer(mock, nil, nil, nil, nil, nil, nil)
+			err := server.getPendingInvoiceAdjustments(w, req)
+
+			var e *apierror.BadRequest
+			assert.ErrorAs(t, err, &e)
+			assert.Equal(t, tt.field, e.Field)
+			assert.Len(t, mock.called, 0)
+		})
+	}
+}
+
+func TestServer_updatePendingInvoiceAdjustments(t *testing.T) {
+	var b bytes.Buffer
+
+	adjustments := []shared.ClientInvoiceAdjustment{{ClientId: 1, AdjustmentId: 2}, {ClientId: 3, AdjustmentId: 4}}
+	_ = json.NewEncoder(&b).Encode(shared.UpdateInvoiceAdjustments{Status: shared.AdjustmentStatusApproved, Adjustments: adjustments})
+	req := httptest.NewRequest(http.MethodPut, "/invoice-adjustments", &b)
+	w := httptest.NewRecorder()
+
+	validator, _ := validation.New()
+
+	mock := &mockService{adjustmentDecisions: shared.InvoiceAdjustmentDecisions{
+		{ClientInvoiceAdjustment: adjustments[0]},
+		{ClientInvoiceAdjustment: adjustments[1], Error: "Unable to update the adjustment"},
+	}}
+	server := NewServer(mock, nil, nil, nil, nil, validator, nil)
+	err := server.updatePendingInvoiceAdjustments(w, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{adjustments, shared.AdjustmentStatusApproved}, mock.lastCalledParams)
+
+	expected := `[{"clientId":1,"adjustmentId":2},{"clientId":3,"adjustmentId":4,"error":"Unable to update the adjustment"}]`
+	assert.Equal(t, expected, strings.TrimSpace(w.Body.String()))
+}
+
+func TestServer_updatePendingInvoiceAdjustmentsValidationError(t *testing.T) {
+	var b bytes.Buffer
+
+	_ = json.NewEncoder(&b).Encode(shared.UpdateInvoiceAdjustments{Status: shared.AdjustmentStatusPending})
+	req := httptest.NewRequest(http.MethodPut, "/invoice-adjustments", &b)
+	w := httptest.NewRecorder()
+
+	validator, _ := validation.New()
+
+	mock := &mockService{}
+	server := NewServer(mock, nil, nil, nil, nil, validator, nil)
+	err := server.updatePendingInvoiceAdjustments(w, req)
+
+	expected := apierror.ValidationError{Errors: apierror.ValidationErrors{
+		"Status": {
+			"oneof": "This field Status needs to be looked at oneof",
+		},
+		"Adjustments": {
+			"required": "This field Adjustments needs to be looked at required",
+		},
+	}}
+	assert.Equal(t, expected, err)
+	assert.Len(t, mock.called, 0)
+}
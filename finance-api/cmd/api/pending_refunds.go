@@ -0,0 +1,42 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+func (s *Server) getPendingRefunds(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	data, err := s.service.GetPendingRefunds(ctx)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(data)
+}
+
+func (s *Server) updateRefundDecisions(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	var body shared.UpdateRefundDecisions
+	defer unchecked(r.Body.Close)
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return err
+	}
+
+	validationError := s.validator.ValidateStruct(body)
+
+	if len(validationError.Errors) != 0 {
+		return validationError
+	}
+
+	data := s.service.UpdateRefundDecisions(ctx, body.Refunds, body.Status)
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(data)
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/validation"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_getPendingRefunds(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/refunds", nil)
+	w := httptest.NewRecorder()
+
+	mock := &mockService{pendingRefunds: shared.PendingRefunds{
+		{
+			ID:              3,
+			ClientId:        1,
+			CourtRef:        "12345678",
+			RaisedDate:      shared.NewDate("2025-01-01"),
+			Amount:          12300,
+			Status:          shared.RefundStatusPending,
+			Notes:           "Refund",
+			CreatedBy:       99,
+			DaysUntilExpiry: 5,
+		},
+	}}
+	server := NewServer(mock, nil, nil, nil, nil, nil, nil)
+	err := server.getPendingRefunds(w, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"GetPendingRefunds"}, mock.called)
+
+	expected := `[{"id":3,"clientId":1,"courtRef":"12345678","raisedDate":"01\/01\/2025","amount":12300,"status":"PENDING","notes":"Refund","createdBy":99,"daysUntilExpiry":5}]`
+	assert.Equal(t, expected, strings.TrimSpace(w.Body.String()))
+}
+
+func TestServer_updateRefundDecisions(t *testing.T) {
+	var b bytes.Buffer
+
+	refunds := []shared.ClientRefund{{ClientId: 1, RefundId: 2}, {ClientId: 3, RefundId: 4}}
+	_ = json.NewEncoder(&b).Encode(shared.UpdateRefundDecisions{Status: shared.RefundStatusApproved, Refunds: refunds})
+	req := httptest.NewRequest(http.MethodPut, "/refunds", &b)
+	w := httptest.NewRecorder()
+
+	validator, _ := validation.New()
+
+	mock := &mockService{refundDecisions: shared.RefundDecisions{
+		{ClientRefund: refunds[0]},
+		{ClientRefund: refunds[1], Error: "Refund is no longer pending"},
+	}}
+	server := NewServer(mock, nil, nil, nil, nil, validator, nil)
+	err := server.updateRefundDecisions(w, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{refunds, shared.RefundStatusApproved}, mock.lastCalledParams)
+
+	expected := `[{"clientId":1,"refundId":2},{"clientId":3,"refundId":4,"error":"Refund is no longer pending"}]`
+	assert.Equal(t, expected, strings.TrimSpace(w.Body.String()))
+}
+
+func TestServer_updateRefundDecisionsValidationError(t *testing.T) {
+	var b bytes.Buffer
+
+	_ = json.NewEncoder(&b).Encode(shared.UpdateRefundDecisions{Status: shared.RefundStatusCancelled})
+	req := httptest.NewRequest(http.MethodPut, "/refunds", &b)
+	w := httptest.NewRecorder()
+
+	validator, _ := validation.New()
+
+	mock := &mockService{}
+	server := NewServer(mock, nil, nil, nil, nil, validator, nil)
+	err := server.updateRefundDecisions(w, req)
+
+	expected := apierror.ValidationError{Errors: apierror.ValidationErrors{
+		"Status": {
+			"oneof": "This field Status needs to be looked at oneof",
+		},
+		"Refunds": {
+			"required": "This field Refunds needs to be looked at required",
+		},
+	}}
+	assert.Equal(t, expected, err)
+	assert.Len(t, mock.called, 0)
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+func (s *Server) previewUpload(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	var upload shared.Upload
+	defer unchecked(r.Body.Close)
+
+	if err := json.NewDecoder(r.Body).Decode(&upload); err != nil {
+		return apierror.BadRequestError("upload", "unable to parse upload", err)
+	}
+
+	fileBytes, err := base64.StdEncoding.DecodeString(upload.Base64Data)
+	if err != nil {
+		return apierror.BadRequestError("upload", "Invalid file data", err)
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(fileBytes)).ReadAll()
+	if err != nil {
+		return apierror.BadRequestError("upload", "Unable to read report", err)
+	}
+
+	s.Logger(ctx).Info(fmt.Sprintf("previewing %s upload", upload.UploadType))
+
+	preview, err := s.service.PreviewUpload(ctx, records, upload.UploadType, upload.UploadDate, upload.PisNumber)
+	if err != nil {
+		return err
+	}
+
+	for i, line := range preview.Lines {
+		if line.Error != "" {
+			preview.Lines[i].Error = uploadErrorMessage(line.Error)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(preview)
+}
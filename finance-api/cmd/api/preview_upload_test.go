@@ -0,0 +1,95 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ministryofjustice/opg-go-common/telemetry"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/auth"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/validation"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func previewRequest(upload shared.Upload) *http.Request {
+	var body bytes.Buffer
+	_ = json.NewEncoder(&body).Encode(upload)
+
+	r := httptest.NewRequest(http.MethodPost, "/uploads/preview", &body)
+	return r.WithContext(auth.Context{
+		Context: telemetry.ContextWithLogger(context.Background(), telemetry.NewLogger("finance-api-test")),
+		User:    &shared.User{ID: 1},
+	})
+}
+
+func TestServer_previewUpload(t *testing.T) {
+	mock := &mockService{uploadPreview: &shared.UploadPreview{
+		UploadType: shared.ReportTypeUploadPaymentsMOTOCard,
+		Lines: []shared.UploadPreviewLine{
+			{
+				Line: 1,
+				Ledgers: []shared.UploadPreviewLedger{
+					{
+						ClientID:           1,
+						CourtRef:           "12345678",
+						LedgerType:         "MOTO CARD PAYMENT",
+						Amount:             1000,
+						Allocations:        []shared.UploadPreviewAllocation{{InvoiceReference: "S203531/19", Amount: 1000, Status: "ALLOCATED"}},
+						OutstandingBalance: 500,
+					},
+				},
+			},
+			{Line: 2, Error: validation.UploadErrorClientNotFound},
+		},
+	}}
+	server := NewServer(mock, nil, nil, nil, nil, nil, nil)
+
+	w := httptest.NewRecorder()
+	err := server.previewUpload(w, previewRequest(shared.Upload{
+		UploadType: shared.ReportTypeUploadPaymentsMOTOCard,
+		Base64Data: base64.StdEncoding.EncodeToString([]byte("col1,col2\nabc,1")),
+		UploadDate: shared.NewDate("2025-01-02"),
+	}))
+	assert.NoError(t, err)
+
+	expected := `{"uploadType":"PAYMENTS_MOTO_CARD","lines":[{"line":1,"ledgers":[{"clientId":1,"courtRef":"12345678","ledgerType":"MOTO CARD PAYMENT","amount":1000,"allocations":[{"invoiceReference":"S203531/19","amount":1000,"status":"ALLOCATED"}],"outstandingBalance":500,"creditBalance":0}]},{"line":2,"error":"Could not find a client with this court reference"}]}`
+
+	assert.Equal(t, expected, strings.TrimSpace(w.Body.String()))
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Equal(t, []string{"PreviewUpload"}, mock.called)
+	assert.Equal(t, [][]string{{"col1", "col2"}, {"abc", "1"}}, mock.lastCalledParams[0])
+}
+
+func TestServer_previewUpload_invalidData(t *testing.T) {
+	mock := &mockService{}
+	server := NewServer(mock, nil, nil, nil, nil, nil, nil)
+
+	err := server.previewUpload(httptest.NewRecorder(), previewRequest(shared.Upload{
+		UploadType: shared.ReportTypeUploadPaymentsMOTOCard,
+		Base64Data: "wrong",
+	}))
+
+	var expected *apierror.BadRequest
+	assert.ErrorAs(t, err, &expected)
+	assert.Nil(t, mock.called)
+}
+
+func TestServer_previewUpload_error(t *testing.T) {
+	mock := &mockService{errs: map[string]error{"PreviewUpload": errors.New("oops")}}
+	server := NewServer(mock, nil, nil, nil, nil, nil, nil)
+
+	err := server.previewUpload(httptest.NewRecorder(), previewRequest(shared.Upload{
+		UploadType: shared.ReportTypeUploadPaymentsMOTOCard,
+		Base64Data: base64.StdEncoding.EncodeToString([]byte("col1,col2\nabc,1")),
+	}))
+
+	assert.EqualError(t, err, "oops")
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/service"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+// processFinanceAdminUpload fetches an upload that has been placed in the async bucket out of band and processes it as
+// if it had been posted to /uploads. Uploads that post ledgers can be large, so they are streamed from the bucket and
+// processed in batches as if they had been posted to /uploads/stream.
+func (s *Server) processFinanceAdminUpload(r *http.Request, detail shared.FinanceAdminUploadEvent) error {
+	ctx := r.Context()
+
+	file, err := s.fileStorage.GetFile(ctx, s.envs.AsyncBucket, detail.Filename)
+	if err != nil {
+		s.Logger(ctx).Error("unable to fetch upload from bucket", "filename", detail.Filename, "err", err)
+		return err
+	}
+	defer unchecked(file.Close)
+
+	if detail.UploadType.PostsLedgers() {
+		_, err = s.startUploadStream(r, service.UploadStream{
+			UploadType: detail.UploadType,
+			UploadDate: detail.UploadDate,
+			PisNumber:  detail.PisNumber,
+			BatchSize:  s.envs.UploadBatchSize,
+		}, detail.EmailAddress, detail.Filename, file)
+		return err
+	}
+
+	fileBytes, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.startUpload(r, shared.Upload{
+		UploadType:   detail.UploadType,
+		EmailAddress: detail.EmailAddress,
+		UploadDate:   detail.UploadDate,
+		PisNumber:    detail.PisNumber,
+		Filename:     detail.Filename,
+	}, fileBytes)
+	return err
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ministryofjustice/opg-go-common/telemetry"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/auth"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/service"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_processFinanceAdminUpload(t *testing.T) {
+	file := []byte("col1, col2\nabc,1")
+	detail := shared.FinanceAdminUploadEvent{
+		EmailAddress: "test@email.com",
+		Filename:     "payments.csv",
+		UploadType:   shared.ReportTypeUploadPaymentsMOTOCard,
+		UploadDate:   shared.NewDate("2025-01-02"),
+		PisNumber:    123,
+	}
+
+	newRequest := func() *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/events", nil)
+		return r.WithContext(auth.Context{
+			Context: telemetry.ContextWithLogger(context.Background(), telemetry.NewLogger("finance-api-test")),
+			User:    &shared.User{ID: 1},
+		})
+	}
+
+	t.Run("creates upload job from bucket file", func(t *testing.T) {
+		// the job is rejected so that processing does not continue in the background
+		duplicate := apierror.BadRequestError("upload", "This file has already been uploaded", nil)
+		mock := &mockService{errs: map[string]error{"CreateUploadJob": duplicate}}
+		fileStorage := &mockFileStorage{data: bytes.NewReader(file)}
+		server := NewServer(mock, nil, fileStorage, &mockNotify{}, nil, nil, &Envs{AsyncBucket: "async"})
+
+		err := server.processFinanceAdminUpload(newRequest(), detail)
+
+		hash := sha256.Sum256(file)
+		assert.ErrorIs(t, err, duplicate)
+		assert.Equal(t, []string{"CreateUploadJob"}, mock.called)
+		assert.Equal(t, []interface{}{service.NewUploadJob{
+			UploadType:   shared.ReportTypeUploadPaymentsMOTOCard,
+			Filename:     "payments.csv",
+			FileHash:     hex.EncodeToString(hash[:]),
+			UploadDate:   shared.NewDate("2025-01-02"),
+			EmailAddress: "test@email.com",
+		}}, mock.lastCalledParams)
+	})
+
+	t.Run("creates upload job for uploads that do not post ledgers", func(t *testing.T) {
+		duplicate := apierror.BadRequestError("upload", "This file has already been uploaded", nil)
+		mock := &mockService{errs: map[string]error{"CreateUploadJob": duplicate}}
+		fileStorage := &mockFileStorage{data: bytes.NewReader(file)}
+		server := NewServer(mock, nil, fileStorage, &mockNotify{}, nil, nil, &Envs{AsyncBucket: "async"})
+
+		schedules := detail
+		schedules.UploadType = shared.ReportTypeUploadRemoveSchedules
+		err := server.processFinanceAdminUpload(newRequest(), schedules)
+
+		hash := sha256.Sum256(file)
+		assert.ErrorIs(t, err, duplicate)
+		assert.Equal(t, []string{"CreateUploadJob"}, mock.called)
+		assert.Equal(t, hex.EncodeToString(hash[:]), mock.lastCalledParams[0].(service.NewUploadJob).FileHash)
+	})
+
+	t.Run("file not found", func(t *testing.T) {
+		mock := &mockService{}
+		fileStorage := &mockFileStorage{data: bytes.NewReader(nil), err: errors.New("not found")}
+		server := NewServer(mock, nil, fileStorage, &mockNotify{}, nil, nil, &Envs{AsyncBucket: "async"})
+
+		err := server.processFinanceAdminUpload(newRequest(), detail)
+
+		assert.EqualError(t, err, "not found")
+		assert.Len(t, mock.called, 0)
+	})
+}
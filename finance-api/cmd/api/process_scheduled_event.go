@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
 )
@@ -12,6 +13,18 @@ func (s *Server) processScheduledEvent(ctx context.Context, event shared.Schedul
 	switch event.Trigger {
 	case shared.ScheduledEventRefundExpiry:
 		return s.service.ExpireRefunds(ctx)
+	case shared.ScheduledEventFailedDirectDebitCollections:
+		date := time.Now().UTC().Truncate(24 * time.Hour)
+		if override, ok := event.Override.(shared.DateOverride); ok && !override.Date.IsNull() {
+			date = override.Date.Time
+		}
+		return s.service.ProcessFailedDirectDebitCollections(ctx, date)
+	case shared.ScheduledEventReportSubscriptions:
+		date := time.Now().UTC().Truncate(24 * time.Hour)
+		if override, ok := event.Override.(shared.DateOverride); ok && !override.Date.IsNull() {
+			date = override.Date.Time
+		}
+		return s.runReportSubscriptions(ctx, date)
 	default:
 		return fmt.Errorf("invalid scheduled event trigger: %s", event.Trigger)
 	}
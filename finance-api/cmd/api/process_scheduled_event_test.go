@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/ministryofjustice/opg-go-common/telemetry"
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/auth"
@@ -37,6 +38,28 @@ func Test_processScheduledEvent(t *testing.T) {
 			hasError:             false,
 			expectedFunctionCall: "ExpireRefunds",
 		},
+		{
+			name: "Failed Direct Debit collections with date override",
+			event: shared.ScheduledEvent{
+				Trigger:  "failed-direct-debit-collections",
+				Override: shared.DateOverride{Date: shared.NewDate("2025-10-13")},
+			},
+			expectedResponse:     nil,
+			hasError:             false,
+			expectedFunctionCall: "ProcessFailedDirectDebitCollections",
+			expectedParams:       []interface{}{time.Date(2025, 10, 13, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name: "Report subscriptions with date override",
+			event: shared.ScheduledEvent{
+				Trigger:  "report-subscriptions",
+				Override: shared.DateOverride{Date: shared.NewDate("2026-10-16")},
+			},
+			expectedResponse:     nil,
+			hasError:             false,
+			expectedFunctionCall: "StartReportSubscriptions",
+			expectedParams:       []interface{}{time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)},
+		},
 	}
 	for _, tt := range tests {
 		ctx := auth.Context{
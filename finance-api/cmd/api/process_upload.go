@@ -3,8 +3,10 @@ package api
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/csv"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -16,6 +18,7 @@ import (
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/auth"
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/notify"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/service"
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/validation"
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
 )
@@ -27,11 +30,10 @@ type Upload struct {
 	PisNumber    int
 	FileBytes    io.Reader
 	Filename     string
+	JobID        int32
 }
 
 func (s *Server) processUpload(w http.ResponseWriter, r *http.Request) error {
-	ctx := r.Context()
-
 	var upload shared.Upload
 	defer unchecked(r.Body.Close)
 
@@ -44,9 +46,33 @@ func (s *Server) processUpload(w http.ResponseWriter, r *http.Request) error {
 		return apierror.BadRequestError("upload", "Invalid file data", err)
 	}
 
-	logger := s.Logger(ctx)
+	job, err := s.startUpload(r, upload, fileBytes)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(job)
+}
 
-	logger.Info(fmt.Sprintf("processing %s upload", upload.UploadType))
+// startUpload records an upload job for the file and processes it in the background, returning the job so the caller
+// can track its progress
+func (s *Server) startUpload(r *http.Request, upload shared.Upload, fileBytes []byte) (shared.UploadJob, error) {
+	ctx := r.Context()
+
+	hash := sha256.Sum256(fileBytes)
+	jobID, err := s.service.CreateUploadJob(ctx, service.NewUploadJob{
+		UploadType:   upload.UploadType,
+		Filename:     upload.Filename,
+		FileHash:     hex.EncodeToString(hash[:]),
+		UploadDate:   upload.UploadDate,
+		EmailAddress: upload.EmailAddress,
+	})
+	if err != nil {
+		return shared.UploadJob{}, err
+	}
+
+	s.Logger(ctx).Info(fmt.Sprintf("processing %s upload", upload.UploadType), "jobId", jobID)
 
 	go func(logger *slog.Logger) {
 		ctx := s.copyCtx(r)
@@ -60,32 +86,42 @@ func (s *Server) processUpload(w http.ResponseWriter, r *http.Request) error {
 			PisNumber:    upload.PisNumber,
 			FileBytes:    bytes.NewReader(fileBytes),
 			Filename:     upload.Filename,
+			JobID:        jobID,
 		})
 	}(telemetry.LoggerFromContext(ctx))
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-
-	return nil
+	return shared.UploadJob{
+		ID:         int(jobID),
+		UploadType: upload.UploadType,
+		Filename:   upload.Filename,
+		Status:     shared.UploadJobStatusProcessing,
+	}, nil
 }
 
 func (s *Server) processUploadFile(ctx context.Context, upload Upload) {
 	var payload notify.Payload
 	var err error
+	var result service.UploadJobResult
 	logger := s.Logger(ctx)
 
+	defer func() {
+		_ = s.service.CompleteUploadJob(ctx, upload.JobID, result)
+	}()
+
 	if upload.UploadType.IsDirectUpload() {
 		err := s.service.ProcessDirectUploadReport(ctx, upload.Filename, upload.FileBytes, upload.UploadType)
 		if err != nil {
 			logger.Error("unable to upload report due to error", "err", err)
 		}
 		payload = createUploadNotifyPayload(upload.EmailAddress, upload.UploadType, err, nil)
+		result.Err = err
 	} else {
 		csvReader := csv.NewReader(upload.FileBytes)
 
 		records, err := csvReader.ReadAll()
 		if err != nil {
 			logger.Error("unable to read report", "err", err)
+			result.Err = err
 			payload := createUploadNotifyPayload(upload.EmailAddress, upload.UploadType, fmt.Errorf("unable to read report"), map[int]string{})
 			err = s.notify.Send(ctx, payload)
 			if err != nil {
@@ -94,8 +130,15 @@ func (s *Server) processUploadFile(ctx context.Context, upload Upload) {
 			return
 		}
 
+		lineCount := len(records)
+		if upload.UploadType.HasHeader() && lineCount > 0 {
+			lineCount--
+		}
+		result.LineCount = &lineCount
+
 		if upload.UploadType.IsPayment() {
 			failedLines, perr := s.service.ProcessPayments(ctx, records, upload.UploadType, upload.UploadDate, upload.PisNumber)
+			result.FailedLines, result.Err = failedLines, perr
 			if perr != nil {
 				logger.Error("unable to process payments due to error", "err", perr)
 			} else if len(failedLines) > 0 {
@@ -104,6 +147,7 @@ func (s *Server) processUploadFile(ctx context.Context, upload Upload) {
 			payload = createUploadNotifyPayload(upload.EmailAddress, upload.UploadType, perr, failedLines)
 		} else if upload.UploadType.IsReversal() {
 			failedLines, perr := s.service.ProcessPaymentReversals(ctx, records, upload.UploadType)
+			result.FailedLines, result.Err = failedLines, perr
 			if perr != nil {
 				logger.Error("unable to process payment reversals due to error", "err", perr)
 			} else if len(failedLines) > 0 {
@@ -112,6 +156,7 @@ func (s *Server) processUploadFile(ctx context.Context, upload Upload) {
 			payload = createUploadNotifyPayload(upload.EmailAddress, upload.UploadType, err, failedLines)
 		} else if upload.UploadType.IsRefund() {
 			failedLines, perr := s.service.ProcessFulfilledRefunds(ctx, records, upload.UploadDate)
+			result.FailedLines, result.Err = failedLines, perr
 			if perr != nil {
 				logger.Error("unable to process fulfilled refunds due to error", "err", perr)
 			} else if len(failedLines) > 0 {
@@ -120,6 +165,7 @@ func (s *Server) processUploadFile(ctx context.Context, upload Upload) {
 			payload = createUploadNotifyPayload(upload.EmailAddress, upload.UploadType, err, failedLines)
 		} else if upload.UploadType.IsRefundReversal() {
 			failedLines, perr := s.service.ProcessRefundReversals(ctx, records, upload.UploadDate)
+			result.FailedLines, result.Err = failedLines, perr
 			if perr != nil {
 				logger.Error("unable to process reverse refunds due to error", "err", perr)
 			} else if len(failedLines) > 0 {
@@ -128,12 +174,23 @@ func (s *Server) processUploadFile(ctx context.Context, upload Upload) {
 			payload = createUploadNotifyPayload(upload.EmailAddress, upload.UploadType, err, failedLines)
 		} else if upload.UploadType.IsScheduleRemoval() {
 			failedLines := s.service.QueueScheduleRemovals(ctx, records, upload.UploadDate)
+			result.FailedLines = failedLines
 			if len(failedLines) > 0 {
 				logger.Error(fmt.Sprintf("unable to process schedule removal due to %d failed lines", len(failedLines)))
 			}
 			payload = createUploadNotifyPayload(upload.EmailAddress, upload.UploadType, err, failedLines)
+		} else if upload.UploadType.IsDeputySchedule() {
+			failedLines, perr := s.service.ProcessDeputySchedule(ctx, records)
+			result.FailedLines, result.Err = failedLines, perr
+			if perr != nil {
+				logger.Error("unable to process deputy schedule due to error", "err", perr)
+			} else if len(failedLines) > 0 {
+				logger.Error(fmt.Sprintf("unable to process deputy schedule due to %d failed lines", len(failedLines)))
+			}
+			payload = createUploadNotifyPayload(upload.EmailAddress, upload.UploadType, perr, failedLines)
 		} else {
 			logger.Error("invalid upload type", "type", upload.UploadType)
+			result.Err = fmt.Errorf("invalid upload type")
 			payload = createUploadNotifyPayload(upload.EmailAddress, upload.UploadType, fmt.Errorf("invalid upload type"), map[int]string{})
 		}
 	}
@@ -145,7 +202,6 @@ func (s *Server) processUploadFile(ctx context.Context, upload Upload) {
 }
 
 func formatFailedLines(failedLines map[int]string) []string {
-	var errorMessage string
 	var formattedLines []string
 	var keys []int
 	for i := range failedLines {
@@ -155,44 +211,56 @@ func formatFailedLines(failedLines map[int]string) []string {
 	slices.Sort(keys)
 
 	for _, key := range keys {
-		failedLine := failedLines[key]
-		errorMessage = ""
-
-		switch failedLine {
-		case validation.UploadErrorDateParse:
-			errorMessage = "Unable to parse date - please use the format DD/MM/YYYY"
-		case validation.UploadErrorDateTimeParse:
-			errorMessage = "Unable to parse date - please use the format YYYY-MM-DD HH:MM:SS"
-		case validation.UploadErrorAmountParse:
-			errorMessage = "Unable to parse amount - please use the format 320.00"
-		case validation.UploadErrorClientNotFound:
-			errorMessage = "Could not find a client with this court reference"
-		case validation.UploadErrorPaymentTypeParse:
-			errorMessage = "Unable to parse payment type"
-		case validation.UploadErrorUnknownUploadType:
-			errorMessage = "Unknown upload type"
-		case validation.UploadErrorNoMatchedPayment:
-			errorMessage = "Unable to find a matched payment to reverse"
-		case validation.UploadErrorReversalClientNotFound:
-			errorMessage = "Could not find client with this court reference [New (correct) court reference]"
-		case validation.UploadErrorDuplicateReversal:
-			errorMessage = "This payment has already been reversed"
-		case validation.UploadErrorRefundNotFound:
-			errorMessage = "The refund could not be found - either the data does not match or the refund has been cancelled"
-		case validation.UploadErrorRefundForReversalNotFound:
-			errorMessage = "The refund to reverse could not be found - either the data does not match or the refund has not been fulfilled"
-		case validation.UploadErrorMaximumDebt:
-			errorMessage = "Payment could not be reversed - maximum invoice debt exceeded"
-		case validation.UploadErrorDuplicatePayment:
-			errorMessage = "Duplicate payment line"
-		}
-
-		formattedLines = append(formattedLines, fmt.Sprintf("Line %d: %s", key, errorMessage))
+		formattedLines = append(formattedLines, fmt.Sprintf("Line %d: %s", key, uploadErrorMessage(failedLines[key])))
 	}
 
 	return formattedLines
 }
 
+func uploadErrorMessage(code string) string {
+	switch code {
+	case validation.UploadErrorDateParse:
+		return "Unable to parse date - please use the format DD/MM/YYYY"
+	case validation.UploadErrorDateTimeParse:
+		return "Unable to parse date - please use the format YYYY-MM-DD HH:MM:SS"
+	case validation.UploadErrorAmountParse:
+		return "Unable to parse amount - please use the format 320.00"
+	case validation.UploadErrorClientNotFound:
+		return "Could not find a client with this court reference"
+	case validation.UploadErrorPaymentTypeParse:
+		return "Unable to parse payment type"
+	case validation.UploadErrorUnknownUploadType:
+		return "Unknown upload type"
+	case validation.UploadErrorNoMatchedPayment:
+		return "Unable to find a matched payment to reverse"
+	case validation.UploadErrorReversalClientNotFound:
+		return "Could not find client with this court reference [New (correct) court reference]"
+	case validation.UploadErrorDuplicateReversal:
+		return "This payment has already been reversed"
+	case validation.UploadErrorRefundNotFound:
+		return "The refund could not be found - either the data does not match or the refund has been cancelled"
+	case validation.UploadErrorRefundForReversalNotFound:
+		return "The refund to reverse could not be found - either the data does not match or the refund has not been fulfilled"
+	case validation.UploadErrorMaximumDebt:
+		return "Payment could not be reversed - maximum invoice debt exceeded"
+	case validation.UploadErrorDuplicatePayment:
+		return "Duplicate payment line"
+	case validation.UploadErrorDeputyNotFound:
+		return "Could not find this deputy number on the case for this court reference"
+	case validation.UploadErrorDeputyNumberParse:
+		return "Unable to parse deputy number - please use a whole number"
+	case validation.UploadErrorDoNotInvoiceParse:
+		return "Unable to parse do not invoice - please use Yes or No"
+	case validation.UploadErrorBalanceMismatch:
+		return "Total outstanding does not match the client's balance"
+	case validation.UploadErrorHeldInSuspense:
+		return "Could not find a client with this court reference - the payment is held in suspense"
+	case validation.UploadErrorAccountingPeriodClosed:
+		return "The accounting period for this date has been closed"
+	}
+	return ""
+}
+
 func createUploadNotifyPayload(email string, uploadType shared.ReportUploadType, err error, failedLines map[int]string) notify.Payload {
 	var payload notify.Payload
 
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -13,6 +14,7 @@ import (
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/auth"
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/notify"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/service"
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
 	"github.com/stretchr/testify/assert"
 )
@@ -68,6 +70,40 @@ func Test_processUpload(t *testing.T) {
 	}
 }
 
+func Test_processUpload_duplicateFile(t *testing.T) {
+	mock := &mockService{errs: map[string]error{"CreateUploadJob": apierror.BadRequestError("upload", "This file has already been uploaded", nil)}}
+	server := NewServer(mock, nil, nil, &mockNotify{}, nil, nil, nil)
+
+	upload := shared.Upload{
+		UploadType:   shared.ReportTypeUploadPaymentsMOTOCard,
+		EmailAddress: "test@email.com",
+		Base64Data:   base64.StdEncoding.EncodeToString([]byte("col1, col2\nabc,1")),
+		Filename:     "moto.csv",
+		UploadDate:   shared.NewDate("2025-01-02"),
+	}
+
+	var body bytes.Buffer
+	_ = json.NewEncoder(&body).Encode(upload)
+	r := httptest.NewRequest(http.MethodPost, "/uploads", &body)
+	r = r.WithContext(auth.Context{
+		Context: telemetry.ContextWithLogger(context.Background(), telemetry.NewLogger("finance-api-test")),
+		User:    &shared.User{ID: 1},
+	})
+
+	err := server.processUpload(httptest.NewRecorder(), r)
+
+	var e *apierror.BadRequest
+	assert.ErrorAs(t, err, &e)
+	assert.Equal(t, []string{"CreateUploadJob"}, mock.called)
+	assert.Equal(t, service.NewUploadJob{
+		UploadType:   shared.ReportTypeUploadPaymentsMOTOCard,
+		Filename:     "moto.csv",
+		FileHash:     "4f08e414dba52b6bbf4ece44ae270f8977d988ed3fad61ab22af2bd4a36e356e",
+		UploadDate:   shared.NewDate("2025-01-02"),
+		EmailAddress: "test@email.com",
+	}, mock.lastCalledParams[0])
+}
+
 func Test_processUploadFile(t *testing.T) {
 	tests := []struct {
 		name                string
@@ -209,6 +245,22 @@ func Test_processUploadFile(t *testing.T) {
 			},
 			expectedServiceCall: "QueueScheduleRemovals",
 		},
+		{
+			name: "Deputy schedule",
+			upload: Upload{
+				UploadType:   shared.ReportTypeUploadDeputySchedule,
+				EmailAddress: "test@email.com",
+				FileBytes:    bytes.NewReader([]byte("col1, col2\nabc,1")),
+			},
+			expectedPayload: notify.Payload{
+				EmailAddress: "test@email.com",
+				TemplateId:   notify.ProcessingSuccessTemplateId,
+				Personalisation: struct {
+					UploadType string `json:"upload_type"`
+				}{"Deputy schedule"},
+			},
+			expectedServiceCall: "ProcessDeputySchedule",
+		},
 	}
 	for _, tt := range tests {
 		notifyClient := &mockNotify{}
@@ -224,13 +276,48 @@ func Test_processUploadFile(t *testing.T) {
 		server.processUploadFile(ctx, tt.upload)
 		assert.Equal(t, tt.expectedPayload, notifyClient.payload, tt.name)
 		if tt.expectedServiceCall != "" {
-			assert.Equal(t, tt.expectedServiceCall, service.called[0], tt.name)
+			assert.Equal(t, []string{tt.expectedServiceCall, "CompleteUploadJob"}, service.called, tt.name)
 		} else {
-			assert.Len(t, service.called, 0, tt.name)
+			assert.Equal(t, []string{"CompleteUploadJob"}, service.called, tt.name)
 		}
 	}
 }
 
+func Test_processUploadFile_completesJob(t *testing.T) {
+	ctx := auth.Context{
+		Context: telemetry.ContextWithLogger(context.Background(), telemetry.NewLogger("finance-api-test")),
+		User:    &shared.User{ID: 1},
+	}
+
+	t.Run("records line count", func(t *testing.T) {
+		mock := &mockService{}
+		server := NewServer(mock, nil, nil, &mockNotify{}, nil, nil, nil)
+
+		server.processUploadFile(ctx, Upload{
+			UploadType: shared.ReportTypeUploadPaymentsMOTOCard,
+			FileBytes:  bytes.NewReader([]byte("col1, col2\nabc,1\ndef,2")),
+			JobID:      5,
+		})
+
+		lineCount := 2
+		assert.Equal(t, []interface{}{int32(5), service.UploadJobResult{LineCount: &lineCount}}, mock.lastCalledParams)
+	})
+
+	t.Run("records error", func(t *testing.T) {
+		mock := &mockService{errs: map[string]error{"ProcessPayments": errors.New("oops")}}
+		server := NewServer(mock, nil, nil, &mockNotify{}, nil, nil, nil)
+
+		server.processUploadFile(ctx, Upload{
+			UploadType: shared.ReportTypeUploadPaymentsMOTOCard,
+			FileBytes:  bytes.NewReader([]byte("col1, col2\nabc,1")),
+			JobID:      5,
+		})
+
+		result := mock.lastCalledParams[1].(service.UploadJobResult)
+		assert.EqualError(t, result.Err, "oops")
+	})
+}
+
 func Test_formatFailedLines(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -273,6 +360,11 @@ func Test_formatFailedLines(t *testing.T) {
 				11: "REFUND_NOT_FOUND_FOR_REVERSAL",
 				12: "MAXIMUM_DEBT",
 				13: "DUPLICATE_PAYMENT",
+				14: "DEPUTY_NOT_FOUND",
+				15: "DO_NOT_INVOICE_PARSE_ERROR",
+				16: "BALANCE_MISMATCH",
+				17: "HELD_IN_SUSPENSE",
+				18: "DEPUTY_NUMBER_PARSE_ERROR",
 			},
 			want: []string{
 				"Line 1: Unable to parse date - please use the format DD/MM/YYYY",
@@ -288,6 +380,11 @@ func Test_formatFailedLines(t *testing.T) {
 				"Line 11: The refund to reverse could not be found - either the data does not match or the refund has not been fulfilled",
 				"Line 12: Payment could not be reversed - maximum invoice debt exceeded",
 				"Line 13: Duplicate payment line",
+				"Line 14: Could not find this deputy number on the case for this court reference",
+				"Line 15: Unable to parse do not invoice - please use Yes or No",
+				"Line 16: Total outstanding does not match the client's balance",
+				"Line 17: Could not find a client with this court reference - the payment is held in suspense",
+				"Line 18: Unable to parse deputy number - please use a whole number",
 			},
 		},
 	}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+func (s *Server) getFailedEvents(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	events, err := s.service.GetFailedProcessedEvents(ctx)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(events)
+}
+
+// replayEvents processes the selected failed events again, as if they had just been received. Each event is replayed
+// independently, so the outcome is returned for each one.
+func (s *Server) replayEvents(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	var body shared.ReplayEvents
+	defer unchecked(r.Body.Close)
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return err
+	}
+
+	validationError := s.validator.ValidateStruct(body)
+
+	if len(validationError.Errors) != 0 {
+		return validationError
+	}
+
+	replays := shared.EventReplays{}
+	for _, id := range body.IDs {
+		payload, err := s.service.ReplayProcessedEvent(ctx, int32(id))
+		var badRequest *apierror.BadRequest
+		if errors.As(err, &badRequest) {
+			replays = append(replays, shared.EventReplay{ID: id, Status: shared.EventReplayStatusSkipped, Error: badRequest.Reason})
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		var event shared.Event
+		err = json.Unmarshal(payload, &event)
+		if err == nil {
+			err = s.processEvent(r, event)
+		}
+		_ = s.service.CompleteProcessedEvent(ctx, int32(id), err)
+
+		replay := shared.EventReplay{ID: id, Status: shared.ProcessedEventStatusProcessed}
+		if err != nil {
+			replay.Status = shared.ProcessedEventStatusFailed
+			replay.Error = err.Error()
+		}
+		replays = append(replays, replay)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(replays)
+}
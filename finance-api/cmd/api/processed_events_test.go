@@ -0,0 +1,99 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ministryofjustice/opg-go-common/telemetry"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/validation"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_getFailedEvents(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/events/failed", nil)
+	w := httptest.NewRecorder()
+
+	mock := &mockService{failedEvents: shared.ProcessedEvents{
+		{
+			ID:          1,
+			Source:      shared.EventSourceSirius,
+			DetailType:  shared.DetailTypeInvoiceCreated,
+			EventKey:    "a1b2c3",
+			Attempts:    2,
+			Error:       "allpay unavailable",
+			ReceivedAt:  time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC),
+			ProcessedAt: shared.Nillable[time.Time]{Value: time.Date(2025, 1, 2, 9, 1, 0, 0, time.UTC), Valid: true},
+		},
+	}}
+	server := NewServer(mock, nil, nil, nil, nil, nil, nil)
+	err := server.getFailedEvents(w, req)
+	assert.NoError(t, err)
+
+	expected := `[{"id":1,"source":"opg.supervision.sirius","detailType":"invoice-created","eventKey":"a1b2c3","attempts":2,"error":"allpay unavailable","receivedAt":"2025-01-02T09:00:00Z","processedAt":{"Value":"2025-01-02T09:01:00Z","Valid":true}}]`
+
+	assert.Equal(t, expected, strings.TrimSpace(w.Body.String()))
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+}
+
+func TestServer_replayEvents(t *testing.T) {
+	clientMadeInactive, _ := json.Marshal(shared.Event{
+		Source:     shared.EventSourceSirius,
+		DetailType: shared.DetailTypeClientMadeInactive,
+		Detail:     shared.ClientMadeInactiveEvent{ClientID: 1, CourtRef: "12345678", Surname: "Smith"},
+	})
+	invoiceCreated, _ := json.Marshal(shared.Event{
+		Source:     shared.EventSourceSirius,
+		DetailType: shared.DetailTypeInvoiceCreated,
+		Detail:     shared.InvoiceCreatedEvent{ClientID: 1, InvoiceID: 2, InvoiceType: shared.InvoiceTypeAD},
+	})
+
+	var body bytes.Buffer
+	_ = json.NewEncoder(&body).Encode(shared.ReplayEvents{IDs: []int{1, 2, 3}})
+	req := httptest.NewRequest(http.MethodPost, "/events/replay", &body)
+	req = req.WithContext(telemetry.ContextWithLogger(req.Context(), telemetry.NewLogger("test")))
+	w := httptest.NewRecorder()
+
+	mock := &mockService{
+		eventPayloads: map[int32][]byte{1: clientMadeInactive, 2: invoiceCreated},
+		errs:          map[string]error{"ApplyInvoiceFeeReduction": errors.New("fee reduction error")},
+	}
+	validator, _ := validation.New()
+	server := NewServer(mock, nil, nil, nil, nil, validator, nil)
+	err := server.replayEvents(w, req)
+	assert.NoError(t, err)
+
+	var replays shared.EventReplays
+	_ = json.NewDecoder(w.Body).Decode(&replays)
+
+	assert.Equal(t, shared.EventReplays{
+		{ID: 1, Status: shared.ProcessedEventStatusProcessed},
+		{ID: 2, Status: shared.ProcessedEventStatusFailed, Error: "fee reduction error"},
+		{ID: 3, Status: shared.EventReplayStatusSkipped, Error: "Only failed or interrupted events can be replayed"},
+	}, replays)
+	assert.Equal(t, []string{"ReplayProcessedEvent", "CancelDirectDebitMandate", "ReplayProcessedEvent", "ApplyInvoiceFeeReduction", "ReplayProcessedEvent"}, mock.called)
+	assert.Len(t, mock.completedEvents, 2)
+	assert.Nil(t, mock.completedEvents[1])
+	assert.Error(t, mock.completedEvents[2])
+}
+
+func TestServer_replayEvents_validation(t *testing.T) {
+	var body bytes.Buffer
+	_ = json.NewEncoder(&body).Encode(shared.ReplayEvents{})
+	req := httptest.NewRequest(http.MethodPost, "/events/replay", &body)
+	w := httptest.NewRecorder()
+
+	mock := &mockService{}
+	validator, _ := validation.New()
+	server := NewServer(mock, nil, nil, nil, nil, validator, nil)
+	err := server.replayEvents(w, req)
+
+	assert.Error(t, err)
+	assert.Empty(t, mock.called)
+}
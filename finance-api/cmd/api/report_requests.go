@@ -0,0 +1,35 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+func (s *Server) getReportRequests(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	reports, err := s.service.GetReportRequests(ctx)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(reports)
+}
+
+func (s *Server) getReportRequest(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	id, err := s.getPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	report, err := s.service.GetReportRequest(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(report)
+}
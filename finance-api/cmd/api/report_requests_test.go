@@ -0,0 +1,150 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/ministryofjustice/opg-go-common/telemetry"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/auth"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_getReportRequests(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/reports", nil)
+	w := httptest.NewRecorder()
+
+	debtType := shared.DebtTypeFeeChase
+	mock := &mockService{requestedReports: shared.RequestedReports{
+		{
+			ID:            2,
+			ReportRequest: shared.ReportRequest{ReportType: shared.ReportsTypeDebt, DebtType: &debtType, Email: "test@example.com"},
+			Status:        shared.RequestedReportStatusCompleted,
+			RequestedAt:   time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC),
+			RequestedBy:   1,
+			CompletedAt:   shared.Nillable[time.Time]{Value: time.Date(2026, 10, 16, 9, 0, 3, 0, time.UTC), Valid: true},
+			DurationMs:    3000,
+			RowCount:      12,
+			Key:           "debt_FeeChase_16:10:2026.csv",
+			VersionID:     "v1",
+		},
+	}}
+	server := NewServer(mock, nil, nil, nil, nil, nil, nil)
+	err := server.getReportRequests(w, req)
+	assert.NoError(t, err)
+
+	expected := `[{"id":2,"reportRequest":{"reportType":"Debt","debtType":"FeeChase","email":"test@example.com","pisNumber":0},"status":"COMPLETED","requestedAt":"2026-10-16T09:00:00Z","requestedBy":1,"completedAt":{"Value":"2026-10-16T09:00:03Z","Valid":true},"durationMs":3000,"rowCount":12,"key":"debt_FeeChase_16:10:2026.csv","versionId":"v1"}]`
+
+	assert.Equal(t, expected, strings.TrimSpace(w.Body.String()))
+	assert.Equal(t, []string{"GetReportRequests"}, mock.called)
+}
+
+func TestServer_getReportRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/reports/2", nil)
+	req.SetPathValue("id", "2")
+	w := httptest.NewRecorder()
+
+	mock := &mockService{requestedReport: &shared.RequestedReport{
+		ID:          2,
+		Status:      shared.RequestedReportStatusRunning,
+		RequestedAt: time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC),
+		RequestedBy: 1,
+	}}
+	server := NewServer(mock, nil, nil, nil, nil, nil, nil)
+	err := server.getReportRequest(w, req)
+	assert.NoError(t, err)
+
+	expected := `{"id":2,"reportRequest":{"reportType":"","email":"","pisNumber":0},"status":"RUNNING","requestedAt":"2026-10-16T09:00:00Z","requestedBy":1,"completedAt":{"Value":"0001-01-01T00:00:00Z","Valid":false},"rowCount":0}`
+
+	assert.Equal(t, expected, strings.TrimSpace(w.Body.String()))
+	assert.Equal(t, []int{2}, mock.expectedIds)
+}
+
+func TestServer_getReportRequestNotFound(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/reports/2", nil)
+	req.SetPathValue("id", "2")
+	w := httptest.NewRecorder()
+
+	mock := &mockService{errs: map[string]error{"GetReportRequest": apierror.NotFoundError(pgx.ErrNoRows)}}
+	server := NewServer(mock, nil, nil, nil, nil, nil, nil)
+	err := server.getReportRequest(w, req)
+
+	var e *apierror.NotFound
+	assert.ErrorAs(t, err, &e)
+}
+
+func TestServer_generateReportRecordsOutcome(t *testing.T) {
+	ctx := auth.Context{
+		Context: telemetry.ContextWithLogger(context.Background(), telemetry.NewLogger("finance-api-test")),
+		User:    &shared.User{ID: 1},
+	}
+
+	debtType := shared.DebtTypeFeeChase
+	reportRequest := shared.ReportRequest{ReportType: shared.ReportsTypeDebt, DebtType: &debtType, Email: "test@example.com"}
+
+	reports := &MockReports{err: errors.New("bucket unavailable")}
+	mock := &mockService{reportRequestID: 7}
+	server := NewServer(mock, reports, nil, nil, nil, nil, nil)
+
+	id, err := server.startReportRequest(ctx, reportRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(7), id)
+
+	_, running := server.runningReports.Load(id)
+	assert.True(t, running)
+
+	server.generateReport(ctx, id, reportRequest)
+
+	assert.Equal(t, map[int32]error{7: reports.err}, mock.completedReports)
+	_, running = server.runningReports.Load(id)
+	assert.False(t, running)
+}
+
+func TestServer_startReportRequestError(t *testing.T) {
+	ctx := auth.Context{
+		Context: telemetry.ContextWithLogger(context.Background(), telemetry.NewLogger("finance-api-test")),
+		User:    &shared.User{ID: 1},
+	}
+
+	debtType := shared.DebtTypeFeeChase
+	reportRequest := shared.ReportRequest{ReportType: shared.ReportsTypeDebt, DebtType: &debtType, Email: "test@example.com"}
+
+	reports := &MockReports{}
+	mock := &mockService{errs: map[string]error{"CreateReportRequest": errors.New("database unavailable")}}
+	server := NewServer(mock, reports, nil, nil, nil, nil, nil)
+
+	_, err := server.startReportRequest(ctx, reportRequest)
+	assert.EqualError(t, err, "database unavailable")
+
+	ids := 0
+	server.runningReports.Range(func(_, _ any) bool {
+		ids++
+		return true
+	})
+	assert.Equal(t, 0, ids)
+	assert.Nil(t, reports.requestedReport)
+}
+
+func TestServer_InterruptRunningReports(t *testing.T) {
+	ctx := telemetry.ContextWithLogger(context.Background(), telemetry.NewLogger("finance-api-test"))
+
+	mock := &mockService{}
+	server := NewServer(mock, nil, nil, nil, nil, nil, nil)
+
+	err := server.InterruptRunningReports(ctx)
+	assert.NoError(t, err)
+	assert.Nil(t, mock.interruptedReports)
+
+	server.runningReports.Store(int32(3), struct{}{})
+
+	err = server.InterruptRunningReports(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []int32{3}, mock.interruptedReports)
+}
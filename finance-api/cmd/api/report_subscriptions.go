@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/service"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+func (s *Server) getReportSubscriptions(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	subscriptions, err := s.service.GetReportSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(subscriptions)
+}
+
+func (s *Server) addReportSubscription(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	var body shared.AddReportSubscription
+	defer unchecked(r.Body.Close)
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return err
+	}
+
+	validationError := s.validator.ValidateStruct(body)
+
+	if len(validationError.Errors) != 0 {
+		return validationError
+	}
+
+	// the template is validated as it will be requested, using the first recipient and standing in yesterday for any
+	// relative dates
+	yesterday := shared.Date{Time: time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -1)}
+	reportRequest := body.ReportRequest
+	reportRequest.Email = body.Recipients[0]
+	if body.TransactionDate != nil {
+		reportRequest.TransactionDate = &yesterday
+	}
+	if body.FromDate != nil {
+		reportRequest.FromDate = &yesterday
+	}
+	if body.ToDate != nil {
+		reportRequest.ToDate = &yesterday
+	}
+
+	err := s.validateReportRequest(reportRequest)
+	if err != nil {
+		return err
+	}
+
+	err = s.service.AddReportSubscription(ctx, body)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	return nil
+}
+
+func (s *Server) cancelReportSubscription(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	id, err := s.getPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	err = s.service.CancelReportSubscription(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// runReportSubscriptions requests the subscribed reports due on the date. The reports are generated one after another
+// in the background, and the post-report actions are run once each subscription has been sent to all its recipients.
+func (s *Server) runReportSubscriptions(ctx context.Context, date time.Time) error {
+	reports, err := s.service.StartReportSubscriptions(ctx, date)
+	if err != nil {
+		return err
+	}
+
+	s.Logger(ctx).Info(fmt.Sprintf("requesting %d subscribed reports", len(reports)))
+	s.asyncRequestSubscribedReports(s.detachCtx(ctx), reports)
+	return nil
+}
+
+func (s *Server) asyncRequestSubscribedReports(ctx context.Context, reports []service.SubscribedReport) {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				fmt.Printf("Recovered from panic in asyncRequestSubscribedReports: %v\n%s", r, debug.Stack())
+			}
+		}()
+
+		for _, report := range reports {
+			for _, recipient := range report.Recipients {
+				reportRequest := report.ReportRequest
+				reportRequest.Email = recipient
+				id, err := s.startReportRequest(ctx, reportRequest)
+				if err != nil {
+					s.Logger(ctx).Error("unable to request subscribed report", "recipient", recipient, "error", err)
+					continue
+				}
+				s.generateReport(ctx, id, reportRequest)
+			}
+			s.service.PostReportActions(ctx, report.ReportRequest)
+		}
+
+		if s.onReportRequested != nil {
+			s.onReportRequested()
+		}
+	}()
+}
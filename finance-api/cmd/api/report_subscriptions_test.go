@@ -0,0 +1,206 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ministryofjustice/opg-go-common/telemetry"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/auth"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/service"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/validation"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_getReportSubscriptions(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/report-subscriptions", nil)
+	w := httptest.NewRecorder()
+
+	debtType := shared.DebtTypeFeeChase
+	previousWorkingDay := shared.RelativeDatePreviousWorkingDay
+	mock := &mockService{reportSubscriptions: shared.ReportSubscriptions{
+		{
+			ID:              1,
+			Name:            "Daily fee chase",
+			ReportRequest:   shared.ReportRequest{ReportType: shared.ReportsTypeDebt, DebtType: &debtType},
+			Recipients:      []string{"finance@example.com"},
+			Cadence:         shared.ReportCadenceDaily,
+			TransactionDate: &previousWorkingDay,
+			CreatedAt:       time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC),
+			CreatedBy:       3,
+		},
+	}}
+	server := NewServer(mock, nil, nil, nil, nil, nil, nil)
+	err := server.getReportSubscriptions(w, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"GetReportSubscriptions"}, mock.called)
+
+	var got shared.ReportSubscriptions
+	_ = json.NewDecoder(w.Body).Decode(&got)
+	assert.Equal(t, mock.reportSubscriptions, got)
+}
+
+func TestServer_addReportSubscription(t *testing.T) {
+	var b bytes.Buffer
+
+	debtType := shared.DebtTypeFeeChase
+	previousWorkingDay := shared.RelativeDatePreviousWorkingDay
+	subscription := shared.AddReportSubscription{
+		Name:            "Daily fee chase",
+		ReportRequest:   shared.ReportRequest{ReportType: shared.ReportsTypeDebt, DebtType: &debtType},
+		Recipients:      []string{"finance@example.com", "debt@example.com"},
+		Cadence:         shared.ReportCadenceDaily,
+		TransactionDate: &previousWorkingDay,
+	}
+	_ = json.NewEncoder(&b).Encode(subscription)
+	req := httptest.NewRequest(http.MethodPost, "/report-subscriptions", &b)
+	w := httptest.NewRecorder()
+
+	validator, _ := validation.New()
+
+	mock := &mockService{}
+	server := NewServer(mock, nil, nil, nil, nil, validator, nil)
+	err := server.addReportSubscription(w, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+	assert.Equal(t, []string{"AddReportSubscription"}, mock.called)
+	assert.Equal(t, []interface{}{subscription}, mock.lastCalledParams)
+}
+
+func TestServer_addReportSubscriptionValidationErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		subscription shared.AddReportSubscription
+		field        string
+	}{
+		{
+			name: "invalid subscription",
+			subscription: shared.AddReportSubscription{
+				Name:          "Weekly aged debt",
+				ReportRequest: shared.ReportRequest{ReportType: shared.ReportsTypeDebt},
+				Recipients:    []string{"not an email"},
+				Cadence:       "FORTNIGHTLY",
+			},
+			field: "Cadence",
+		},
+		{
+			name: "invalid report request",
+			subscription: shared.AddReportSubscription{
+				Name:          "Weekly aged debt",
+				ReportRequest: shared.ReportRequest{ReportType: shared.ReportsTypeAccountsReceivable},
+				Recipients:    []string{"finance@example.com"},
+				Cadence:       shared.ReportCadenceWeekly,
+			},
+			field: "AccountsReceivableType",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+
+			_ = json.NewEncoder(&b).Encode(tt.subscription)
+			req := httptest.NewRequest(http.MethodPost, "/report-subscriptions", &b)
+			w := httptest.NewRecorder()
+
+			validator, _ := validation.New()
+
+			mock := &mockService{}
+			server := NewServer(mock, nil, nil, nil, nil, validator, nil)
+			err := server.addReportSubscription(w, req)
+
+			var e apierror.ValidationError
+			assert.ErrorAs(t, err, &e)
+			assert.Contains(t, e.Errors, tt.field)
+			assert.Len(t, mock.called, 0)
+		})
+	}
+}
+
+func TestServer_cancelReportSubscription(t *testing.T) {
+	req := httptest.NewRequest(http.MethodDelete, "/report-subscriptions/4", nil)
+	req.SetPathValue("id", "4")
+	w := httptest.NewRecorder()
+
+	mock := &mockService{}
+	server := NewServer(mock, nil, nil, nil, nil, nil, nil)
+	err := server.cancelReportSubscription(w, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, w.Result().StatusCode)
+	assert.Equal(t, []int{4}, mock.expectedIds)
+}
+
+func TestServer_cancelReportSubscriptionNotFound(t *testing.T) {
+	req := httptest.NewRequest(http.MethodDelete, "/report-subscriptions/4", nil)
+	req.SetPathValue("id", "4")
+	w := httptest.NewRecorder()
+
+	mock := &mockService{errs: map[string]error{"CancelReportSubscription": apierror.NotFoundError(errors.New("not found"))}}
+	server := NewServer(mock, nil, nil, nil, nil, nil, nil)
+	err := server.cancelReportSubscription(w, req)
+
+	var e *apierror.NotFound
+	assert.ErrorAs(t, err, &e)
+}
+
+func TestServer_runReportSubscriptions(t *testing.T) {
+	ctx := auth.Context{
+		Context: telemetry.ContextWithLogger(context.Background(), telemetry.NewLogger("finance-api-test")),
+		User:    &shared.User{ID: 1},
+	}
+
+	debtType := shared.DebtTypeApprovedRefunds
+	transactionDate := shared.NewDate("2026-10-15")
+	reportRequest := shared.ReportRequest{ReportType: shared.ReportsTypeDebt, DebtType: &debtType, TransactionDate: &transactionDate}
+
+	reports := &MockReports{}
+	mock := &mockService{subscribedReports: []service.SubscribedReport{
+		{ReportRequest: reportRequest, Recipients: []string{"finance@example.com", "debt@example.com"}},
+	}}
+	server := NewServer(mock, reports, nil, nil, nil, nil, nil)
+
+	done := make(chan struct{})
+	server.onReportRequested = func() {
+		close(done)
+	}
+
+	err := server.runReportSubscriptions(ctx, time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for subscribed reports to complete")
+	}
+
+	assert.Len(t, reports.requestedReports, 2)
+	assert.Equal(t, "finance@example.com", reports.requestedReports[0].Email)
+	assert.Equal(t, "debt@example.com", reports.requestedReports[1].Email)
+	assert.Equal(t, &transactionDate, reports.requestedReports[1].TransactionDate)
+
+	// post-report actions run once the report has gone to every recipient
+	assert.Equal(t, []string{"StartReportSubscriptions", "PostReportActions"}, mock.called)
+}
+
+func TestServer_runReportSubscriptionsError(t *testing.T) {
+	ctx := auth.Context{
+		Context: telemetry.ContextWithLogger(context.Background(), telemetry.NewLogger("finance-api-test")),
+		User:    &shared.User{ID: 1},
+	}
+
+	mock := &mockService{errs: map[string]error{"StartReportSubscriptions": errors.New("something is wrong")}}
+	server := NewServer(mock, &MockReports{}, nil, nil, nil, nil, nil)
+
+	err := server.runReportSubscriptions(ctx, time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC))
+	assert.EqualError(t, err, "something is wrong")
+	assert.Equal(t, []string{"StartReportSubscriptions"}, mock.called)
+}
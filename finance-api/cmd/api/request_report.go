@@ -39,7 +39,11 @@ func (s *Server) requestReport(w http.ResponseWriter, r *http.Request) error {
 		}
 	}
 
-	s.asyncRequestReport(s.copyCtx(r), reportRequest)
+	id, err := s.startReportRequest(r.Context(), reportRequest)
+	if err != nil {
+		return err
+	}
+	s.asyncRequestReport(s.copyCtx(r), id, reportRequest)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
@@ -47,7 +51,7 @@ func (s *Server) requestReport(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
-func (s *Server) asyncRequestReport(ctx context.Context, reportRequest shared.ReportRequest) {
+func (s *Server) asyncRequestReport(ctx context.Context, id int32, reportRequest shared.ReportRequest) {
 	go func() {
 		defer func() {
 			if r := recover(); r != nil {
@@ -55,7 +59,7 @@ func (s *Server) asyncRequestReport(ctx context.Context, reportRequest shared.Re
 			}
 		}()
 
-		s.reports.GenerateAndUploadReport(ctx, reportRequest, time.Now())
+		s.generateReport(ctx, id, reportRequest)
 		s.service.PostReportActions(ctx, reportRequest)
 
 		if s.onReportRequested != nil {
@@ -64,6 +68,38 @@ func (s *Server) asyncRequestReport(ctx context.Context, reportRequest shared.Re
 	}()
 }
 
+// startReportRequest records the report request so its progress can be followed
+func (s *Server) startReportRequest(ctx context.Context, reportRequest shared.ReportRequest) (int32, error) {
+	id, err := s.service.CreateReportRequest(ctx, reportRequest)
+	if err != nil {
+		return 0, err
+	}
+	s.runningReports.Store(id, struct{}{})
+	return id, nil
+}
+
+// generateReport generates the report and records the outcome against the report request
+func (s *Server) generateReport(ctx context.Context, id int32, reportRequest shared.ReportRequest) {
+	report, err := s.reports.GenerateAndUploadReport(ctx, reportRequest, time.Now())
+	_ = s.service.CompleteReportRequest(ctx, id, report, err)
+	s.runningReports.Delete(id)
+}
+
+// InterruptRunningReports marks any reports still being generated as interrupted, so they are not left running in the
+// report history when the server shuts down
+func (s *Server) InterruptRunningReports(ctx context.Context) error {
+	var ids []int32
+	s.runningReports.Range(func(key, _ any) bool {
+		ids = append(ids, key.(int32))
+		return true
+	})
+
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.service.InterruptReportRequests(ctx, ids)
+}
+
 func (s *Server) validateReportRequest(reportRequest shared.ReportRequest) error {
 	validationErrors := apierror.ValidationErrors{}
 
@@ -2,10 +2,12 @@ package api
 
 import (
 	"context"
+	"encoding/csv"
 	"io"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -22,38 +24,78 @@ import (
 )
 
 type Service interface {
+	AddCreditTransfer(ctx context.Context, clientId int32, transfer shared.AddCreditTransfer) error
 	AddFeeReduction(ctx context.Context, clientId int32, data shared.AddFeeReduction) error
 	AddInvoiceAdjustment(ctx context.Context, clientId int32, invoiceId int32, ledgerEntry *shared.AddInvoiceAdjustmentRequest) (*shared.InvoiceReference, error)
 	AddManualInvoice(ctx context.Context, clientId int32, invoice shared.AddManualInvoice) error
+	AddManualPayment(ctx context.Context, clientId int32, payment shared.AddManualPayment) error
 	AddRefund(ctx context.Context, clientId int32, refund shared.AddRefund) error
+	AddReportSubscription(ctx context.Context, data shared.AddReportSubscription) error
+	ApplyInvoiceFeeReduction(ctx context.Context, clientID int32, invoiceID int32) error
 	CancelFeeReduction(ctx context.Context, id int32, cancelledFeeReduction shared.CancelFeeReduction) error
 	CancelDirectDebitMandate(ctx context.Context, id int32, cancelMandate shared.CancelMandate) error
+	CancelReportSubscription(ctx context.Context, id int32) error
+	ProvisionFinanceClient(ctx context.Context, detail shared.OrderCreatedEvent) error
+	GetSuspenseItems(ctx context.Context) (shared.SuspenseItems, error)
+	AllocateSuspenseItem(ctx context.Context, id int32, allocation shared.AllocateSuspense) error
+	CloseAccountingPeriod(ctx context.Context, data shared.CloseAccountingPeriod) error
+	CompleteProcessedEvent(ctx context.Context, id int32, processErr error) error
+	CompleteReportRequest(ctx context.Context, id int32, report shared.GeneratedReport, reportErr error) error
+	CompleteUploadJob(ctx context.Context, id int32, result service.UploadJobResult) error
 	CreateDirectDebitMandate(ctx context.Context, id int32, createMandate shared.CreateMandate) (service.ScheduleData, error)
 	CreateDirectDebitSchedule(ctx context.Context, details shared.InvoiceCreatedEvent) error
+	CreateReportRequest(ctx context.Context, reportRequest shared.ReportRequest) (int32, error)
+	CreateUploadJob(ctx context.Context, job service.NewUploadJob) (int32, error)
 	RemoveDirectDebitSchedule(ctx context.Context, data shared.RemoveSchedule) error
+	ReopenAccountingPeriod(ctx context.Context, data shared.ReopenAccountingPeriod) error
+	RescheduleDirectDebit(ctx context.Context, clientID int32) error
 	ExpireRefunds(ctx context.Context) error
 	GetAccountInformation(ctx context.Context, id int32) (*shared.AccountInformation, error)
+	GetAccountingPeriods(ctx context.Context) (shared.AccountingPeriods, error)
+	GetAuditLogs(ctx context.Context, clientId int32) (shared.AuditLogs, error)
 	GetAnnualBillingInformation(ctx context.Context) (shared.AnnualBillingInformation, error)
 	GetBillingHistory(ctx context.Context, id int32) ([]shared.BillingHistory, error)
+	GetDeadLetteredOutboxEvents(ctx context.Context) (shared.OutboxEvents, error)
+	GetFailedProcessedEvents(ctx context.Context) (shared.ProcessedEvents, error)
 	GetFeeReductions(ctx context.Context, invoiceId int32) (shared.FeeReductions, error)
 	GetInvoices(ctx context.Context, clientId int32) (shared.Invoices, error)
 	GetInvoiceAdjustments(ctx context.Context, clientId int32) (shared.InvoiceAdjustments, error)
+	GetPendingInvoiceAdjustments(ctx context.Context, filter service.PendingInvoiceAdjustmentsFilter) (shared.PendingInvoiceAdjustments, error)
+	GetPendingCreditTransfers(ctx context.Context) (shared.CreditTransfers, error)
+	GetPendingRefunds(ctx context.Context) (shared.PendingRefunds, error)
 	GetPermittedAdjustments(ctx context.Context, invoiceId int32) ([]shared.AdjustmentType, error)
 	GetRefunds(ctx context.Context, clientId int32) (shared.Refunds, error)
+	GetReportRequest(ctx context.Context, id int32) (*shared.RequestedReport, error)
+	GetReportRequests(ctx context.Context) (shared.RequestedReports, error)
+	GetReportSubscriptions(ctx context.Context) (shared.ReportSubscriptions, error)
+	GetUploadJob(ctx context.Context, id int32) (*shared.UploadJob, error)
+	GetUploadJobs(ctx context.Context) (shared.UploadJobs, error)
+	InterruptReportRequests(ctx context.Context, ids []int32) error
 	PostReportActions(ctx context.Context, report shared.ReportRequest)
+	PreviewUpload(ctx context.Context, records [][]string, uploadType shared.ReportUploadType, uploadDate shared.Date, pisNumber int) (*shared.UploadPreview, error)
 	ProcessAdhocEvent(ctx context.Context, event shared.AdhocEvent) error
 	ProcessDirectUploadReport(ctx context.Context, filename string, fileBytes io.Reader, uploadType shared.ReportUploadType) error
+	ProcessFailedDirectDebitCollections(ctx context.Context, date time.Time) error
 	ProcessFulfilledRefunds(ctx context.Context, records [][]string, date shared.Date) (map[int]string, error)
 	ProcessPayments(ctx context.Context, records [][]string, uploadType shared.ReportUploadType, bankDate shared.Date, pisNumber int) (map[int]string, error)
 	ProcessPaymentReversals(ctx context.Context, records [][]string, uploadType shared.ReportUploadType) (map[int]string, error)
 	ProcessRefundReversals(ctx context.Context, records [][]string, date shared.Date) (map[int]string, error)
+	ReplayProcessedEvent(ctx context.Context, id int32) ([]byte, error)
+	StartProcessedEvent(ctx context.Context, event service.InboundEvent) (int32, bool, error)
+	StartReportSubscriptions(ctx context.Context, runDate time.Time) ([]service.SubscribedReport, error)
+	ProcessDeputySchedule(ctx context.Context, records [][]string) (map[int]string, error)
+	ProcessUploadStream(ctx context.Context, reader *csv.Reader, upload service.UploadStream) (int, map[int]string, error)
 	PostLedgerActions(ctx context.Context, clientID int32, tx *store.Tx) error
+	UpdateCreditTransferDecision(ctx context.Context, id int32, status shared.AdjustmentStatus) error
 	UpdatePaymentMethod(ctx context.Context, clientID int32, paymentMethod shared.PaymentMethod) error
 	UpdatePendingInvoiceAdjustment(ctx context.Context, clientId int32, adjustmentId int32, status shared.AdjustmentStatus) error
+	UpdatePendingInvoiceAdjustments(ctx context.Context, adjustments []shared.ClientInvoiceAdjustment, status shared.AdjustmentStatus) shared.InvoiceAdjustmentDecisions
 	UpdateRefundDecision(ctx context.Context, clientId int32, refundId int32, status shared.RefundStatus) error
+	UpdateRefundDecisions(ctx context.Context, refunds []shared.ClientRefund, status shared.RefundStatus) shared.RefundDecisions
 	SendDirectDebitCollectionEvent(ctx context.Context, id int32, pendingCollection service.ScheduleData) error
 	QueueScheduleRemovals(ctx context.Context, schedules [][]string, scheduleDate shared.Date) map[int]string
 	UpdateClientMandateDetails(ctx context.Context, id int32, detail shared.ClientUpdatedEvent) error
+	VoidInvoice(ctx context.Context, clientId int32, invoiceId int32, void shared.VoidInvoice) error
 }
 type FileStorage interface {
 	GetFile(ctx context.Context, bucketName string, filename string) (io.ReadCloser, error)
@@ -63,7 +105,7 @@ type FileStorage interface {
 }
 
 type Reports interface {
-	GenerateAndUploadReport(ctx context.Context, reportRequest shared.ReportRequest, requestedDate time.Time)
+	GenerateAndUploadReport(ctx context.Context, reportRequest shared.ReportRequest, requestedDate time.Time) (shared.GeneratedReport, error)
 }
 
 type JWTClient interface {
@@ -82,14 +124,17 @@ type Server struct {
 	JWT               JWTClient
 	validator         *validation.Validate
 	envs              *Envs
-	onReportRequested func() // hook to allow tests to wait on async function to complete
+	runningReports    sync.Map // IDs of the report requests being generated, to be interrupted on shutdown
+	onReportRequested func()   // hook to allow tests to wait on async function to complete
 }
 
 type Envs struct {
 	ReportsBucket     string
+	AsyncBucket       string
 	GoLiveDate        time.Time
 	EventBridgeAPIKey string
 	SystemUserID      int32
+	UploadBatchSize   int
 }
 
 func NewServer(service Service, reports Reports, fileStorage FileStorage, notify NotifyClient, jwtClient JWTClient, validator *validation.Validate, envs *Envs) *Server {
@@ -114,6 +159,7 @@ func (s *Server) SetupRoutes(logger *slog.Logger) http.Handler {
 	}
 
 	authFunc("GET /clients/{clientId}", shared.RoleAny, s.getAccountInformation)
+	authFunc("GET /clients/{clientId}/audit", shared.RoleAny, s.getAuditLogs)
 	authFunc("GET /clients/{clientId}/billing-history", shared.RoleAny, s.getBillingHistory)
 	authFunc("GET /clients/{clientId}/fee-reductions", shared.RoleAny, s.getFeeReductions)
 	authFunc("GET /clients/{clientId}/invoices", shared.RoleAny, s.getInvoices)
@@ -121,22 +167,50 @@ func (s *Server) SetupRoutes(logger *slog.Logger) http.Handler {
 	authFunc("GET /clients/{clientId}/invoice-adjustments", shared.RoleAny, s.getInvoiceAdjustments)
 	authFunc("GET /clients/{clientId}/refunds", shared.RoleAny, s.getRefunds)
 
+	authFunc("POST /clients/{clientId}/credit-transfers", shared.RoleFinanceUser, s.addCreditTransfer)
 	authFunc("POST /clients/{clientId}/fee-reductions", shared.RoleFinanceUser, s.addFeeReduction)
 	authFunc("PUT /clients/{clientId}/fee-reductions/{feeReductionId}/cancel", shared.RoleFinanceManager, s.cancelFeeReduction)
 	authFunc("POST /clients/{clientId}/invoices", shared.RoleFinanceManager, s.addManualInvoice)
 	authFunc("POST /clients/{clientId}/invoices/{invoiceId}/invoice-adjustments", shared.RoleFinanceUser, s.addInvoiceAdjustment)
+	authFunc("POST /clients/{clientId}/invoices/{invoiceId}/void", shared.RoleFinanceManager, s.voidInvoice)
 	authFunc("PUT /clients/{clientId}/invoice-adjustments/{adjustmentId}", shared.RoleFinanceManager, s.updatePendingInvoiceAdjustment)
+	authFunc("POST /clients/{clientId}/payments", shared.RoleFinanceManager, s.addManualPayment)
 	authFunc("PUT /clients/{clientId}/payment-method", shared.RoleFinanceUser, s.updatePaymentMethod)
 	authFunc("POST /clients/{clientId}/refunds", shared.RoleFinanceUser, s.addRefund)
 	authFunc("PUT /clients/{clientId}/refunds/{refundId}", shared.RoleFinanceManager, s.updateRefundDecision)
 	authFunc("POST /clients/{clientId}/direct-debit", shared.RoleFinanceUser, s.createDirectDebitMandate)
 	authFunc("DELETE /clients/{clientId}/direct-debit", shared.RoleFinanceUser, s.cancelDirectDebitMandate)
 
+	authFunc("GET /accounting-periods", shared.RoleAny, s.getAccountingPeriods)
+	authFunc("POST /accounting-periods/close", shared.RoleCorporateFinance, s.closeAccountingPeriod)
+	authFunc("POST /accounting-periods/reopen", shared.RoleCorporateFinance, s.reopenAccountingPeriod)
+
+	authFunc("GET /credit-transfers", shared.RoleFinanceManager, s.getPendingCreditTransfers)
+	authFunc("PUT /credit-transfers/{creditTransferId}", shared.RoleFinanceManager, s.updateCreditTransferDecision)
+	authFunc("GET /invoice-adjustments", shared.RoleFinanceManager, s.getPendingInvoiceAdjustments)
+	authFunc("PUT /invoice-adjustments", shared.RoleFinanceManager, s.updatePendingInvoiceAdjustments)
+	authFunc("GET /refunds", shared.RoleFinanceManager, s.getPendingRefunds)
+	authFunc("PUT /refunds", shared.RoleFinanceManager, s.updateRefundDecisions)
+	authFunc("GET /suspense", shared.RoleFinanceUser, s.getSuspenseItems)
+	authFunc("POST /suspense/{suspenseId}/allocate", shared.RoleFinanceUser, s.allocateSuspenseItem)
+
 	authFunc("GET /download", shared.RoleFinanceReporting, s.download)
 	authFunc("HEAD /download", shared.RoleFinanceReporting, s.checkDownload)
+	authFunc("GET /reports", shared.RoleFinanceReporting, s.getReportRequests)
+	authFunc("GET /reports/{id}", shared.RoleFinanceReporting, s.getReportRequest)
 	authFunc("POST /reports", shared.RoleFinanceReporting, s.requestReport)
+	authFunc("GET /report-subscriptions", shared.RoleFinanceReporting, s.getReportSubscriptions)
+	authFunc("POST /report-subscriptions", shared.RoleFinanceReporting, s.addReportSubscription)
+	authFunc("DELETE /report-subscriptions/{id}", shared.RoleFinanceReporting, s.cancelReportSubscription)
 	authFunc("POST /uploads", shared.RoleFinanceReporting, s.processUpload)
+	authFunc("POST /uploads/preview", shared.RoleFinanceReporting, s.previewUpload)
+	authFunc("POST /uploads/stream", shared.RoleFinanceReporting, s.streamUpload)
+	authFunc("GET /uploads", shared.RoleFinanceReporting, s.getUploadJobs)
+	authFunc("GET /uploads/{id}", shared.RoleFinanceReporting, s.getUploadJob)
 	authFunc("GET /annual-billing-letters-information", shared.RoleFinanceReporting, s.getAnnualBillingInformation)
+	authFunc("GET /events/failed", shared.RoleFinanceReporting, s.getFailedEvents)
+	authFunc("POST /events/replay", shared.RoleFinanceReporting, s.replayEvents)
+	authFunc("GET /events/outbox/dead-lettered", shared.RoleFinanceReporting, s.getDeadLetteredOutboxEvents)
 
 	// unauthenticated as request is coming from EventBridge
 	eventFunc := func(pattern string, h handlerFunc) {
@@ -178,10 +252,16 @@ func (s *Server) Logger(ctx context.Context) *slog.Logger {
 }
 
 func (s *Server) copyCtx(r *http.Request) context.Context {
-	copyCtx := telemetry.ContextWithLogger(context.Background(), s.Logger(r.Context()))
+	return s.detachCtx(r.Context())
+}
+
+// detachCtx copies the logger and user into a context that is not cancelled with the request, for work that continues
+// after the response has been sent
+func (s *Server) detachCtx(ctx context.Context) context.Context {
+	copyCtx := telemetry.ContextWithLogger(context.Background(), s.Logger(ctx))
 	return auth.Context{
 		Context: copyCtx,
-		User:    r.Context().(auth.Context).User,
+		User:    ctx.(auth.Context).User,
 	}
 }
 
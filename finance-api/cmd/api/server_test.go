@@ -3,9 +3,11 @@ package api
 import (
 	"bytes"
 	"context"
+	"encoding/csv"
 	"io"
 	"time"
 
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/notify"
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/service"
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
@@ -19,15 +21,43 @@ type mockService struct {
 	feeReductions            shared.FeeReductions
 	invoiceReference         *shared.InvoiceReference
 	invoiceAdjustments       shared.InvoiceAdjustments
+	pendingAdjustments       shared.PendingInvoiceAdjustments
+	adjustmentDecisions      shared.InvoiceAdjustmentDecisions
 	feeReduction             *shared.AddFeeReduction
 	cancelFeeReduction       *shared.CancelFeeReduction
 	ledger                   *shared.AddInvoiceAdjustmentRequest
 	manualInvoice            *shared.AddManualInvoice
+	manualPayment            shared.AddManualPayment
 	adjustmentTypes          []shared.AdjustmentType
 	billingHistory           []shared.BillingHistory
 	refunds                  shared.Refunds
+	pendingRefunds           shared.PendingRefunds
+	refundDecisions          shared.RefundDecisions
+	suspenseItems            shared.SuspenseItems
+	creditTransfer           shared.AddCreditTransfer
+	creditTransfers          shared.CreditTransfers
+	accountingPeriods        shared.AccountingPeriods
+	auditLogs                shared.AuditLogs
 	addRefund                shared.AddRefund
 	pendingCollection        service.ScheduleData
+	uploadPreview            *shared.UploadPreview
+	uploadJobID              int32
+	uploadJob                *shared.UploadJob
+	uploadJobs               shared.UploadJobs
+	failedEvents             shared.ProcessedEvents
+	deadLetteredEvents       shared.OutboxEvents
+	reportSubscriptions      shared.ReportSubscriptions
+	reportRequestID          int32
+	requestedReports         shared.RequestedReports
+	requestedReport          *shared.RequestedReport
+	completedReports         map[int32]error
+	interruptedReports       []int32
+	subscribedReports        []service.SubscribedReport
+	eventPayloads            map[int32][]byte
+	inboundEvent             service.InboundEvent
+	duplicateEvent           bool
+	completedEvents          map[int32]error
+	failedLines              map[int]string
 	expectedIds              []int
 	called                   []string
 	errs                     map[string]error
@@ -45,6 +75,12 @@ func (s *mockService) UpdatePaymentMethod(ctx context.Context, clientID int32, p
 	return s.errs["UpdatePaymentMethod"]
 }
 
+func (s *mockService) ApplyInvoiceFeeReduction(ctx context.Context, clientID int32, invoiceID int32) error {
+	s.expectedIds = []int{int(clientID), int(invoiceID)}
+	s.called = append(s.called, "ApplyInvoiceFeeReduction")
+	return s.errs["ApplyInvoiceFeeReduction"]
+}
+
 func (s *mockService) PostLedgerActions(ctx context.Context, clientID int32, tx *store.Tx) error {
 	s.expectedIds = []int{int(clientID)}
 	s.called = append(s.called, "ReapplyCredit")
@@ -63,6 +99,13 @@ func (s *mockService) AddManualInvoice(ctx context.Context, id int32, invoice sh
 	return s.errs["AddManualInvoice"]
 }
 
+func (s *mockService) AddManualPayment(ctx context.Context, id int32, payment shared.AddManualPayment) error {
+	s.expectedIds = []int{int(id)}
+	s.manualPayment = payment
+	s.called = append(s.called, "AddManualPayment")
+	return s.errs["AddManualPayment"]
+}
+
 func (s *mockService) GetPermittedAdjustments(ctx context.Context, id int32) ([]shared.AdjustmentType, error) {
 	s.expectedIds = []int{int(id)}
 	s.called = append(s.called, "GetPermittedAdjustments")
@@ -75,6 +118,18 @@ func (s *mockService) UpdatePendingInvoiceAdjustment(ctx context.Context, client
 	return s.errs["UpdatePendingInvoiceAdjustment"]
 }
 
+func (s *mockService) GetPendingInvoiceAdjustments(ctx context.Context, filter service.PendingInvoiceAdjustmentsFilter) (shared.PendingInvoiceAdjustments, error) {
+	s.lastCalledParams = []interface{}{filter}
+	s.called = append(s.called, "GetPendingInvoiceAdjustments")
+	return s.pendingAdjustments, s.errs["GetPendingInvoiceAdjustments"]
+}
+
+func (s *mockService) UpdatePendingInvoiceAdjustments(ctx context.Context, adjustments []shared.ClientInvoiceAdjustment, status shared.AdjustmentStatus) shared.InvoiceAdjustmentDecisions {
+	s.lastCalledParams = []interface{}{adjustments, status}
+	s.called = append(s.called, "UpdatePendingInvoiceAdjustments")
+	return s.adjustmentDecisions
+}
+
 func (s *mockService) AddFeeReduction(ctx context.Context, id int32, data shared.AddFeeReduction) error {
 	s.expectedIds = []int{int(id)}
 	s.called = append(s.called, "AddFeeReduction")
@@ -134,6 +189,17 @@ func (s *mockService) UpdateRefundDecision(ctx context.Context, clientId int32,
 	return s.errs["UpdateRefundDecision"]
 }
 
+func (s *mockService) GetPendingRefunds(ctx context.Context) (shared.PendingRefunds, error) {
+	s.called = append(s.called, "GetPendingRefunds")
+	return s.pendingRefunds, s.errs["GetPendingRefunds"]
+}
+
+func (s *mockService) UpdateRefundDecisions(ctx context.Context, refunds []shared.ClientRefund, status shared.RefundStatus) shared.RefundDecisions {
+	s.lastCalledParams = []interface{}{refunds, status}
+	s.called = append(s.called, "UpdateRefundDecisions")
+	return s.refundDecisions
+}
+
 func (s *mockService) AddInvoiceAdjustment(ctx context.Context, clientId int32, invoiceId int32, ledgerEntry *shared.AddInvoiceAdjustmentRequest) (*shared.InvoiceReference, error) {
 	s.ledger = ledgerEntry
 	s.expectedIds = []int{int(clientId), int(invoiceId)}
@@ -165,6 +231,131 @@ func (s *mockService) ProcessFulfilledRefunds(ctx context.Context, records [][]s
 	return nil, s.errs["ProcessFulfilledRefunds"]
 }
 
+func (s *mockService) CreateUploadJob(ctx context.Context, job service.NewUploadJob) (int32, error) {
+	s.called = append(s.called, "CreateUploadJob")
+	s.lastCalledParams = []interface{}{job}
+	return s.uploadJobID, s.errs["CreateUploadJob"]
+}
+
+func (s *mockService) CompleteUploadJob(ctx context.Context, id int32, result service.UploadJobResult) error {
+	s.called = append(s.called, "CompleteUploadJob")
+	s.lastCalledParams = []interface{}{id, result}
+	return s.errs["CompleteUploadJob"]
+}
+
+// StartProcessedEvent and CompleteProcessedEvent wrap every event, so they are recorded separately from the handlers called
+func (s *mockService) StartProcessedEvent(ctx context.Context, event service.InboundEvent) (int32, bool, error) {
+	s.inboundEvent = event
+	return 1, !s.duplicateEvent, s.errs["StartProcessedEvent"]
+}
+
+func (s *mockService) CompleteProcessedEvent(ctx context.Context, id int32, processErr error) error {
+	if s.completedEvents == nil {
+		s.completedEvents = map[int32]error{}
+	}
+	s.completedEvents[id] = processErr
+	return s.errs["CompleteProcessedEvent"]
+}
+
+func (s *mockService) ReplayProcessedEvent(ctx context.Context, id int32) ([]byte, error) {
+	s.called = append(s.called, "ReplayProcessedEvent")
+	payload, ok := s.eventPayloads[id]
+	if !ok {
+		return nil, apierror.BadRequestError("id", "Only failed or interrupted events can be replayed", nil)
+	}
+	return payload, nil
+}
+
+func (s *mockService) CreateReportRequest(ctx context.Context, reportRequest shared.ReportRequest) (int32, error) {
+	return s.reportRequestID, s.errs["CreateReportRequest"]
+}
+
+func (s *mockService) CompleteReportRequest(ctx context.Context, id int32, report shared.GeneratedReport, reportErr error) error {
+	if s.completedReports == nil {
+		s.completedReports = map[int32]error{}
+	}
+	s.completedReports[id] = reportErr
+	return s.errs["CompleteReportRequest"]
+}
+
+func (s *mockService) InterruptReportRequests(ctx context.Context, ids []int32) error {
+	s.interruptedReports = ids
+	return s.errs["InterruptReportRequests"]
+}
+
+func (s *mockService) GetReportRequests(ctx context.Context) (shared.RequestedReports, error) {
+	s.called = append(s.called, "GetReportRequests")
+	return s.requestedReports, s.errs["GetReportRequests"]
+}
+
+func (s *mockService) GetReportRequest(ctx context.Context, id int32) (*shared.RequestedReport, error) {
+	s.expectedIds = []int{int(id)}
+	s.called = append(s.called, "GetReportRequest")
+	return s.requestedReport, s.errs["GetReportRequest"]
+}
+
+func (s *mockService) AddReportSubscription(ctx context.Context, data shared.AddReportSubscription) error {
+	s.lastCalledParams = []interface{}{data}
+	s.called = append(s.called, "AddReportSubscription")
+	return s.errs["AddReportSubscription"]
+}
+
+func (s *mockService) CancelReportSubscription(ctx context.Context, id int32) error {
+	s.expectedIds = []int{int(id)}
+	s.called = append(s.called, "CancelReportSubscription")
+	return s.errs["CancelReportSubscription"]
+}
+
+func (s *mockService) GetReportSubscriptions(ctx context.Context) (shared.ReportSubscriptions, error) {
+	s.called = append(s.called, "GetReportSubscriptions")
+	return s.reportSubscriptions, s.errs["GetReportSubscriptions"]
+}
+
+func (s *mockService) StartReportSubscriptions(ctx context.Context, runDate time.Time) ([]service.SubscribedReport, error) {
+	s.lastCalledParams = []interface{}{runDate}
+	s.called = append(s.called, "StartReportSubscriptions")
+	return s.subscribedReports, s.errs["StartReportSubscriptions"]
+}
+
+func (s *mockService) GetDeadLetteredOutboxEvents(ctx context.Context) (shared.OutboxEvents, error) {
+	s.called = append(s.called, "GetDeadLetteredOutboxEvents")
+	return s.deadLetteredEvents, s.errs["GetDeadLetteredOutboxEvents"]
+}
+
+func (s *mockService) GetFailedProcessedEvents(ctx context.Context) (shared.ProcessedEvents, error) {
+	s.called = append(s.called, "GetFailedProcessedEvents")
+	return s.failedEvents, s.errs["GetFailedProcessedEvents"]
+}
+
+func (s *mockService) GetUploadJobs(ctx context.Context) (shared.UploadJobs, error) {
+	s.called = append(s.called, "GetUploadJobs")
+	return s.uploadJobs, s.errs["GetUploadJobs"]
+}
+
+func (s *mockService) GetUploadJob(ctx context.Context, id int32) (*shared.UploadJob, error) {
+	s.expectedIds = []int{int(id)}
+	s.called = append(s.called, "GetUploadJob")
+	return s.uploadJob, s.errs["GetUploadJob"]
+}
+
+func (s *mockService) ProcessUploadStream(ctx context.Context, reader *csv.Reader, upload service.UploadStream) (int, map[int]string, error) {
+	s.called = append(s.called, "ProcessUploadStream")
+	records, _ := reader.ReadAll()
+	s.lastCalledParams = []interface{}{records, upload}
+	return len(records), s.failedLines, s.errs["ProcessUploadStream"]
+}
+
+func (s *mockService) PreviewUpload(ctx context.Context, records [][]string, uploadType shared.ReportUploadType, uploadDate shared.Date, pisNumber int) (*shared.UploadPreview, error) {
+	s.called = append(s.called, "PreviewUpload")
+	s.lastCalledParams = []interface{}{records, uploadType, uploadDate, pisNumber}
+	return s.uploadPreview, s.errs["PreviewUpload"]
+}
+
+func (s *mockService) ProcessDeputySchedule(ctx context.Context, records [][]string) (map[int]string, error) {
+	s.called = append(s.called, "ProcessDeputySchedule")
+	return nil, s.errs["ProcessDeputySchedule"]
+}
+
 func (s *mockService) ProcessRefundReversals(ctx context.Context, records [][]string, date shared.Date) (map[int]string, error) {
 	s.called = append(s.called, "ProcessRefundReversals")
 	return nil, s.errs["ProcessRefundReversals"]
@@ -175,6 +366,12 @@ func (s *mockService) ProcessDirectUploadReport(ctx context.Context, filename st
 	return s.errs["ProcessDirectUploadReport"]
 }
 
+func (s *mockService) ProcessFailedDirectDebitCollections(ctx context.Context, date time.Time) error {
+	s.called = append(s.called, "ProcessFailedDirectDebitCollections")
+	s.lastCalledParams = []interface{}{date}
+	return s.errs["ProcessFailedDirectDebitCollections"]
+}
+
 func (s *mockService) PostReportActions(ctx context.Context, reportType shared.ReportRequest) {
 	s.called = append(s.called, "PostReportActions")
 }
@@ -184,6 +381,73 @@ func (s *mockService) ExpireRefunds(ctx context.Context) error {
 	return s.errs["ExpireRefunds"]
 }
 
+func (s *mockService) GetSuspenseItems(ctx context.Context) (shared.SuspenseItems, error) {
+	s.called = append(s.called, "GetSuspenseItems")
+	return s.suspenseItems, s.errs["GetSuspenseItems"]
+}
+
+func (s *mockService) AllocateSuspenseItem(ctx context.Context, id int32, allocation shared.AllocateSuspense) error {
+	s.called = append(s.called, "AllocateSuspenseItem")
+	s.expectedIds = []int{int(id)}
+	s.lastCalledParams = []interface{}{allocation}
+	return s.errs["AllocateSuspenseItem"]
+}
+
+func (s *mockService) AddCreditTransfer(ctx context.Context, id int32, transfer shared.AddCreditTransfer) error {
+	s.expectedIds = []int{int(id)}
+	s.creditTransfer = transfer
+	s.called = append(s.called, "AddCreditTransfer")
+	return s.errs["AddCreditTransfer"]
+}
+
+func (s *mockService) GetPendingCreditTransfers(ctx context.Context) (shared.CreditTransfers, error) {
+	s.called = append(s.called, "GetPendingCreditTransfers")
+	return s.creditTransfers, s.errs["GetPendingCreditTransfers"]
+}
+
+func (s *mockService) UpdateCreditTransferDecision(ctx context.Context, id int32, status shared.AdjustmentStatus) error {
+	s.expectedIds = []int{int(id)}
+	s.lastCalledParams = []interface{}{status}
+	s.called = append(s.called, "UpdateCreditTransferDecision")
+	return s.errs["UpdateCreditTransferDecision"]
+}
+
+func (s *mockService) VoidInvoice(ctx context.Context, clientId int32, invoiceId int32, void shared.VoidInvoice) error {
+	s.expectedIds = []int{int(clientId), int(invoiceId)}
+	s.lastCalledParams = []interface{}{void}
+	s.called = append(s.called, "VoidInvoice")
+	return s.errs["VoidInvoice"]
+}
+
+func (s *mockService) GetAccountingPeriods(ctx context.Context) (shared.AccountingPeriods, error) {
+	s.called = append(s.called, "GetAccountingPeriods")
+	return s.accountingPeriods, s.errs["GetAccountingPeriods"]
+}
+
+func (s *mockService) GetAuditLogs(ctx context.Context, clientId int32) (shared.AuditLogs, error) {
+	s.expectedIds = []int{int(clientId)}
+	s.called = append(s.called, "GetAuditLogs")
+	return s.auditLogs, s.errs["GetAuditLogs"]
+}
+
+func (s *mockService) CloseAccountingPeriod(ctx context.Context, data shared.CloseAccountingPeriod) error {
+	s.lastCalledParams = []interface{}{data}
+	s.called = append(s.called, "CloseAccountingPeriod")
+	return s.errs["CloseAccountingPeriod"]
+}
+
+func (s *mockService) ReopenAccountingPeriod(ctx context.Context, data shared.ReopenAccountingPeriod) error {
+	s.lastCalledParams = []interface{}{data}
+	s.called = append(s.called, "ReopenAccountingPeriod")
+	return s.errs["ReopenAccountingPeriod"]
+}
+
+func (s *mockService) ProvisionFinanceClient(ctx context.Context, detail shared.OrderCreatedEvent) error {
+	s.called = append(s.called, "ProvisionFinanceClient")
+	s.lastCalledParams = []interface{}{detail}
+	return s.errs["ProvisionFinanceClient"]
+}
+
 func (s *mockService) CancelDirectDebitMandate(ctx context.Context, id int32, cancelMandate shared.CancelMandate) error {
 	s.called = append(s.called, "CancelDirectDebitMandate")
 	return s.errs["CancelDirectDebitMandate"]
@@ -214,6 +478,11 @@ func (s *mockService) RemoveDirectDebitSchedule(ctx context.Context, data shared
 	return s.errs["RemoveDirectDebitSchedule"]
 }
 
+func (s *mockService) RescheduleDirectDebit(ctx context.Context, clientID int32) error {
+	s.called = append(s.called, "RescheduleDirectDebit")
+	return s.errs["RescheduleDirectDebit"]
+}
+
 func (s *mockService) UpdateClientMandateDetails(ctx context.Context, id int32, detail shared.ClientUpdatedEvent) error {
 	s.called = append(s.called, "UpdateClientMandateDetails")
 	return s.errs["UpdateClientMandateDetails"]
@@ -264,11 +533,16 @@ func (n *mockNotify) Send(ctx context.Context, payload notify.Payload) error {
 }
 
 type MockReports struct {
-	requestedReport *shared.ReportRequest
-	requestedDate   time.Time
+	requestedReport  *shared.ReportRequest
+	requestedReports []shared.ReportRequest
+	requestedDate    time.Time
+	generatedReport  shared.GeneratedReport
+	err              error
 }
 
-func (m *MockReports) GenerateAndUploadReport(ctx context.Context, reportRequest shared.ReportRequest, requestedDate time.Time) {
+func (m *MockReports) GenerateAndUploadReport(ctx context.Context, reportRequest shared.ReportRequest, requestedDate time.Time) (shared.GeneratedReport, error) {
 	m.requestedReport = &reportRequest
+	m.requestedReports = append(m.requestedReports, reportRequest)
 	m.requestedDate = requestedDate
+	return m.generatedReport, m.err
 }
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/ministryofjustice/opg-go-common/telemetry"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/auth"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/service"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+// streamUpload accepts the upload as a raw CSV body, with the upload details as query parameters, and processes it in
+// batches without holding the whole file in memory.
+func (s *Server) streamUpload(w http.ResponseWriter, r *http.Request) error {
+	query := r.URL.Query()
+	defer unchecked(r.Body.Close)
+
+	upload := service.UploadStream{
+		UploadType: shared.ParseUploadType(query.Get("uploadType")),
+		BatchSize:  s.envs.UploadBatchSize,
+	}
+	if !upload.UploadType.PostsLedgers() {
+		return apierror.BadRequestError("uploadType", "Streaming is not available for this upload type", nil)
+	}
+
+	if query.Get("uploadDate") != "" {
+		err := upload.UploadDate.UnmarshalJSON([]byte(query.Get("uploadDate")))
+		if err != nil {
+			return apierror.BadRequestError("uploadDate", "Unable to parse date", err)
+		}
+	}
+
+	if query.Get("pisNumber") != "" {
+		pisNumber, err := strconv.Atoi(query.Get("pisNumber"))
+		if err != nil {
+			return apierror.BadRequestError("pisNumber", "Unable to parse value to int", err)
+		}
+		upload.PisNumber = pisNumber
+	}
+
+	if query.Get("batchSize") != "" {
+		batchSize, err := strconv.Atoi(query.Get("batchSize"))
+		if err != nil || batchSize < 0 {
+			return apierror.BadRequestError("batchSize", "Batch size must be zero or a positive integer", err)
+		}
+		upload.BatchSize = batchSize
+	}
+
+	job, err := s.startUploadStream(r, upload, query.Get("emailAddress"), query.Get("fileName"), r.Body)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(job)
+}
+
+// startUploadStream writes the body to a temporary file as it is hashed, so that repeated uploads can be rejected before
+// any lines are processed, and then processes it from the file in batches in the background
+func (s *Server) startUploadStream(r *http.Request, upload service.UploadStream, email string, filename string, body io.Reader) (shared.UploadJob, error) {
+	ctx := r.Context()
+
+	file, err := os.CreateTemp("", "upload-*.csv")
+	if err != nil {
+		return shared.UploadJob{}, err
+	}
+	removeFile := func() {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+	}
+
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(file, hash), body)
+	if err != nil {
+		removeFile()
+		return shared.UploadJob{}, apierror.BadRequestError("upload", "Unable to read upload", err)
+	}
+
+	upload.JobID, err = s.service.CreateUploadJob(ctx, service.NewUploadJob{
+		UploadType:   upload.UploadType,
+		Filename:     filename,
+		FileHash:     hex.EncodeToString(hash.Sum(nil)),
+		UploadDate:   upload.UploadDate,
+		EmailAddress: email,
+	})
+	if err != nil {
+		removeFile()
+		return shared.UploadJob{}, err
+	}
+
+	s.Logger(ctx).Info(fmt.Sprintf("streaming %s upload", upload.UploadType), "jobId", upload.JobID, "batchSize", upload.BatchSize)
+
+	go func(logger *slog.Logger) {
+		defer removeFile()
+
+		ctx := s.copyCtx(r)
+		ctx.(auth.Context).WithContext(telemetry.ContextWithLogger(ctx, logger))
+
+		_, err := file.Seek(0, io.SeekStart)
+		if err != nil {
+			logger.Error("unable to read upload", "err", err)
+		}
+		s.processUploadStream(ctx, email, upload, file)
+	}(telemetry.LoggerFromContext(ctx))
+
+	return shared.UploadJob{
+		ID:         int(upload.JobID),
+		UploadType: upload.UploadType,
+		Filename:   filename,
+		Status:     shared.UploadJobStatusProcessing,
+	}, nil
+}
+
+func (s *Server) processUploadStream(ctx context.Context, email string, upload service.UploadStream, file io.Reader) {
+	logger := s.Logger(ctx)
+
+	lineCount, failedLines, err := s.service.ProcessUploadStream(ctx, csv.NewReader(file), upload)
+	if err != nil {
+		logger.Error("unable to process upload due to error", "err", err)
+	} else if len(failedLines) > 0 {
+		logger.Error(fmt.Sprintf("unable to process upload due to %d failed lines", len(failedLines)))
+	}
+
+	_ = s.service.CompleteUploadJob(ctx, upload.JobID, service.UploadJobResult{
+		LineCount:   &lineCount,
+		FailedLines: failedLines,
+		Err:         err,
+	})
+
+	err = s.notify.Send(ctx, createUploadNotifyPayload(email, upload.UploadType, err, failedLines))
+	if err != nil {
+		logger.Error("unable to send notification", "err", err)
+	}
+}
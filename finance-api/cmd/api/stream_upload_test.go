@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ministryofjustice/opg-go-common/telemetry"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/auth"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/notify"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/service"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/validation"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func streamRequest(query string, body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/uploads/stream?"+query, strings.NewReader(body))
+	return r.WithContext(auth.Context{
+		Context: telemetry.ContextWithLogger(context.Background(), telemetry.NewLogger("finance-api-test")),
+		User:    &shared.User{ID: 1},
+	})
+}
+
+func TestServer_streamUpload(t *testing.T) {
+	mock := &mockService{uploadJobID: 3}
+	server := NewServer(mock, nil, nil, &mockNotify{}, nil, nil, &Envs{UploadBatchSize: 500})
+
+	w := httptest.NewRecorder()
+	err := server.streamUpload(w, streamRequest("uploadType=PAYMENTS_MOTO_CARD&uploadDate=2025-01-02&fileName=moto.csv&emailAddress=test@email.com", "col1,col2\nabc,1"))
+	assert.NoError(t, err)
+
+	expected := `{"id":3,"uploadType":"PAYMENTS_MOTO_CARD","filename":"moto.csv","fileHash":"","status":"PROCESSING","lineCount":{"Value":0,"Valid":false},"failedLineCount":{"Value":0,"Valid":false},"startedAt":"0001-01-01T00:00:00Z","endedAt":{"Value":"0001-01-01T00:00:00Z","Valid":false},"createdBy":0,"uploadDate":{"Value":"01\/01\/0001","Valid":false},"emailAddress":"","processedLineCount":{"Value":0,"Valid":false}}`
+	assert.Equal(t, expected, strings.TrimSpace(w.Body.String()))
+}
+
+func TestServer_streamUpload_badRequest(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		field string
+	}{
+		{name: "unsupported upload type", query: "uploadType=REMOVE_SCHEDULES", field: "uploadType"},
+		{name: "invalid upload date", query: "uploadType=PAYMENTS_MOTO_CARD&uploadDate=not-a-date", field: "uploadDate"},
+		{name: "invalid pis number", query: "uploadType=PAYMENTS_SUPERVISION_CHEQUE&pisNumber=abc", field: "pisNumber"},
+		{name: "negative batch size", query: "uploadType=PAYMENTS_MOTO_CARD&batchSize=-1", field: "batchSize"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockService{}
+			server := NewServer(mock, nil, nil, &mockNotify{}, nil, nil, &Envs{UploadBatchSize: 500})
+
+			err := server.streamUpload(httptest.NewRecorder(), streamRequest(tt.query, "col1,col2\nabc,1"))
+
+			var e *apierror.BadRequest
+			assert.ErrorAs(t, err, &e)
+			assert.Equal(t, tt.field, e.Field)
+			assert.Nil(t, mock.called)
+		})
+	}
+}
+
+func TestServer_streamUpload_duplicateFile(t *testing.T) {
+	mock := &mockService{errs: map[string]error{"CreateUploadJob": apierror.BadRequestError("upload", "This file has already been uploaded", nil)}}
+	server := NewServer(mock, nil, nil, &mockNotify{}, nil, nil, &Envs{UploadBatchSize: 500})
+
+	err := server.streamUpload(httptest.NewRecorder(), streamRequest("uploadType=BOUNCED_CHEQUE&batchSize=0&fileName=cheques.csv", "col1,col2\nabc,1"))
+
+	var e *apierror.BadRequest
+	assert.ErrorAs(t, err, &e)
+	assert.Equal(t, []string{"CreateUploadJob"}, mock.called)
+	assert.Equal(t, service.NewUploadJob{
+		UploadType: shared.ReportTypeUploadBouncedCheque,
+		Filename:   "cheques.csv",
+		FileHash:   "cb87e15cfe7a0165cba9cc1d0ced79cc150e9f4bacb83cf99f5ce1214768808c",
+	}, mock.lastCalledParams[0])
+}
+
+func Test_processUploadStream(t *testing.T) {
+	ctx := auth.Context{
+		Context: telemetry.ContextWithLogger(context.Background(), telemetry.NewLogger("finance-api-test")),
+		User:    &shared.User{ID: 1},
+	}
+	upload := service.UploadStream{JobID: 3, UploadType: shared.ReportTypeUploadPaymentsMOTOCard, BatchSize: 100}
+
+	t.Run("failed lines", func(t *testing.T) {
+		notifyClient := &mockNotify{}
+		mock := &mockService{failedLines: map[int]string{1: validation.UploadErrorClientNotFound}}
+		server := NewServer(mock, nil, nil, notifyClient, nil, nil, nil)
+
+		server.processUploadStream(ctx, "test@email.com", upload, strings.NewReader("col1,col2\nabc,1"))
+
+		assert.Equal(t, []string{"ProcessUploadStream", "CompleteUploadJob"}, mock.called)
+		lineCount := 2
+		assert.Equal(t, []interface{}{int32(3), service.UploadJobResult{LineCount: &lineCount, FailedLines: map[int]string{1: validation.UploadErrorClientNotFound}}}, mock.lastCalledParams)
+		assert.Equal(t, notify.ProcessingFailedTemplateId, notifyClient.payload.TemplateId)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		notifyClient := &mockNotify{}
+		mock := &mockService{errs: map[string]error{"ProcessUploadStream": errors.New("oops")}}
+		server := NewServer(mock, nil, nil, notifyClient, nil, nil, nil)
+
+		server.processUploadStream(ctx, "test@email.com", upload, strings.NewReader("col1,col2\nabc,1"))
+
+		result := mock.lastCalledParams[1].(service.UploadJobResult)
+		assert.EqualError(t, result.Err, "oops")
+		assert.Equal(t, notify.ProcessingErrorTemplateId, notifyClient.payload.TemplateId)
+	})
+}
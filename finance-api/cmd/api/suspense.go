@@ -0,0 +1,50 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+func (s *Server) getSuspenseItems(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	data, err := s.service.GetSuspenseItems(ctx)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(data)
+}
+
+func (s *Server) allocateSuspenseItem(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	var body shared.AllocateSuspense
+	defer unchecked(r.Body.Close)
+
+	suspenseId, err := s.getPathID(r, "suspenseId")
+	if err != nil {
+		return err
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return err
+	}
+
+	validationError := s.validator.ValidateStruct(body)
+
+	if len(validationError.Errors) != 0 {
+		return validationError
+	}
+
+	err = s.service.AllocateSuspenseItem(ctx, suspenseId, body)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/validation"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_getSuspenseItems(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/suspense", nil)
+	w := httptest.NewRecorder()
+
+	mock := &mockService{suspenseItems: shared.SuspenseItems{
+		{
+			ID:           1,
+			CourtRef:     "99999999",
+			Amount:       12300,
+			LedgerType:   shared.TransactionTypeMotoCardPayment,
+			BankDate:     shared.NewDate("2025-01-02"),
+			ReceivedDate: shared.NewDate("2025-01-01"),
+			UploadType:   shared.ReportTypeUploadPaymentsMOTOCard,
+			Line:         []string{"99999999", "01/01/2025", "123.00"},
+			CreatedDate:  shared.NewDate("2025-01-03"),
+			CreatedBy:    2,
+		},
+	}}
+	server := NewServer(mock, nil, nil, nil, nil, nil, nil)
+	err := server.getSuspenseItems(w, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"GetSuspenseItems"}, mock.called)
+
+	expected := `[{"id":1,"courtRef":"99999999","amount":12300,"ledgerType":"MOTO CARD PAYMENT","bankDate":"02\/01\/2025","receivedDate":"01\/01\/2025","uploadType":"PAYMENTS_MOTO_CARD","line":["99999999","01/01/2025","123.00"],"createdDate":"03\/01\/2025","createdBy":2}]`
+	assert.Equal(t, expected, strings.TrimSpace(w.Body.String()))
+}
+
+func TestServer_allocateSuspenseItem(t *testing.T) {
+	var b bytes.Buffer
+
+	_ = json.NewEncoder(&b).Encode(shared.AllocateSuspense{CourtRef: "12345678"})
+	req := httptest.NewRequest(http.MethodPost, "/suspense/3/allocate", &b)
+	req.SetPathValue("suspenseId", "3")
+	w := httptest.NewRecorder()
+
+	validator, _ := validation.New()
+
+	mock := &mockService{}
+	server := NewServer(mock, nil, nil, nil, nil, validator, nil)
+	err := server.allocateSuspenseItem(w, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, w.Result().StatusCode)
+	assert.Equal(t, []int{3}, mock.expectedIds)
+	assert.Equal(t, []interface{}{shared.AllocateSuspense{CourtRef: "12345678"}}, mock.lastCalledParams)
+}
+
+func TestServer_allocateSuspenseItemValidationError(t *testing.T) {
+	var b bytes.Buffer
+
+	_ = json.NewEncoder(&b).Encode(shared.AllocateSuspense{})
+	req := httptest.NewRequest(http.MethodPost, "/suspense/3/allocate", &b)
+	req.SetPathValue("suspenseId", "3")
+	w := httptest.NewRecorder()
+
+	validator, _ := validation.New()
+
+	mock := &mockService{}
+	server := NewServer(mock, nil, nil, nil, nil, validator, nil)
+	err := server.allocateSuspenseItem(w, req)
+
+	var e apierror.ValidationError
+	assert.ErrorAs(t, err, &e)
+	assert.Len(t, mock.called, 0)
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+func (s *Server) voidInvoice(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	var void shared.VoidInvoice
+	if err := json.NewDecoder(r.Body).Decode(&void); err != nil {
+		return err
+	}
+
+	validationError := s.validator.ValidateStruct(void)
+	if len(validationError.Errors) != 0 {
+		return validationError
+	}
+
+	clientId, err := s.getPathID(r, "clientId")
+	if err != nil {
+		return err
+	}
+	invoiceId, err := s.getPathID(r, "invoiceId")
+	if err != nil {
+		return err
+	}
+
+	err = s.service.VoidInvoice(ctx, clientId, invoiceId, void)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/validation"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_voidInvoice(t *testing.T) {
+	var b bytes.Buffer
+
+	void := shared.VoidInvoice{VoidReason: "Raised in error"}
+	_ = json.NewEncoder(&b).Encode(void)
+	req := httptest.NewRequest(http.MethodPost, "/clients/1/invoices/2/void", &b)
+	req.SetPathValue("clientId", "1")
+	req.SetPathValue("invoiceId", "2")
+	w := httptest.NewRecorder()
+
+	validator, _ := validation.New()
+
+	mock := &mockService{}
+	server := NewServer(mock, nil, nil, nil, nil, validator, nil)
+	err := server.voidInvoice(w, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, w.Result().StatusCode)
+	assert.Equal(t, []int{1, 2}, mock.expectedIds)
+	assert.Equal(t, []interface{}{void}, mock.lastCalledParams)
+}
+
+func TestServer_voidInvoiceValidationErrors(t *testing.T) {
+	var b bytes.Buffer
+
+	_ = json.NewEncoder(&b).Encode(shared.VoidInvoice{})
+	req := httptest.NewRequest(http.MethodPost, "/clients/1/invoices/2/void", &b)
+	req.SetPathValue("clientId", "1")
+	req.SetPathValue("invoiceId", "2")
+	w := httptest.NewRecorder()
+
+	validator, _ := validation.New()
+
+	mock := &mockService{}
+	server := NewServer(mock, nil, nil, nil, nil, validator, nil)
+	err := server.voidInvoice(w, req)
+
+	expected := apierror.ValidationError{Errors: apierror.ValidationErrors{
+		"VoidReason": {"required": "This field VoidReason needs to be looked at required"},
+	}}
+	assert.Equal(t, expected, err)
+	assert.Len(t, mock.called, 0)
+}
+
+func TestServer_voidInvoiceAlreadyVoided(t *testing.T) {
+	var b bytes.Buffer
+
+	_ = json.NewEncoder(&b).Encode(shared.VoidInvoice{VoidReason: "Raised in error"})
+	req := httptest.NewRequest(http.MethodPost, "/clients/1/invoices/2/void", &b)
+	req.SetPathValue("clientId", "1")
+	req.SetPathValue("invoiceId", "2")
+	w := httptest.NewRecorder()
+
+	validator, _ := validation.New()
+
+	expected := apierror.BadRequestError("invoice", "Invoice AD11111/24 has already been voided", nil)
+	mock := &mockService{errs: map[string]error{"VoidInvoice": expected}}
+	server := NewServer(mock, nil, nil, nil, nil, validator, nil)
+	err := server.voidInvoice(w, req)
+
+	assert.Equal(t, expected, err)
+}
@@ -0,0 +1,122 @@
+package db
+
+import (
+	"time"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+// FeeAccrual generates a report of the fee income accrued over a given date range. Each invoice fee range is accrued
+// evenly across the days it covers, and the report includes the share of each fee range that falls within the date
+// range. Invoices without fee ranges are accrued over their own start and end dates, so a one-off fee accrues in full
+// on the day it starts. Invoices voided by the end of the date range are excluded.
+// If the date range is not provided, it defaults to the system go-live date and the current date respectively.
+type FeeAccrual struct {
+	ReportQuery
+	FeeAccrualInput
+}
+
+type FeeAccrualInput struct {
+	FromDate   *shared.Date
+	ToDate     *shared.Date
+	GoLiveDate time.Time
+}
+
+func NewFeeAccrual(input FeeAccrualInput) ReportQuery {
+	return &FeeAccrual{
+		ReportQuery:     NewReportQuery(FeeAccrualQuery),
+		FeeAccrualInput: input,
+	}
+}
+
+const FeeAccrualQuery = `
+WITH fee_periods AS (SELECT i.id,
+                            i.finance_client_id,
+                            i.feetype,
+                            i.reference,
+                            CASE
+                                WHEN i.feetype IN ('AD', 'GA', 'GS', 'GT') THEN i.feetype
+                                ELSE COALESCE(ifr.supervisionlevel, i.supervisionlevel, '')
+                                END                             AS supervision_level,
+                            COALESCE(ifr.fromdate, i.startdate) AS period_start,
+                            COALESCE(ifr.todate, i.enddate)     AS period_end,
+                            COALESCE(ifr.amount, i.amount)      AS amount
+                     FROM supervision_finance.invoice i
+                              LEFT JOIN supervision_finance.invoice_fee_range ifr ON ifr.invoice_id = i.id
+                     WHERE i.voided_at IS NULL
+                        OR i.voided_at::DATE > $2::DATE),
+     accruals AS (SELECT fp.*,
+                         fp.period_end - fp.period_start + 1                                        AS days_in_period,
+                         LEAST(fp.period_end, $2::DATE) - GREATEST(fp.period_start, $1::DATE) + 1 AS days_accrued
+                  FROM fee_periods fp
+                  WHERE fp.period_start <= $2::DATE
+                    AND fp.period_end >= $1::DATE
+                    AND fp.period_end >= fp.period_start)
+SELECT CONCAT(p.firstname, ' ', p.surname)                                                      AS "Customer name",
+       p.caserecnumber                                                                          AS "Customer number",
+       fc.sop_number                                                                            AS "SOP number",
+       '="0470"'                                                                                AS "Entity",
+       cc.code                                                                                  AS "Revenue cost centre",
+       cc.cost_centre_description                                                               AS "Revenue cost centre description",
+       a.code                                                                                   AS "Revenue account code",
+       a.account_code_description                                                               AS "Revenue account code description",
+       ac.feetype                                                                               AS "Invoice type",
+       ac.reference                                                                             AS "Invoice number",
+       ac.supervision_level                                                                     AS "Supervision level",
+       TO_CHAR(ac.period_start, 'YYYY-MM-DD')                                                   AS "Fee period start",
+       TO_CHAR(ac.period_end, 'YYYY-MM-DD')                                                     AS "Fee period end",
+       ac.days_in_period                                                                        AS "Days in fee period",
+       ac.days_accrued                                                                          AS "Days accrued",
+       (ac.amount / 100.0)::NUMERIC(10, 2)::VARCHAR(255)                                        AS "Fee amount",
+       (ROUND(ac.amount * ac.days_accrued / ac.days_in_period::NUMERIC) / 100.0)::NUMERIC(10, 2)::VARCHAR(255) AS "Accrued amount"
+FROM accruals ac
+         JOIN supervision_finance.finance_client fc ON fc.id = ac.finance_client_id
+         JOIN public.persons p ON fc.client_id = p.id
+         JOIN supervision_finance.transaction_type tt
+              ON ac.feetype = tt.fee_type AND ac.supervision_level = tt.supervision_level
+         JOIN supervision_finance.account a ON tt.account_code = a.code
+         JOIN supervision_finance.cost_centre cc ON cc.code = a.cost_centre
+ORDER BY p.caserecnumber, ac.reference, ac.period_start;
+`
+
+func (f *FeeAccrual) GetHeaders() []string {
+	return []string{
+		"Customer name",
+		"Customer number",
+		"SOP number",
+		"Entity",
+		"Revenue cost centre",
+		"Revenue cost centre description",
+		"Revenue account code",
+		"Revenue account code description",
+		"Invoice type",
+		"Invoice number",
+		"Supervision level",
+		"Fee period start",
+		"Fee period end",
+		"Days in fee period",
+		"Days accrued",
+		"Fee amount",
+		"Accrued amount",
+	}
+}
+
+func (f *FeeAccrual) GetParams() []any {
+	var (
+		from, to time.Time
+	)
+
+	if f.FromDate == nil || f.FromDate.IsNull() {
+		from = f.GoLiveDate
+	} else {
+		from = f.FromDate.Time
+	}
+
+	if f.ToDate == nil || f.ToDate.IsNull() {
+		to = time.Now()
+	} else {
+		to = f.ToDate.Time
+	}
+
+	return []any{from.Format("2006-01-02"), to.Format("2006-01-02")}
+}
@@ -0,0 +1,125 @@
+package db
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationSuite) Test_fee_accrual() {
+	ctx := suite.ctx
+
+	// one client with an annual general supervision fee of £365, accruing at £1 a day:
+	client1ID := suite.seeder.CreateClient(ctx, "Ian", "Test", "11111111", "1111", "ACTIVE")
+	suite.seeder.CreateOrder(ctx, client1ID, "pfa")
+	_, client1InvoiceRef := suite.seeder.CreateInvoice(ctx, client1ID, shared.InvoiceTypeS2, valToPtr("365.00"), valToPtr("2025-03-31"), valToPtr("2024-04-01"), nil, nil, nil)
+
+	// one client with one-off AD fees, one of which is voided within the date range and one afterwards:
+	client2ID := suite.seeder.CreateClient(ctx, "Barry", "Giggle", "22222222", "2222", "ACTIVE")
+	suite.seeder.CreateOrder(ctx, client2ID, "pfa")
+	_, client2Invoice1Ref := suite.seeder.CreateInvoice(ctx, client2ID, shared.InvoiceTypeAD, nil, valToPtr("2024-07-01"), nil, nil, nil, nil)
+	client2Invoice2ID, _ := suite.seeder.CreateInvoice(ctx, client2ID, shared.InvoiceTypeAD, nil, valToPtr("2024-07-02"), nil, nil, nil, nil)
+	client2Invoice3ID, client2Invoice3Ref := suite.seeder.CreateInvoice(ctx, client2ID, shared.InvoiceTypeAD, nil, valToPtr("2024-07-03"), nil, nil, nil, nil)
+	suite.seeder.SeedData(
+		fmt.Sprintf("UPDATE supervision_finance.invoice SET voided_at = '2024-08-01' WHERE id = %d", client2Invoice2ID),
+		fmt.Sprintf("UPDATE supervision_finance.invoice SET voided_at = '2024-09-01' WHERE id = %d", client2Invoice3ID),
+	)
+
+	// fee outside the date range:
+	_, _ = suite.seeder.CreateInvoice(ctx, client2ID, shared.InvoiceTypeAD, nil, valToPtr("2024-09-01"), nil, nil, nil, nil)
+
+	c := Client{suite.seeder.Conn}
+
+	from := shared.NewDate("2024-06-01")
+	to := shared.NewDate("2024-08-12")
+
+	rows, err := c.Run(ctx, NewFeeAccrual(FeeAccrualInput{
+		FromDate: &from,
+		ToDate:   &to,
+	}))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 3, len(rows))
+
+	results := mapByHeader(rows)
+	assert.NotEmpty(suite.T(), results)
+
+	// client 1
+	assert.Equal(suite.T(), "Ian Test", results[0]["Customer name"], "Customer name - client 1")
+	assert.Equal(suite.T(), "11111111", results[0]["Customer number"], "Customer number - client 1")
+	assert.Equal(suite.T(), "1111", results[0]["SOP number"], "SOP number - client 1")
+	assert.Equal(suite.T(), "=\"0470\"", results[0]["Entity"], "Entity - client 1")
+	assert.Equal(suite.T(), "10482009", results[0]["Revenue cost centre"], "Revenue cost centre - client 1")
+	assert.Equal(suite.T(), "Supervision Investigations", results[0]["Revenue cost centre description"], "Revenue cost centre description - client 1")
+	assert.Equal(suite.T(), "4481102094", results[0]["Revenue account code"], "Revenue account code - client 1")
+	assert.Equal(suite.T(), "INC - RECEIPT OF FEES AND CHARGES - Supervision Fee 1", results[0]["Revenue account code description"], "Revenue account code description - client 1")
+	assert.Equal(suite.T(), "S2", results[0]["Invoice type"], "Invoice type - client 1")
+	assert.Equal(suite.T(), client1InvoiceRef, results[0]["Invoice number"], "Invoice number - client 1")
+	assert.Equal(suite.T(), "GENERAL", results[0]["Supervision level"], "Supervision level - client 1")
+	assert.Equal(suite.T(), "2024-04-01", results[0]["Fee period start"], "Fee period start - client 1")
+	assert.Equal(suite.T(), "2025-03-31", results[0]["Fee period end"], "Fee period end - client 1")
+	assert.Equal(suite.T(), "365", results[0]["Days in fee period"], "Days in fee period - client 1")
+	assert.Equal(suite.T(), "73", results[0]["Days accrued"], "Days accrued - client 1")
+	assert.Equal(suite.T(), "365.00", results[0]["Fee amount"], "Fee amount - client 1")
+	assert.Equal(suite.T(), "73.00", results[0]["Accrued amount"], "Accrued amount - client 1")
+
+	// client 2, invoice 1
+	assert.Equal(suite.T(), "Barry Giggle", results[1]["Customer name"], "Customer name - client 2, invoice 1")
+	assert.Equal(suite.T(), "4481102093", results[1]["Revenue account code"], "Revenue account code - client 2, invoice 1")
+	assert.Equal(suite.T(), "INC - RECEIPT OF FEES AND CHARGES - Appoint Deputy", results[1]["Revenue account code description"], "Revenue account code description - client 2, invoice 1")
+	assert.Equal(suite.T(), "AD", results[1]["Invoice type"], "Invoice type - client 2, invoice 1")
+	assert.Equal(suite.T(), client2Invoice1Ref, results[1]["Invoice number"], "Invoice number - client 2, invoice 1")
+	assert.Equal(suite.T(), "AD", results[1]["Supervision level"], "Supervision level - client 2, invoice 1")
+	assert.Equal(suite.T(), "2024-07-01", results[1]["Fee period start"], "Fee period start - client 2, invoice 1")
+	assert.Equal(suite.T(), "2024-07-01", results[1]["Fee period end"], "Fee period end - client 2, invoice 1")
+	assert.Equal(suite.T(), "1", results[1]["Days in fee period"], "Days in fee period - client 2, invoice 1")
+	assert.Equal(suite.T(), "1", results[1]["Days accrued"], "Days accrued - client 2, invoice 1")
+	assert.Equal(suite.T(), "100.00", results[1]["Fee amount"], "Fee amount - client 2, invoice 1")
+	assert.Equal(suite.T(), "100.00", results[1]["Accrued amount"], "Accrued amount - client 2, invoice 1")
+
+	// client 2, invoice 3 - voided after the date range
+	assert.Equal(suite.T(), client2Invoice3Ref, results[2]["Invoice number"], "Invoice number - client 2, invoice 3")
+	assert.Equal(suite.T(), "2024-07-03", results[2]["Fee period start"], "Fee period start - client 2, invoice 3")
+	assert.Equal(suite.T(), "100.00", results[2]["Accrued amount"], "Accrued amount - client 2, invoice 3")
+}
+
+func Test_feeAccrual_getParams(t *testing.T) {
+	today := time.Now()
+	goLiveDate := today.AddDate(-4, 0, 0)
+	toDate := shared.NewDate(today.AddDate(-1, 0, 0).Format("2006-01-02"))
+	fromDate := shared.NewDate(today.AddDate(-2, 0, 0).Format("2006-01-02"))
+
+	tests := []struct {
+		name     string
+		fromDate *shared.Date
+		toDate   *shared.Date
+		expected []any
+	}{
+		{
+			name:     "No FromDate and ToDate",
+			fromDate: nil,
+			toDate:   nil,
+			expected: []any{goLiveDate.Format("2006-01-02"), today.Format("2006-01-02")},
+		},
+		{
+			name:     "With FromDate and ToDate",
+			fromDate: &fromDate,
+			toDate:   &toDate,
+			expected: []any{fromDate.Time.Format("2006-01-02"), toDate.Time.Format("2006-01-02")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feeAccrual := NewFeeAccrual(FeeAccrualInput{
+				FromDate:   tt.fromDate,
+				ToDate:     tt.toDate,
+				GoLiveDate: goLiveDate,
+			})
+			params := feeAccrual.GetParams()
+			assert.Equal(t, tt.expected, params)
+		})
+	}
+}
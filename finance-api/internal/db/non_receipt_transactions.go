@@ -5,7 +5,7 @@ import (
 )
 
 // NonReceiptTransactions generates the non-receipts transactions journal for a given date. The journal displays all
-// non-receipt transactions (invoices, adjustments, unapplies, and reapplies) that were created on that date, grouped by
+// non-receipt transactions (invoices, adjustments, unapplies, reapplies, credit transfers and invoice voids) that were created on that date, grouped by
 // transaction type and create date.  This line description matches a schedule report, which breaks down the transactions
 // further for reconciliation purposes.
 type NonReceiptTransactions struct {
@@ -82,9 +82,17 @@ const NonReceiptTransactionsQuery = `WITH
 			WHEN line_description LIKE 'GA Fee reduction reversal%' THEN 51
 			WHEN line_description LIKE 'GS Fee reduction reversal%' THEN 52
 			WHEN line_description LIKE 'GT Fee reduction reversal%' THEN 53
-			WHEN fee_type = 'UA' THEN 54
-			WHEN fee_type = 'RA' THEN 55
-			ELSE 56
+			WHEN line_description LIKE 'AD Invoice void%' THEN 54
+			WHEN line_description LIKE 'Gen Invoice void%' THEN 55
+			WHEN line_description LIKE 'Min Invoice void%' THEN 56
+			WHEN line_description LIKE 'GA Invoice void%' THEN 57
+			WHEN line_description LIKE 'GS Invoice void%' THEN 58
+			WHEN line_description LIKE 'GT Invoice void%' THEN 59
+			WHEN fee_type = 'UA' THEN 60
+			WHEN fee_type = 'RA' THEN 61
+			WHEN fee_type = 'CTO' THEN 62
+			WHEN fee_type = 'CTI' THEN 63
+			ELSE 64
 			END AS index
 	FROM supervision_finance.transaction_type 
 ),
@@ -117,6 +125,15 @@ transactions AS (
         INNER JOIN supervision_finance.ledger l ON l.id = la.ledger_id
 		INNER JOIN supervision_finance.invoice i ON i.id = la.invoice_id
 	WHERE l.created_at::DATE = $1 AND la.status IN ('UNAPPLIED', 'REAPPLIED')
+	UNION ALL
+	SELECT
+		NULL AS ledger_type,
+		CASE WHEN la.amount > 0 THEN 'CTO' ELSE 'CTI' END AS fee_type,
+		la.amount AS amount,
+		NULL AS invoice_id
+	FROM supervision_finance.ledger_allocation la
+		INNER JOIN supervision_finance.ledger l ON l.id = la.ledger_id
+	WHERE l.created_at::DATE = $1 AND l.type = 'CREDIT TRANSFER'
 ),
 transaction_totals AS (
 	SELECT
@@ -143,15 +160,15 @@ transaction_totals AS (
 		SELECT 
 		    tto.index, 
 		    CASE WHEN n % 2 = 1  THEN
-		 	CASE WHEN t.fee_type IN ('UA', 'RA') THEN '1816102005' ELSE tt.account_code END
+		 	CASE WHEN t.fee_type IN ('UA', 'RA', 'CTO', 'CTI') THEN '1816102005' ELSE tt.account_code END
 		ELSE
-			CASE WHEN t.fee_type IN ('UA', 'RA') THEN tt.account_code ELSE '1816102003' END
+			CASE WHEN t.fee_type IN ('UA', 'RA', 'CTO', 'CTI') THEN tt.account_code ELSE '1816102003' END
         END account_code, 
 		    line_description 
 		FROM supervision_finance.transaction_type tt
 		INNER JOIN transaction_type_order tto ON tt.id = tto.id
 		WHERE (tt.ledger_type = t.ledger_type OR (t.ledger_type IS NULL AND tt.fee_type = t.fee_type)) 
-		AND (t.fee_type IN ('UA', 'RA') OR sl.supervision_level = tt.supervision_level)
+		AND (t.fee_type IN ('UA', 'RA', 'CTO', 'CTI') OR sl.supervision_level = tt.supervision_level)
 	) tt ON TRUE
 	INNER JOIN supervision_finance.account ON tt.account_code = account.code
 	GROUP BY tt.line_description, tt.account_code, account.cost_centre, tt.index, n
@@ -436,3 +436,87 @@ func (suite *IntegrationSuite) Test_non_receipt_transactions() {
 	assert.Equal(suite.T(), "15.00", results[31]["Credit"], "Credit - Reapply Credit")
 	assert.Equal(suite.T(), fmt.Sprintf("Reapply [%s]", yesterday.Date().Format("02/01/2006")), results[31]["Line description"], "Line description - Reapply Credit")
 }
+
+func (suite *IntegrationSuite) Test_non_receipt_transactions_credit_transfer() {
+	ctx := suite.ctx
+
+	today := suite.seeder.Today()
+	yesterday := today.Sub(0, 0, 1)
+
+	fromClientID := suite.seeder.CreateClient(ctx, "Ian", "From", "11111111", "1111", "ACTIVE")
+	toClientID := suite.seeder.CreateClient(ctx, "Ian", "To", "22222222", "2222", "ACTIVE")
+
+	suite.seeder.SeedData(
+		fmt.Sprintf("INSERT INTO supervision_finance.ledger (id, reference, datetime, method, amount, notes, type, status, finance_client_id, created_at, created_by) VALUES (1, 'ct-out', '%s', '', -2500, '', 'CREDIT TRANSFER', 'CONFIRMED', %d, '%s', 1);", yesterday.String(), fromClientID, yesterday.String()),
+		fmt.Sprintf("INSERT INTO supervision_finance.ledger_allocation (id, ledger_id, invoice_id, datetime, amount, status) VALUES (1, 1, NULL, '%s', 2500, 'REAPPLIED');", yesterday.String()),
+		fmt.Sprintf("INSERT INTO supervision_finance.ledger (id, reference, datetime, method, amount, notes, type, status, finance_client_id, created_at, created_by) VALUES (2, 'ct-in', '%s', '', 2500, '', 'CREDIT TRANSFER', 'CONFIRMED', %d, '%s', 1);", yesterday.String(), toClientID, yesterday.String()),
+		fmt.Sprintf("INSERT INTO supervision_finance.ledger_allocation (id, ledger_id, invoice_id, datetime, amount, status) VALUES (2, 2, NULL, '%s', -2500, 'UNAPPLIED');", yesterday.String()),
+	)
+
+	c := Client{suite.seeder.Conn}
+
+	rows, err := c.Run(ctx, NewNonReceiptTransactions(NonReceiptTransactionsInput{Date: &shared.Date{Time: yesterday.Date()}}))
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 5, len(rows))
+
+	results := mapByHeader(rows)
+	assert.NotEmpty(suite.T(), results)
+
+	// transferred out of the sending client's credit
+	assert.Equal(suite.T(), "1816102005", results[0]["Account"], "Account - Credit transfer out debit")
+	assert.Equal(suite.T(), "25.00", results[0]["Debit"], "Debit - Credit transfer out debit")
+	assert.Equal(suite.T(), "", results[0]["Credit"], "Credit - Credit transfer out debit")
+	assert.Equal(suite.T(), fmt.Sprintf("Credit transfer out [%s]", yesterday.UKString()), results[0]["Line description"], "Line description - Credit transfer out debit")
+
+	assert.Equal(suite.T(), "1816102003", results[1]["Account"], "Account - Credit transfer out credit")
+	assert.Equal(suite.T(), "", results[1]["Debit"], "Debit - Credit transfer out credit")
+	assert.Equal(suite.T(), "25.00", results[1]["Credit"], "Credit - Credit transfer out credit")
+
+	// transferred into the receiving client's credit
+	assert.Equal(suite.T(), "1816102005", results[2]["Account"], "Account - Credit transfer in credit")
+	assert.Equal(suite.T(), "", results[2]["Debit"], "Debit - Credit transfer in credit")
+	assert.Equal(suite.T(), "25.00", results[2]["Credit"], "Credit - Credit transfer in credit")
+	assert.Equal(suite.T(), fmt.Sprintf("Credit transfer in [%s]", yesterday.UKString()), results[2]["Line description"], "Line description - Credit transfer in credit")
+
+	assert.Equal(suite.T(), "1816102003", results[3]["Account"], "Account - Credit transfer in debit")
+	assert.Equal(suite.T(), "25.00", results[3]["Debit"], "Debit - Credit transfer in debit")
+	assert.Equal(suite.T(), "", results[3]["Credit"], "Credit - Credit transfer in debit")
+}
+
+func (suite *IntegrationSuite) Test_non_receipt_transactions_invoice_void() {
+	ctx := suite.ctx
+
+	today := suite.seeder.Today()
+	yesterday := today.Sub(0, 0, 1)
+	twoMonthsAgo := today.Sub(0, 2, 0)
+
+	clientID := suite.seeder.CreateClient(ctx, "Ian", "Void", "12345678", "1234", "ACTIVE")
+	suite.seeder.CreateOrder(ctx, clientID, "pfa")
+
+	invoiceID, _ := suite.seeder.CreateInvoice(ctx, clientID, shared.InvoiceTypeAD, nil, twoMonthsAgo.StringPtr(), nil, nil, nil, twoMonthsAgo.StringPtr())
+
+	suite.seeder.SeedData(
+		fmt.Sprintf("INSERT INTO supervision_finance.ledger (id, reference, datetime, method, amount, notes, type, status, finance_client_id, created_at, created_by) VALUES (99, 'void', '%s', '', 10000, '', 'INVOICE VOID', 'CONFIRMED', (SELECT id FROM supervision_finance.finance_client WHERE client_id = %d), '%s', 1);", yesterday.String(), clientID, yesterday.String()),
+		fmt.Sprintf("INSERT INTO supervision_finance.ledger_allocation (id, ledger_id, invoice_id, datetime, amount, status) VALUES (99, 99, %d, '%s', 10000, 'ALLOCATED');", invoiceID, yesterday.String()),
+	)
+
+	c := Client{suite.seeder.Conn}
+
+	rows, err := c.Run(ctx, NewNonReceiptTransactions(NonReceiptTransactionsInput{Date: &shared.Date{Time: yesterday.Date()}}))
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 3, len(rows))
+
+	results := mapByHeader(rows)
+	assert.NotEmpty(suite.T(), results)
+
+	assert.Equal(suite.T(), "4481102093", results[0]["Account"], "Account - AD Invoice void debit")
+	assert.Equal(suite.T(), "100.00", results[0]["Debit"], "Debit - AD Invoice void debit")
+	assert.Equal(suite.T(), "", results[0]["Credit"], "Credit - AD Invoice void debit")
+	assert.Equal(suite.T(), fmt.Sprintf("AD Invoice void [%s]", yesterday.UKString()), results[0]["Line description"], "Line description - AD Invoice void debit")
+
+	assert.Equal(suite.T(), "1816102003", results[1]["Account"], "Account - AD Invoice void credit")
+	assert.Equal(suite.T(), "", results[1]["Debit"], "Debit - AD Invoice void credit")
+	assert.Equal(suite.T(), "100.00", results[1]["Credit"], "Credit - AD Invoice void credit")
+}
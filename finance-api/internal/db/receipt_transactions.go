@@ -10,6 +10,8 @@ import (
 // further for reconciliation purposes.
 // For the purpose of debit/credit categorisation, refunds are considered as payment reversals, and refund reversals
 // are considered as payments.
+// Payments held in suspense are journalled against the suspense account on the date they are uploaded, and moved back
+// out of it on the date they are allocated to a client.
 type ReceiptTransactions struct {
 	ReportQuery
 	ReceiptTransactionsInput
@@ -92,6 +94,21 @@ allocation_totals AS (
 	WHERE l.created_at::DATE = $1
 	GROUP BY tt.line_description, tt.index, l.bankdate, l.type, l.pis_number
 ),
+suspense_totals AS (
+    SELECT
+        'Suspense [' || TO_CHAR(s.bank_date, 'DD/MM/YYYY') || ']' AS line_description,
+        CASE
+            WHEN s.type = 'SUPERVISION BACS PAYMENT' THEN '1841102088'
+            ELSE '1841102050'
+        END AS bank_account_code,
+        SUM(CASE WHEN s.created_at::DATE = $1 THEN s.amount ELSE 0 END) AS held_amount,
+        SUM(CASE WHEN s.allocated_at::DATE = $1 THEN s.amount ELSE 0 END) AS allocated_amount,
+        s.bank_date AS bankdate,
+        8 AS index
+    FROM supervision_finance.suspense s
+    WHERE s.created_at::DATE = $1 OR s.allocated_at::DATE = $1
+    GROUP BY s.bank_date, bank_account_code
+),
 transaction_rows AS (
     -- debit row
     SELECT
@@ -196,6 +213,74 @@ transaction_rows AS (
         6 AS n
     FROM allocation_totals
     WHERE reversed_overpayment_amount > 0
+    UNION ALL
+    -- suspense held debit row
+    SELECT
+        bank_account_code AS account_code,
+        '="0000000"' AS objective,
+        '="00000000"' AS analysis,
+        '="0000"' AS intercompany,
+        '="000000"' AS spare,
+        (held_amount / 100.0)::NUMERIC(10, 2)::VARCHAR(255) AS debit,
+        '' AS credit,
+        line_description,
+        bankdate,
+        NULL::INTEGER AS pis_number,
+        index,
+        1 AS n
+    FROM suspense_totals
+    WHERE held_amount > 0
+    UNION ALL
+    -- suspense held credit row
+    SELECT
+        '1816102007' AS account_code,
+        '="0000000"' AS objective,
+        '="00000000"' AS analysis,
+        '="0000"' AS intercompany,
+        '="00000"' AS spare,
+        '' AS debit,
+        (held_amount / 100.0)::NUMERIC(10, 2)::VARCHAR(255) AS credit,
+        line_description,
+        bankdate,
+        NULL::INTEGER AS pis_number,
+        index,
+        2 AS n
+    FROM suspense_totals
+    WHERE held_amount > 0
+    UNION ALL
+    -- suspense allocated debit row
+    SELECT
+        '1816102007' AS account_code,
+        '="0000000"' AS objective,
+        '="00000000"' AS analysis,
+        '="0000"' AS intercompany,
+        '="00000"' AS spare,
+        (allocated_amount / 100.0)::NUMERIC(10, 2)::VARCHAR(255) AS debit,
+        '' AS credit,
+        line_description,
+        bankdate,
+        NULL::INTEGER AS pis_number,
+        index,
+        3 AS n
+    FROM suspense_totals
+    WHERE allocated_amount > 0
+    UNION ALL
+    -- suspense allocated credit row
+    SELECT
+        bank_account_code AS account_code,
+        '="0000000"' AS objective,
+        '="00000000"' AS analysis,
+        '="0000"' AS intercompany,
+        '="000000"' AS spare,
+        '' AS debit,
+        (allocated_amount / 100.0)::NUMERIC(10, 2)::VARCHAR(255) AS credit,
+        line_description,
+        bankdate,
+        NULL::INTEGER AS pis_number,
+        index,
+        4 AS n
+    FROM suspense_totals
+    WHERE allocated_amount > 0
 )
 SELECT
     '="0470"' AS "Entity",
@@ -493,3 +493,48 @@ func (suite *IntegrationSuite) Test_receipt_transactions_reversals() {
 	assert.Equal(suite.T(), "40.00", results[14]["Credit"], "Credit - Refund Reversal on invoice")
 	assert.Equal(suite.T(), fmt.Sprintf("Refund [%s]", yesterday.Date().Format("02/01/2006")), results[14]["Line description"], "Line description - Refund Reversal on invoice")
 }
+
+func (suite *IntegrationSuite) Test_receipt_transactions_suspense() {
+	ctx := suite.ctx
+
+	today := suite.seeder.Today()
+	yesterday := today.Sub(0, 0, 1)
+	twoDaysAgo := today.Sub(0, 0, 2)
+
+	suite.seeder.SeedData(
+		// held yesterday
+		fmt.Sprintf("INSERT INTO supervision_finance.suspense VALUES (1, '11111111', 12345, 'MOTO CARD PAYMENT', '%s', '%s', NULL, 'PAYMENTS_MOTO_CARD', '[]', '%s', 1);", twoDaysAgo.String(), twoDaysAgo.String(), yesterday.String()),
+		// held two days ago, allocated yesterday
+		fmt.Sprintf("INSERT INTO supervision_finance.suspense VALUES (2, '22222222', 5000, 'SUPERVISION BACS PAYMENT', '%s', '%s', NULL, 'PAYMENTS_SUPERVISION_BACS', '[]', '%s', 1, '33333333', NULL, '%s', 1);", twoDaysAgo.String(), twoDaysAgo.String(), twoDaysAgo.String(), yesterday.String()),
+		// held today
+		fmt.Sprintf("INSERT INTO supervision_finance.suspense VALUES (3, '44444444', 1000, 'MOTO CARD PAYMENT', '%s', '%s', NULL, 'PAYMENTS_MOTO_CARD', '[]', '%s', 1);", today.String(), today.String(), today.String()),
+	)
+
+	c := Client{suite.seeder.Conn}
+
+	rows, err := c.Run(ctx, NewReceiptTransactions(ReceiptTransactionsInput{Date: &shared.Date{Time: yesterday.Date()}}))
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 5, len(rows))
+
+	results := mapByHeader(rows)
+	assert.NotEmpty(suite.T(), results)
+
+	lineDescription := fmt.Sprintf("Suspense [%s]", twoDaysAgo.UKString())
+
+	// held in suspense
+	assert.Equal(suite.T(), "1841102050", results[0]["Account"], "Account - Suspense held debit")
+	assert.Equal(suite.T(), "123.45", results[0]["Debit"], "Debit - Suspense held debit")
+	assert.Equal(suite.T(), lineDescription, results[0]["Line description"], "Line description - Suspense held debit")
+
+	assert.Equal(suite.T(), "1816102007", results[1]["Account"], "Account - Suspense held credit")
+	assert.Equal(suite.T(), "123.45", results[1]["Credit"], "Credit - Suspense held credit")
+
+	// allocated from suspense
+	assert.Equal(suite.T(), "1816102007", results[2]["Account"], "Account - Suspense allocated debit")
+	assert.Equal(suite.T(), "50.00", results[2]["Debit"], "Debit - Suspense allocated debit")
+
+	assert.Equal(suite.T(), "1841102088", results[3]["Account"], "Account - Suspense allocated credit")
+	assert.Equal(suite.T(), "50.00", results[3]["Credit"], "Credit - Suspense allocated credit")
+	assert.Equal(suite.T(), lineDescription, results[3]["Line description"], "Line description - Suspense allocated credit")
+}
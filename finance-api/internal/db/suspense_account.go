@@ -0,0 +1,72 @@
+package db
+
+import (
+	"time"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+// SuspenseAccount generates a report of all payments held in suspense as of a specified date. A payment is held from
+// the date it was uploaded until the date it was allocated to a client.
+// If the date is not provided, it defaults to the current date.
+type SuspenseAccount struct {
+	ReportQuery
+	SuspenseAccountInput
+}
+
+type SuspenseAccountInput struct {
+	ToDate *shared.Date
+}
+
+func NewSuspenseAccount(input SuspenseAccountInput) ReportQuery {
+	return &SuspenseAccount{
+		ReportQuery:          NewReportQuery(SuspenseAccountQuery),
+		SuspenseAccountInput: input,
+	}
+}
+
+const SuspenseAccountQuery = `SELECT COALESCE(s.court_ref, '')                                                  AS "Court reference",
+       s.type                                                                             AS "Payment type",
+       '0470'                                                                             AS "Entity",
+       cc.code                                                                            AS "Receivables cost centre",
+       cc.cost_centre_description                                                         AS "Receivables cost centre description",
+       a.code                                                                             AS "Receivables account code",
+       a.account_code_description                                                         AS "Receivables account code description",
+       CASE WHEN s.bank_date IS NOT NULL THEN TO_CHAR(s.bank_date, 'YYYY-MM-DD') ELSE '' END AS "Receipt date",
+       TO_CHAR(s.created_at, 'YYYY-MM-DD')                                                AS "Sirius upload date",
+       ((s.amount / 100.0)::NUMERIC(10, 2))::VARCHAR(255)                                 AS "Amount"
+FROM supervision_finance.suspense s
+         JOIN supervision_finance.account a ON a.code = 1816102007
+         JOIN supervision_finance.cost_centre cc ON cc.code = a.cost_centre
+WHERE s.created_at::DATE <= $1::DATE
+  AND (s.allocated_at IS NULL OR s.allocated_at::DATE > $1::DATE)
+ORDER BY s.created_at, s.id;` // #nosec G101 -- False Positive
+
+func (s *SuspenseAccount) GetHeaders() []string {
+	return []string{
+		"Court reference",
+		"Payment type",
+		"Entity",
+		"Receivables cost centre",
+		"Receivables cost centre description",
+		"Receivables account code",
+		"Receivables account code description",
+		"Receipt date",
+		"Sirius upload date",
+		"Amount",
+	}
+}
+
+func (s *SuspenseAccount) GetParams() []any {
+	var (
+		to time.Time
+	)
+
+	if s.ToDate == nil {
+		to = time.Now()
+	} else {
+		to = s.ToDate.Time
+	}
+
+	return []any{to.Format("2006-01-02")}
+}
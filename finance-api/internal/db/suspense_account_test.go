@@ -0,0 +1,53 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationSuite) Test_suspense_account() {
+	ctx := suite.ctx
+
+	today := suite.seeder.Today()
+	yesterday := today.Sub(0, 0, 1)
+	twoDaysAgo := today.Sub(0, 0, 2)
+	twoMonthsAgo := today.Sub(0, 2, 0)
+
+	suite.seeder.SeedData(
+		// held before the report date and not yet allocated
+		fmt.Sprintf("INSERT INTO supervision_finance.suspense VALUES (1, '11111111', 12345, 'MOTO CARD PAYMENT', '%s', '%s', NULL, 'PAYMENTS_MOTO_CARD', '[]', '%s', 1);", twoMonthsAgo.String(), twoMonthsAgo.String(), twoMonthsAgo.String()),
+		// held before the report date and allocated after it
+		fmt.Sprintf("INSERT INTO supervision_finance.suspense VALUES (2, '22222222', 5000, 'SUPERVISION BACS PAYMENT', '%s', '%s', NULL, 'PAYMENTS_SUPERVISION_BACS', '[]', '%s', 1, '33333333', NULL, '%s', 1);", twoDaysAgo.String(), twoDaysAgo.String(), twoDaysAgo.String(), today.String()),
+		// allocated before the report date
+		fmt.Sprintf("INSERT INTO supervision_finance.suspense VALUES (3, '44444444', 1000, 'MOTO CARD PAYMENT', '%s', '%s', NULL, 'PAYMENTS_MOTO_CARD', '[]', '%s', 1, '55555555', NULL, '%s', 1);", twoMonthsAgo.String(), twoMonthsAgo.String(), twoMonthsAgo.String(), twoDaysAgo.String()),
+		// held after the report date
+		fmt.Sprintf("INSERT INTO supervision_finance.suspense VALUES (4, '66666666', 2000, 'MOTO CARD PAYMENT', '%s', '%s', NULL, 'PAYMENTS_MOTO_CARD', '[]', '%s', 1);", today.String(), today.String(), today.String()),
+	)
+
+	c := Client{suite.seeder.Conn}
+
+	to := shared.NewDate(yesterday.String())
+
+	rows, err := c.Run(ctx, NewSuspenseAccount(SuspenseAccountInput{
+		ToDate: &to,
+	}))
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 3, len(rows))
+
+	results := mapByHeader(rows)
+	assert.NotEmpty(suite.T(), results)
+
+	assert.Equal(suite.T(), "11111111", results[0]["Court reference"], "Court reference - item 1")
+	assert.Equal(suite.T(), "MOTO CARD PAYMENT", results[0]["Payment type"], "Payment type - item 1")
+	assert.Equal(suite.T(), "1816102007", results[0]["Receivables account code"], "Receivables account code - item 1")
+	assert.Equal(suite.T(), twoMonthsAgo.String(), results[0]["Receipt date"], "Receipt date - item 1")
+	assert.Equal(suite.T(), twoMonthsAgo.String(), results[0]["Sirius upload date"], "Sirius upload date - item 1")
+	assert.Equal(suite.T(), "123.45", results[0]["Amount"], "Amount - item 1")
+
+	assert.Equal(suite.T(), "22222222", results[1]["Court reference"], "Court reference - item 2")
+	assert.Equal(suite.T(), "SUPERVISION BACS PAYMENT", results[1]["Payment type"], "Payment type - item 2")
+	assert.Equal(suite.T(), "50.00", results[1]["Amount"], "Amount - item 2")
+}
@@ -11,6 +11,17 @@ import (
 
 const source = "opg.supervision.finance"
 
+const (
+	DetailTypeCreditOnAccount             = "credit-on-account"
+	DetailTypeDirectDebitCollection       = "direct-debit-collection"
+	DetailTypeDirectDebitCollectionFailed = "direct-debit-collection-failed"
+	DetailTypeDirectDebitScheduleFailed   = "direct-debit-schedule-failed"
+	DetailTypePaymentMethodChanged        = "payment-method-changed"
+	DetailTypePendingInvoiceAdjustment    = "pending-invoice-adjustment"
+	DetailTypeRefundAdded                 = "refund-added"
+	DetailTypeRefundReset                 = "refund-reset"
+)
+
 type EventBridgeClient interface {
 	PutEvents(ctx context.Context, params *eventbridge.PutEventsInput, optFns ...func(*eventbridge.Options)) (*eventbridge.PutEventsOutput, error)
 }
@@ -8,5 +8,5 @@ type CreditOnAccount struct {
 }
 
 func (c *Client) CreditOnAccount(ctx context.Context, event CreditOnAccount) error {
-	return c.send(ctx, "credit-on-account", event)
+	return c.send(ctx, DetailTypeCreditOnAccount, event)
 }
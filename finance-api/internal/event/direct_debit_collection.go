@@ -12,5 +12,5 @@ type DirectDebitCollection struct {
 }
 
 func (c *Client) DirectDebitCollection(ctx context.Context, event DirectDebitCollection) error {
-	return c.send(ctx, "direct-debit-collection", event)
+	return c.send(ctx, DetailTypeDirectDebitCollection, event)
 }
@@ -9,5 +9,5 @@ type DirectDebitCollectionFailed struct {
 }
 
 func (c *Client) DirectDebitCollectionFailed(ctx context.Context, event DirectDebitCollectionFailed) error {
-	return c.send(ctx, "direct-debit-collection-failed", event)
+	return c.send(ctx, DetailTypeDirectDebitCollectionFailed, event)
 }
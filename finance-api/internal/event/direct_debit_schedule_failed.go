@@ -9,5 +9,5 @@ type DirectDebitScheduleFailed struct {
 }
 
 func (c *Client) DirectDebitScheduleFailed(ctx context.Context, event DirectDebitScheduleFailed) error {
-	return c.send(ctx, "direct-debit-schedule-failed", event)
+	return c.send(ctx, DetailTypeDirectDebitScheduleFailed, event)
 }
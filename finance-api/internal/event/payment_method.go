@@ -11,5 +11,5 @@ type PaymentMethod struct {
 }
 
 func (c *Client) PaymentMethodChanged(ctx context.Context, event PaymentMethod) error {
-	return c.send(ctx, "payment-method-changed", event)
+	return c.send(ctx, DetailTypePaymentMethodChanged, event)
 }
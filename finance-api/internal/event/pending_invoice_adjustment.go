@@ -11,5 +11,5 @@ type PendingInvoiceAdjustment struct {
 }
 
 func (c *Client) PendingInvoiceAdjustment(ctx context.Context, event PendingInvoiceAdjustment) error {
-	return c.send(ctx, "pending-invoice-adjustment", event)
+	return c.send(ctx, DetailTypePendingInvoiceAdjustment, event)
 }
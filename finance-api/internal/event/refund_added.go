@@ -7,5 +7,5 @@ type RefundAdded struct {
 }
 
 func (c *Client) RefundAdded(ctx context.Context, event RefundAdded) error {
-	return c.send(ctx, "refund-added", event)
+	return c.send(ctx, DetailTypeRefundAdded, event)
 }
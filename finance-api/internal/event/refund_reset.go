@@ -7,5 +7,5 @@ type RefundReset struct {
 }
 
 func (c *Client) RefundReset(ctx context.Context, event RefundReset) error {
-	return c.send(ctx, "refund-reset", event)
+	return c.send(ctx, DetailTypeRefundReset, event)
 }
@@ -7,12 +7,14 @@ import (
 )
 
 type ScheduleToRemove struct {
-	CourtRef string      `json:"courtRef"`
-	Surname  string      `json:"surname"`
-	Amount   int32       `json:"amount"`
-	Date     shared.Date `json:"date"`
+	CourtRef   string      `json:"courtRef"`
+	Surname    string      `json:"surname"`
+	Amount     int32       `json:"amount"`
+	Date       shared.Date `json:"date"`
+	ClientID   int32       `json:"clientId"`
+	Reschedule bool        `json:"reschedule,omitempty"`
 }
 
 func (c *Client) ScheduleToRemove(ctx context.Context, event ScheduleToRemove) error {
-	return c.send(ctx, "schedule-to-remove", event)
+	return c.send(ctx, shared.DetailTypeScheduleToRemove, event)
 }
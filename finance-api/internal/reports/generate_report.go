@@ -17,31 +17,10 @@ const (
 	reportFailedTemplateId    = "31c40127-b5b6-4d23-aaab-050d90639d83"
 )
 
-func (c *Client) createDownloadFeeAccrualNotifyPayload(emailAddress string, requestedDate time.Time) (notify.Payload, error) {
-	downloadRequest := shared.DownloadRequest{Key: "Fee_Accrual.csv"}
-
-	uid, err := downloadRequest.Encode()
-	if err != nil {
-		return notify.Payload{}, err
-	}
-
-	downloadLink := fmt.Sprintf("%s/download?uid=%s", c.envs.FinanceAdminURL, uid)
-
-	payload := notify.Payload{
-		EmailAddress: emailAddress,
-		TemplateId:   reportRequestedTemplateId,
-		Personalisation: reportRequestedNotifyPersonalisation{
-			downloadLink,
-			shared.AccountsReceivableTypeFeeAccrual.Translation(),
-			requestedDate.Format("2006-01-02"),
-			requestedDate.Format("2006-01-02 15:04:05"),
-		},
-	}
-
-	return payload, nil
-}
-
-func (c *Client) GenerateAndUploadReport(ctx context.Context, reportRequest shared.ReportRequest, requestedDate time.Time) {
+// GenerateAndUploadReport streams the requested report to the reports bucket and emails the requester a link to download
+// it, or a failure notification if it could not be generated. The uploaded report is returned so the request can be
+// recorded.
+func (c *Client) GenerateAndUploadReport(ctx context.Context, reportRequest shared.ReportRequest, requestedDate time.Time) (shared.GeneratedReport, error) {
 	logger := telemetry.LoggerFromContext(ctx)
 	filename, reportName, stream, err := c.generateReport(ctx, reportRequest, requestedDate)
 
@@ -51,37 +30,31 @@ func (c *Client) GenerateAndUploadReport(ctx context.Context, reportRequest shar
 		if notifyErr != nil {
 			logger.Error("unable to send message to notify", "err", notifyErr)
 		}
-		return
+		return shared.GeneratedReport{Key: filename}, err
 	}
 
-	if reportRequest.ReportType == shared.ReportsTypeAccountsReceivable &&
-		*reportRequest.AccountsReceivableType == shared.AccountsReceivableTypeFeeAccrual {
-		payload, err := c.createDownloadFeeAccrualNotifyPayload(reportRequest.Email, requestedDate)
-		if err != nil {
-			logger.Error("failed to generate notify payload", "err", err)
-		}
-
-		err = c.notify.Send(ctx, payload)
-		if err != nil {
-			logger.Error("unable to send message to notify", "err", err)
-		}
-		return
-	}
-
-	versionId, err := c.fileStorage.StreamFile(ctx, c.envs.ReportsBucket, filename, stream)
+	rows := &rowCounter{ReadCloser: stream}
+	versionId, err := c.fileStorage.StreamFile(ctx, c.envs.ReportsBucket, filename, rows)
 	if err != nil {
 		logger.Error("failed to generate report", "err", err)
 		notifyErr := c.sendFailureNotification(ctx, reportRequest.Email, requestedDate, reportName)
 		if notifyErr != nil {
 			logger.Error("unable to send message to notify", "err", notifyErr)
 		}
-		return
+		return shared.GeneratedReport{Key: filename}, err
+	}
+
+	report := shared.GeneratedReport{Key: filename, Rows: rows.Rows()}
+	if versionId != nil {
+		report.VersionID = *versionId
 	}
 
 	notifyErr := c.sendSuccessNotification(ctx, reportRequest.Email, filename, versionId, requestedDate, reportName)
 	if notifyErr != nil {
 		logger.Error("unable to send message to notify", "err", notifyErr)
 	}
+
+	return report, nil
 }
 
 func (c *Client) generateReport(ctx context.Context, reportRequest shared.ReportRequest, requestedDate time.Time) (filename string, reportName string, stream io.ReadCloser, err error) {
@@ -131,8 +104,22 @@ func (c *Client) generateReport(ctx context.Context, reportRequest shared.Report
 			query = db.NewCustomerCredit(db.CustomerCreditInput{
 				ToDate: reportRequest.ToDate,
 			})
+		case shared.AccountsReceivableTypeSuspenseAccount:
+			if reportRequest.ToDate != nil && !reportRequest.ToDate.IsNull() {
+				reportDate = reportRequest.ToDate.Time.Format("02:01:2006")
+			}
+			query = db.NewSuspenseAccount(db.SuspenseAccountInput{
+				ToDate: reportRequest.ToDate,
+			})
 		case shared.AccountsReceivableTypeFeeAccrual:
-			return filename, reportName, nil, nil
+			if reportRequest.ToDate != nil && !reportRequest.ToDate.IsNull() {
+				reportDate = reportRequest.ToDate.Time.Format("02:01:2006")
+			}
+			query = db.NewFeeAccrual(db.FeeAccrualInput{
+				FromDate:   reportRequest.FromDate,
+				ToDate:     reportRequest.ToDate,
+				GoLiveDate: c.envs.GoLiveDate,
+			})
 		default:
 			return "", reportName, nil, fmt.Errorf("unimplemented accounts receivable query: %s", reportRequest.AccountsReceivableType.Key())
 		}
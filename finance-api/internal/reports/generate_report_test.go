@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/csv"
+	"errors"
 	"io"
 	"os"
 	"testing"
@@ -35,7 +36,8 @@ func (m *MockFileStorage) PutFile(ctx context.Context, bucketName string, fileNa
 func (m *MockFileStorage) StreamFile(ctx context.Context, bucketName string, fileName string, stream io.ReadCloser) (*string, error) {
 	m.bucketName = bucketName
 	m.filename = fileName
-	m.data = stream
+	data, _ := io.ReadAll(stream)
+	m.data = bytes.NewReader(data)
 	return &m.versionId, m.err
 }
 
@@ -210,13 +212,31 @@ func TestGenerateAndUploadReport(t *testing.T) {
 			expectedFilename: "UnappliedReceipts_02:02:2024.csv",
 			expectedTemplate: reportRequestedTemplateId,
 		},
+		{
+			name: "Suspense Account",
+			reportRequest: shared.ReportRequest{
+				ReportType:             shared.ReportsTypeAccountsReceivable,
+				AccountsReceivableType: toPtr(shared.AccountsReceivableTypeSuspenseAccount),
+			},
+			expectedQuery:    &db.SuspenseAccount{ReportQuery: db.NewReportQuery(db.SuspenseAccountQuery)},
+			expectedFilename: "SuspenseAccount_02:02:2024.csv",
+			expectedTemplate: reportRequestedTemplateId,
+		},
 		{
 			name: "Fee Accrual",
 			reportRequest: shared.ReportRequest{
 				ReportType:             shared.ReportsTypeAccountsReceivable,
 				AccountsReceivableType: toPtr(shared.AccountsReceivableTypeFeeAccrual),
+				ToDate:                 &toDate,
+				FromDate:               &fromDate,
 			},
-			expectedQuery:    nil,
+			expectedQuery: &db.FeeAccrual{
+				FeeAccrualInput: db.FeeAccrualInput{
+					FromDate: &fromDate,
+					ToDate:   &toDate,
+				},
+				ReportQuery: db.NewReportQuery(db.FeeAccrualQuery)},
+			expectedFilename: "FeeAccrual_01:01:2024.csv",
 			expectedTemplate: reportRequestedTemplateId,
 		},
 		{
@@ -496,6 +516,11 @@ func TestGenerateAndUploadReport(t *testing.T) {
 				assert.True(t, ok)
 				assert.Equal(t, expected, actual)
 				assert.Equal(t, tt.expectedTemplate, mockNotify.payload.TemplateId)
+			case *db.FeeAccrual:
+				actual, ok := mockDb.query.(*db.FeeAccrual)
+				assert.True(t, ok)
+				assert.Equal(t, expected, actual)
+				assert.Equal(t, tt.expectedTemplate, mockNotify.payload.TemplateId)
 			case *db.NonReceiptTransactions:
 				actual, ok := mockDb.query.(*db.NonReceiptTransactions)
 				assert.True(t, ok)
@@ -511,6 +536,11 @@ func TestGenerateAndUploadReport(t *testing.T) {
 				assert.True(t, ok)
 				assert.Equal(t, expected, actual)
 				assert.Equal(t, tt.expectedTemplate, mockNotify.payload.TemplateId)
+			case *db.SuspenseAccount:
+				actual, ok := mockDb.query.(*db.SuspenseAccount)
+				assert.True(t, ok)
+				assert.Equal(t, expected, actual)
+				assert.Equal(t, tt.expectedTemplate, mockNotify.payload.TemplateId)
 			case *db.PaymentsSchedule:
 				actual, ok := mockDb.query.(*db.PaymentsSchedule)
 				assert.True(t, ok)
@@ -540,6 +570,49 @@ func TestGenerateAndUploadReport(t *testing.T) {
 		})
 	}
 }
+func TestGenerateAndUploadReport_returnsReport(t *testing.T) {
+	timeNow, _ := time.Parse("2006-01-02", "2024-02-02")
+
+	mockFileStorage := MockFileStorage{versionId: "v1"}
+	mockNotify := MockNotify{}
+	mockDb := MockDb{rows: [][]string{{"Customer name", "Notes"}, {"Ian Moneybags", "line one\nline two"}, {"Penny Pincher", ""}}}
+
+	client := NewClient(nil, &mockFileStorage, &mockNotify, &Envs{ReportsBucket: "test"})
+	client.db = &mockDb
+
+	ctx := telemetry.ContextWithLogger(context.Background(), telemetry.NewLogger("finance-api-test"))
+
+	report, err := client.GenerateAndUploadReport(ctx, shared.ReportRequest{
+		ReportType: shared.ReportsTypeDebt,
+		DebtType:   toPtr(shared.DebtTypeFeeChase),
+	}, timeNow)
+
+	assert.NoError(t, err)
+	assert.Equal(t, shared.GeneratedReport{Key: "debt_FeeChase_02:02:2024.csv", VersionID: "v1", Rows: 2}, report)
+	assert.Equal(t, reportRequestedTemplateId, mockNotify.payload.TemplateId)
+}
+
+func TestGenerateAndUploadReport_uploadFails(t *testing.T) {
+	timeNow, _ := time.Parse("2006-01-02", "2024-02-02")
+
+	mockFileStorage := MockFileStorage{err: errors.New("bucket unavailable")}
+	mockNotify := MockNotify{}
+
+	client := NewClient(nil, &mockFileStorage, &mockNotify, &Envs{ReportsBucket: "test"})
+	client.db = &MockDb{}
+
+	ctx := telemetry.ContextWithLogger(context.Background(), telemetry.NewLogger("finance-api-test"))
+
+	report, err := client.GenerateAndUploadReport(ctx, shared.ReportRequest{
+		ReportType: shared.ReportsTypeDebt,
+		DebtType:   toPtr(shared.DebtTypeFeeChase),
+	}, timeNow)
+
+	assert.EqualError(t, err, "bucket unavailable")
+	assert.Equal(t, shared.GeneratedReport{Key: "debt_FeeChase_02:02:2024.csv"}, report)
+	assert.Equal(t, reportFailedTemplateId, mockNotify.payload.TemplateId)
+}
+
 func TestSendSuccessNotification(t *testing.T) {
 	mockNotify := MockNotify{}
 	client := &Client{notify: &mockNotify, envs: &Envs{FinanceAdminURL: "http://example.com"}}
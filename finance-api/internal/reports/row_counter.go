@@ -0,0 +1,43 @@
+package reports
+
+import "io"
+
+// rowCounter counts the CSV records read from a report stream, so the number of rows can be recorded once the report has
+// been uploaded. Line breaks within quoted fields are not counted.
+type rowCounter struct {
+	io.ReadCloser
+	records  int
+	inQuotes bool
+	partial  bool
+}
+
+func (r *rowCounter) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	for _, b := range p[:n] {
+		switch b {
+		case '"':
+			r.inQuotes = !r.inQuotes
+			r.partial = true
+		case '\n':
+			if !r.inQuotes {
+				r.records++
+				r.partial = false
+			}
+		default:
+			r.partial = true
+		}
+	}
+	return n, err
+}
+
+// Rows returns the number of records read, excluding the header
+func (r *rowCounter) Rows() int {
+	records := r.records
+	if r.partial {
+		records++
+	}
+	if records == 0 {
+		return 0
+	}
+	return records - 1
+}
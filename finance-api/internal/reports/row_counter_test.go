@@ -0,0 +1,51 @@
+package reports
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRowCounter(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected int
+	}{
+		{
+			name:     "empty",
+			data:     "",
+			expected: 0,
+		},
+		{
+			name:     "header only",
+			data:     "\uFEFFName,Amount\n",
+			expected: 0,
+		},
+		{
+			name:     "rows",
+			data:     "\uFEFFName,Amount\nIan,10\nPenny,20\n",
+			expected: 2,
+		},
+		{
+			name:     "quoted line breaks",
+			data:     "Name,Notes\nIan,\"first\nsecond\"\n\"Penny \"\"P\"\"\",\"\"\n",
+			expected: 2,
+		},
+		{
+			name:     "no trailing line break",
+			data:     "Name,Amount\nIan,10",
+			expected: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counter := &rowCounter{ReadCloser: io.NopCloser(strings.NewReader(tt.data))}
+			_, err := io.Copy(io.Discard, counter)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, counter.Rows())
+		})
+	}
+}
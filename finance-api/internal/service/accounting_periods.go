@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/auth"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/validation"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+// accountingPeriodsShown is the number of months, including the current one, returned by GetAccountingPeriods
+const accountingPeriodsShown = 12
+
+// CloseAccountingPeriod closes the month containing the given date. Journals for a closed month have been submitted to
+// the general ledger, so no further postings can be made into it. Only months that have ended can be closed, so that
+// there is always an open period to post into.
+func (s *Service) CloseAccountingPeriod(ctx context.Context, data shared.CloseAccountingPeriod) error {
+	periodStart := startOfMonth(data.Period.Time)
+	if !periodStart.Before(startOfMonth(time.Now())) {
+		return apierror.BadRequestError("Period", "An accounting period cannot be closed until the month has ended", nil)
+	}
+
+	var period pgtype.Date
+	_ = period.Scan(periodStart)
+
+	closed, err := s.store.CloseAccountingPeriod(ctx, store.CloseAccountingPeriodParams{
+		PeriodStart: period,
+		ClosedBy:    ctx.(auth.Context).User.ID,
+	})
+	if err != nil {
+		s.Logger(ctx).Error("Error closing accounting period", slog.String("err", err.Error()))
+		return err
+	}
+	if closed == 0 {
+		return apierror.BadRequestError("Period", fmt.Sprintf("The accounting period for %s is already closed", periodStart.Format("January 2006")), nil)
+	}
+
+	return nil
+}
+
+// ReopenAccountingPeriod reopens a closed month, e.g. if it was closed in error or a correction must be posted into it.
+// The closure is removed, so the audit log is the only record that the month was closed.
+func (s *Service) ReopenAccountingPeriod(ctx context.Context, data shared.ReopenAccountingPeriod) error {
+	periodStart := startOfMonth(data.Period.Time)
+
+	var period pgtype.Date
+	_ = period.Scan(periodStart)
+
+	tx, err := s.BeginStoreTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	reopened, err := tx.ReopenAccountingPeriod(ctx, period)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apierror.BadRequestError("Period", fmt.Sprintf("The accounting period for %s is not closed", periodStart.Format("January 2006")), nil)
+	} else if err != nil {
+		s.Logger(ctx).Error("Error reopening accounting period", slog.String("err", err.Error()))
+		return err
+	}
+
+	err = s.audit(ctx, tx, auditEntry{
+		action:     shared.AuditActionAccountingPeriodReopened,
+		entityType: shared.AuditEntityAccountingPeriod,
+		entityId:   reopened.ID,
+		before: map[string]string{
+			"period":   periodStart.Format("2006-01-02"),
+			"status":   shared.AccountingPeriodStatusClosed,
+			"closedAt": reopened.ClosedAt.Time.Format(time.RFC3339),
+			"closedBy": strconv.Itoa(int(reopened.ClosedBy)),
+		},
+		after: map[string]string{
+			"period": periodStart.Format("2006-01-02"),
+			"status": shared.AccountingPeriodStatusOpen,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetAccountingPeriods returns the current month and those before it, most recent first, with whether each is closed
+func (s *Service) GetAccountingPeriods(ctx context.Context) (shared.AccountingPeriods, error) {
+	current := startOfMonth(time.Now())
+	earliest := current.AddDate(0, 1-accountingPeriodsShown, 0)
+
+	var fromDate pgtype.Date
+	_ = fromDate.Scan(earliest)
+
+	rows, err := s.store.GetClosedAccountingPeriods(ctx, fromDate)
+	if err != nil {
+		return nil, err
+	}
+
+	closed := make(map[time.Time]store.GetClosedAccountingPeriodsRow)
+	for _, row := range rows {
+		closed[startOfMonth(row.PeriodStart.Time)] = row
+	}
+
+	periods := shared.AccountingPeriods{}
+	for month := current; !month.Before(earliest); month = month.AddDate(0, -1, 0) {
+		period := shared.AccountingPeriod{
+			Period: shared.Date{Time: month},
+			Status: shared.AccountingPeriodStatusOpen,
+		}
+		if row, ok := closed[month]; ok {
+			period.Status = shared.AccountingPeriodStatusClosed
+			period.ClosedAt = &shared.Date{Time: row.ClosedAt.Time}
+			period.ClosedBy = int(row.ClosedBy)
+		}
+		periods = append(periods, period)
+	}
+
+	return periods, nil
+}
+
+// checkPostingPeriod rejects a posting dated in an accounting period that has already been closed. The dates are those
+// the posting takes effect on, such as the bank and received dates of a payment, or today for a ledger posted with the
+// current date.
+func (s *Service) checkPostingPeriod(ctx context.Context, tx *store.Tx, dates ...time.Time) error {
+	closed, err := s.closedPostingPeriod(ctx, tx, dates...)
+	if err != nil {
+		return err
+	}
+	if !closed.IsZero() {
+		return apierror.BadRequestError("AccountingPeriod", fmt.Sprintf("The accounting period for %s is closed", closed.Format("January 2006")), nil)
+	}
+
+	return nil
+}
+
+// validatePostingPeriod fails an upload line dated in a closed accounting period, so that the rest of the upload can
+// still be processed rather than the whole file being rejected
+func (s *Service) validatePostingPeriod(ctx context.Context, tx *store.Tx, index int, failedLines *map[int]string, dates ...time.Time) (bool, error) {
+	closed, err := s.closedPostingPeriod(ctx, tx, dates...)
+	if err != nil {
+		return false, err
+	}
+	if !closed.IsZero() {
+		(*failedLines)[index] = validation.UploadErrorAccountingPeriodClosed
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// closedPostingPeriod returns the first of the dates that falls in a closed accounting period, or a zero time if they
+// are all open. Unset dates are ignored.
+func (s *Service) closedPostingPeriod(ctx context.Context, tx *store.Tx, dates ...time.Time) (time.Time, error) {
+	for _, date := range dates {
+		if date.IsZero() {
+			continue
+		}
+
+		var d pgtype.Date
+		_ = d.Scan(date)
+
+		closed, err := tx.IsAccountingPeriodClosed(ctx, d)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if closed {
+			return date, nil
+		}
+	}
+
+	return time.Time{}, nil
+}
+
+// openPostingDate forward-dates a posting in a closed accounting period to the start of the first open period after it
+func (s *Service) openPostingDate(ctx context.Context, tx *store.Tx, date time.Time) (time.Time, error) {
+	for {
+		closed, err := s.closedPostingPeriod(ctx, tx, date)
+		if err != nil || closed.IsZero() {
+			return date, err
+		}
+		date = startOfMonth(date).AddDate(0, 1, 0)
+	}
+}
+
+func startOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
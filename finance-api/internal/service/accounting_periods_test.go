@@ -0,0 +1,121 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/validation"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationSuite) TestService_AccountingPeriods() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	seeder.SeedData(
+		"INSERT INTO finance_client VALUES (1, 1, 'period-1', 'DEMANDED', NULL, '11111111');",
+		"INSERT INTO invoice VALUES (1, 1, 1, 'AD', 'AD11111/24', '2024-04-01', '2025-03-31', 10000, NULL, '2024-03-31', 11, '2024-03-31', NULL, NULL, NULL, '2024-03-31 00:00:00', '99');",
+	)
+
+	s := Service{store: store.New(seeder.Conn), dispatch: &mockDispatch{}, tx: seeder.Conn}
+
+	now := time.Now()
+	currentMonth := shared.Date{Time: startOfMonth(now)}
+	lastMonth := shared.Date{Time: startOfMonth(now).AddDate(0, -1, 0)}
+	nextMonth := shared.Date{Time: startOfMonth(now).AddDate(0, 1, 0)}
+
+	suite.T().Run("future period", func(t *testing.T) {
+		err := s.CloseAccountingPeriod(ctx, shared.CloseAccountingPeriod{Period: &nextMonth})
+		var e *apierror.BadRequest
+		assert.ErrorAs(t, err, &e)
+		assert.Equal(t, "An accounting period cannot be closed until the month has ended", e.Reason)
+	})
+
+	suite.T().Run("current period", func(t *testing.T) {
+		err := s.CloseAccountingPeriod(ctx, shared.CloseAccountingPeriod{Period: &currentMonth})
+		var e *apierror.BadRequest
+		assert.ErrorAs(t, err, &e)
+		assert.Equal(t, "An accounting period cannot be closed until the month has ended", e.Reason)
+	})
+
+	err := s.CloseAccountingPeriod(ctx, shared.CloseAccountingPeriod{Period: &lastMonth})
+	assert.NoError(suite.T(), err)
+
+	suite.T().Run("already closed", func(t *testing.T) {
+		err := s.CloseAccountingPeriod(ctx, shared.CloseAccountingPeriod{Period: &lastMonth})
+		var e *apierror.BadRequest
+		assert.ErrorAs(t, err, &e)
+		assert.Equal(t, fmt.Sprintf("The accounting period for %s is already closed", lastMonth.Time.Format("January 2006")), e.Reason)
+	})
+
+	// postings can still be made into the current period, but not backdated into the closed one
+	tx, _ := s.BeginStoreTx(ctx)
+	assert.NoError(suite.T(), s.checkPostingPeriod(ctx, tx, now))
+	assert.Error(suite.T(), s.checkPostingPeriod(ctx, tx, now, lastMonth.Time.AddDate(0, 0, 14)))
+	tx.Rollback(ctx)
+
+	suite.T().Run("backdated payment line", func(t *testing.T) {
+		failedLines, err := s.ProcessPayments(ctx, [][]string{
+			{"Case number (confirmed on Sirius)", "Cheque number", "Cheque Value (£)", "Comments", "Date in Bank"},
+			{"11111111", "11111", "100", "", lastMonth.Time.AddDate(0, 0, 14).Format("02/01/2006")},
+		}, shared.ReportTypeUploadPaymentsSupervisionCheque, shared.Date{Time: now}, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, map[int]string{1: validation.UploadErrorAccountingPeriodClosed}, failedLines)
+
+		var ledgers int
+		_ = seeder.QueryRow(ctx, "SELECT COUNT(*) FROM ledger").Scan(&ledgers)
+		assert.Equal(t, 0, ledgers)
+	})
+
+	periods, err := s.GetAccountingPeriods(ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), periods, 12)
+	assert.Equal(suite.T(), currentMonth, periods[0].Period)
+	assert.Equal(suite.T(), shared.AccountingPeriodStatusOpen, periods[0].Status)
+	assert.Equal(suite.T(), lastMonth, periods[1].Period)
+	assert.Equal(suite.T(), shared.AccountingPeriodStatusClosed, periods[1].Status)
+	assert.Equal(suite.T(), 10, periods[1].ClosedBy)
+	assert.Equal(suite.T(), shared.AccountingPeriodStatusOpen, periods[2].Status)
+	assert.Nil(suite.T(), periods[2].ClosedAt)
+
+	err = s.ReopenAccountingPeriod(ctx, shared.ReopenAccountingPeriod{Period: &lastMonth})
+	assert.NoError(suite.T(), err)
+
+	suite.T().Run("not closed", func(t *testing.T) {
+		err := s.ReopenAccountingPeriod(ctx, shared.ReopenAccountingPeriod{Period: &lastMonth})
+		var e *apierror.BadRequest
+		assert.ErrorAs(t, err, &e)
+		assert.Equal(t, fmt.Sprintf("The accounting period for %s is not closed", lastMonth.Time.Format("January 2006")), e.Reason)
+	})
+
+	periods, err = s.GetAccountingPeriods(ctx)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), shared.AccountingPeriodStatusOpen, periods[1].Status)
+
+	var (
+		action   string
+		before   string
+		clientId pgtype.Int4
+	)
+	_ = seeder.QueryRow(ctx, "SELECT action, before ->> 'closedBy', finance_client_id FROM audit_log WHERE entity_type = 'ACCOUNTING PERIOD'").Scan(&action, &before, &clientId)
+	assert.Equal(suite.T(), shared.AuditActionAccountingPeriodReopened, action)
+	assert.Equal(suite.T(), "10", before)
+	assert.False(suite.T(), clientId.Valid)
+
+	// the current period is only closed here to check postings are rejected, as it cannot be closed through the service
+	seeder.SeedData(fmt.Sprintf("INSERT INTO accounting_period VALUES (NEXTVAL('accounting_period_id_seq'), '%s', NOW(), 10);", currentMonth.Time.Format("2006-01-02")))
+
+	err = s.VoidInvoice(ctx, 1, 1, shared.VoidInvoice{VoidReason: "Raised in error"})
+	var e *apierror.BadRequest
+	assert.ErrorAs(suite.T(), err, &e)
+	assert.Equal(suite.T(), fmt.Sprintf("The accounting period for %s is closed", now.Format("January 2006")), e.Reason)
+
+	var ledgers int
+	_ = seeder.QueryRow(ctx, "SELECT COUNT(*) FROM ledger").Scan(&ledgers)
+	assert.Equal(suite.T(), 0, ledgers)
+}
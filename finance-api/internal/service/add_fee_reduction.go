@@ -50,6 +50,11 @@ func (s *Service) AddFeeReduction(ctx context.Context, clientId int32, data shar
 	}
 	defer tx.Rollback(ctx)
 
+	err = s.checkPostingPeriod(ctx, tx, time.Now())
+	if err != nil {
+		return err
+	}
+
 	feeReduction, err := tx.AddFeeReduction(ctx, feeReductionParams)
 	if err != nil {
 		s.Logger(ctx).Error("Add fee reduction has an issue " + err.Error())
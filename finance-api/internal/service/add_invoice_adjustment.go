@@ -44,18 +44,28 @@ func (s *Service) AddInvoiceAdjustment(ctx context.Context, clientId int32, invo
 		Notes:          adjustment.AdjustmentNotes,
 		CreatedBy:      ctx.(auth.Context).User.ID,
 	}
-	invoiceReference, err := s.store.CreatePendingInvoiceAdjustment(ctx, params)
+	tx, err := s.BeginStoreTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	invoiceReference, err := tx.CreatePendingInvoiceAdjustment(ctx, params)
 	if err != nil {
 		s.Logger(ctx).Error("Error creating pending invoice adjustment", slog.String("err", err.Error()))
 		return nil, err
 	}
 
-	err = s.dispatch.PendingInvoiceAdjustment(ctx, event.PendingInvoiceAdjustment{
+	err = s.outbox(tx).PendingInvoiceAdjustment(ctx, event.PendingInvoiceAdjustment{
 		ClientID:         int(clientId),
 		AdjustmentType:   adjustment.AdjustmentType.Key(),
 		InvoiceReference: invoiceReference,
 	})
+	if err != nil {
+		return nil, err
+	}
 
+	err = tx.Commit(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -64,6 +74,10 @@ func (s *Service) AddInvoiceAdjustment(ctx context.Context, clientId int32, invo
 }
 
 func (s *Service) validateAdjustmentAmount(ctx context.Context, adjustment *shared.AddInvoiceAdjustmentRequest, balance store.GetInvoiceBalanceDetailsRow, feeReductionDetails store.GetInvoiceFeeReductionReversalDetailsRow) error {
+	if balance.Voided {
+		return apierror.BadRequestError("Invoice", "The invoice has been voided and cannot be adjusted", nil)
+	}
+
 	switch adjustment.AdjustmentType {
 	case shared.AdjustmentTypeCreditMemo:
 		if adjustment.Amount-balance.Outstanding > balance.Initial {
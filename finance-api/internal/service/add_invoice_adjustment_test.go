@@ -29,7 +29,7 @@ func (suite *IntegrationSuite) TestService_AddInvoiceAdjustment() {
 		"ALTER SEQUENCE ledger_allocation_id_seq RESTART WITH 2;",
 	)
 
-	s := Service{store: store.New(seeder.Conn), dispatch: dispatch}
+	s := Service{store: store.New(seeder.Conn), dispatch: dispatch, tx: seeder.Conn}
 
 	testCases := []struct {
 		name      string
@@ -107,6 +107,7 @@ func (suite *IntegrationSuite) TestService_AddInvoiceAdjustment() {
 			}
 
 			assert.EqualValues(t, expected, pendingAdjustment)
+			assert.NoError(t, s.RelayOutbox(ctx))
 			assert.Equal(suite.T(), int(tt.clientId), dispatch.event.(event.PendingInvoiceAdjustment).ClientID)
 			assert.Equal(suite.T(), tt.data.AdjustmentType.Key(), dispatch.event.(event.PendingInvoiceAdjustment).AdjustmentType)
 		})
@@ -51,6 +51,11 @@ func (s *Service) AddManualInvoice(ctx context.Context, clientId int32, data sha
 	}
 	defer tx.Rollback(ctx)
 
+	err = s.checkPostingPeriod(ctx, tx, time.Now())
+	if err != nil {
+		return err
+	}
+
 	reference, err := generateInvoiceReference(ctx, tx, data.InvoiceType, data.StartDate.Value)
 	if err != nil {
 		s.Logger(ctx).Error("Generate invoice reference has an issue " + err.Error())
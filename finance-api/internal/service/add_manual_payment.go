@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/auth"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+// AddManualPayment posts a single payment to a client's account, allocating it to their unpaid invoices in the same way
+// as an uploaded payment line. If an invoice reference is given, the payment is allocated to that invoice first. A
+// payment matching one already posted to the client, or held in suspense against their court reference, is rejected as a
+// duplicate. The client is locked while the payment is checked and posted, so that concurrent duplicates are also caught.
+func (s *Service) AddManualPayment(ctx context.Context, clientId int32, payment shared.AddManualPayment) error {
+	validationErrors := validateManualPayment(payment)
+	if len(validationErrors) != 0 {
+		return apierror.ValidationError{Errors: validationErrors}
+	}
+
+	tx, err := s.BeginStoreTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	courtRef, err := tx.GetCourtRefByClientIdForUpdate(ctx, clientId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apierror.NotFoundError(err)
+	} else if err != nil {
+		return err
+	}
+
+	if payment.InvoiceReference != "" {
+		invoices, err := tx.GetUnpaidInvoicesByCourtRef(ctx, courtRef)
+		if err != nil {
+			return err
+		}
+		if !slices.ContainsFunc(invoices, func(invoice store.GetUnpaidInvoicesByCourtRefRow) bool {
+			return strings.EqualFold(invoice.Reference, payment.InvoiceReference)
+		}) {
+			return apierror.ValidationError{Errors: apierror.ValidationErrors{
+				"InvoiceReference": {"unpaid-invoice": "The invoice reference does not match an unpaid invoice for this client"},
+			}}
+		}
+	}
+
+	var (
+		bankDate         pgtype.Date
+		receivedDate     pgtype.Timestamp
+		createdBy        pgtype.Int4
+		pisNumber        pgtype.Int4
+		invoiceReference pgtype.Text
+	)
+
+	_ = bankDate.Scan(payment.BankDate.Time)
+	_ = receivedDate.Scan(payment.ReceivedDate.Time)
+	_ = store.ToInt4(&createdBy, ctx.(auth.Context).User.ID)
+	_ = store.ToInt4(&pisNumber, payment.PisNumber)
+	_ = invoiceReference.Scan(payment.InvoiceReference)
+
+	details := shared.PaymentDetails{
+		Amount:           payment.Amount,
+		BankDate:         bankDate,
+		CourtRef:         courtRef,
+		LedgerType:       payment.PaymentType,
+		ReceivedDate:     receivedDate,
+		CreatedBy:        createdBy,
+		PisNumber:        pisNumber,
+		InvoiceReference: invoiceReference,
+	}
+
+	count, err := tx.CountDuplicateLedger(ctx, store.CountDuplicateLedgerParams{
+		CourtRef:     details.CourtRef,
+		Amount:       details.Amount,
+		Type:         details.LedgerType.Key(),
+		BankDate:     details.BankDate,
+		ReceivedDate: details.ReceivedDate,
+		PisNumber:    details.PisNumber,
+	})
+	if err != nil {
+		return err
+	}
+
+	suspended, err := tx.CountDuplicateSuspense(ctx, store.CountDuplicateSuspenseParams{
+		CourtRef:     details.CourtRef,
+		Amount:       details.Amount,
+		Type:         details.LedgerType.Key(),
+		BankDate:     details.BankDate,
+		ReceivedDate: details.ReceivedDate,
+		PisNumber:    details.PisNumber,
+	})
+	if err != nil {
+		return err
+	}
+
+	if count+suspended > 0 {
+		return apierror.ValidationError{Errors: apierror.ValidationErrors{
+			"Duplicate": {"duplicate": "A payment with these details has already been added"},
+		}}
+	}
+
+	_, err = s.ProcessPaymentsUploadLine(ctx, tx, details)
+	if err != nil {
+		s.Logger(ctx).Error("failed to add manual payment", "clientId", clientId, "err", err)
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func validateManualPayment(payment shared.AddManualPayment) apierror.ValidationErrors {
+	validationErrors := apierror.ValidationErrors{}
+
+	if !slices.Contains(shared.ManualPaymentTypes, payment.PaymentType) {
+		validationErrors["PaymentType"] = map[string]string{"valid-enum": "This payment type cannot be added manually"}
+	}
+
+	if payment.PaymentType == shared.TransactionTypeSupervisionChequePayment && payment.PisNumber == 0 {
+		validationErrors["PisNumber"] = map[string]string{"required": "A PIS number is required for cheque payments"}
+	}
+
+	return validationErrors
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationSuite) TestService_AddManualPayment() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	seeder.SeedData(
+		"INSERT INTO finance_client VALUES (1, 11, 'invoice-1', 'DEMANDED', NULL, '1234');",
+		"INSERT INTO invoice VALUES (1, 11, 1, 'AD', 'AD11223/19', '2023-04-01', '2025-03-31', 10000, NULL, '2024-03-31', 11, '2024-03-31', NULL, NULL, NULL, '2024-03-31 00:00:00', '99');",
+		"INSERT INTO finance_client VALUES (2, 12, 'invoice-2', 'DEMANDED', NULL, '5678');",
+		"INSERT INTO invoice VALUES (2, 12, 2, 'AD', 'AD11224/19', '2023-04-01', '2025-03-31', 10000, NULL, '2024-03-31', 11, '2024-03-31', NULL, NULL, NULL, '2024-03-31 00:00:00', '99');",
+		"INSERT INTO invoice VALUES (3, 12, 2, 'S2', 'S211225/20', '2024-04-01', '2025-03-31', 20000, NULL, '2024-04-30', 11, '2024-04-30', NULL, NULL, NULL, '2024-04-30 00:00:00', '99');",
+		"ALTER SEQUENCE ledger_id_seq RESTART WITH 2;",
+		"ALTER SEQUENCE ledger_allocation_id_seq RESTART WITH 2;",
+	)
+
+	dispatch := &mockDispatch{}
+	s := Service{store: store.New(seeder.Conn), dispatch: dispatch, tx: seeder.Conn}
+
+	bankDate := shared.NewDate("2024-01-02")
+	receivedDate := shared.NewDate("2024-01-01")
+	payment := shared.AddManualPayment{
+		PaymentType:  shared.TransactionTypeSupervisionChequePayment,
+		Amount:       15000,
+		BankDate:     &bankDate,
+		ReceivedDate: &receivedDate,
+		PisNumber:    100023,
+	}
+
+	err := s.AddManualPayment(ctx, 11, payment)
+	assert.NoError(suite.T(), err)
+
+	type allocation struct {
+		Amount    int32
+		Type      string
+		PisNumber int32
+		Allocated int32
+		Status    string
+	}
+
+	var allocations []allocation
+	rows, _ := seeder.Query(ctx, "SELECT l.amount, l.type, l.pis_number, la.amount, la.status FROM ledger l JOIN ledger_allocation la ON la.ledger_id = l.id WHERE l.finance_client_id = 1 ORDER BY la.id")
+	for rows.Next() {
+		var a allocation
+		_ = rows.Scan(&a.Amount, &a.Type, &a.PisNumber, &a.Allocated, &a.Status)
+		allocations = append(allocations, a)
+	}
+
+	assert.Equal(suite.T(), []allocation{
+		{Amount: 15000, Type: "SUPERVISION CHEQUE PAYMENT", PisNumber: 100023, Allocated: 10000, Status: "ALLOCATED"},
+		{Amount: 15000, Type: "SUPERVISION CHEQUE PAYMENT", PisNumber: 100023, Allocated: -5000, Status: "UNAPPLIED"},
+	}, allocations)
+	assert.NoError(suite.T(), s.RelayOutbox(ctx))
+	assert.Equal(suite.T(), []string{"CreditOnAccount"}, dispatch.called)
+
+	suite.T().Run("duplicate payment", func(t *testing.T) {
+		err := s.AddManualPayment(ctx, 11, payment)
+		assert.Equal(t, apierror.ValidationError{Errors: apierror.ValidationErrors{
+			"Duplicate": {"duplicate": "A payment with these details has already been added"},
+		}}, err)
+	})
+
+	suite.T().Run("duplicate of a payment held in suspense", func(t *testing.T) {
+		seeder.SeedData(
+			"INSERT INTO suspense VALUES (1, '5678', 7000, 'SUPERVISION CHEQUE PAYMENT', '2024-01-02', '2024-01-01', 100024, 'PAYMENTS_SUPERVISION_CHEQUE', '{}', NOW(), 1);",
+		)
+		suspended := payment
+		suspended.Amount = 7000
+		suspended.PisNumber = 100024
+		err := s.AddManualPayment(ctx, 12, suspended)
+		assert.Equal(t, apierror.ValidationError{Errors: apierror.ValidationErrors{
+			"Duplicate": {"duplicate": "A payment with these details has already been added"},
+		}}, err)
+	})
+
+	suite.T().Run("cheque without PIS number", func(t *testing.T) {
+		cheque := payment
+		cheque.PisNumber = 0
+		err := s.AddManualPayment(ctx, 11, cheque)
+		assert.Equal(t, apierror.ValidationError{Errors: apierror.ValidationErrors{
+			"PisNumber": {"required": "A PIS number is required for cheque payments"},
+		}}, err)
+	})
+
+	suite.T().Run("payment type not permitted", func(t *testing.T) {
+		directDebit := payment
+		directDebit.PaymentType = shared.TransactionTypeDirectDebitPayment
+		err := s.AddManualPayment(ctx, 11, directDebit)
+		assert.Equal(t, apierror.ValidationError{Errors: apierror.ValidationErrors{
+			"PaymentType": {"valid-enum": "This payment type cannot be added manually"},
+		}}, err)
+	})
+
+	suite.T().Run("targeted invoice", func(t *testing.T) {
+		targeted := payment
+		targeted.InvoiceReference = "S211225/20"
+		err := s.AddManualPayment(ctx, 12, targeted)
+		assert.NoError(t, err)
+
+		var (
+			invoiceIds []int
+			amounts    []int
+			strategy   string
+		)
+		rows, _ := seeder.Query(ctx, "SELECT la.invoice_id, la.amount, l.allocation_strategy FROM ledger l JOIN ledger_allocation la ON la.ledger_id = l.id WHERE l.finance_client_id = 2 ORDER BY la.id")
+		for rows.Next() {
+			var invoiceId, amount int
+			_ = rows.Scan(&invoiceId, &amount, &strategy)
+			invoiceIds = append(invoiceIds, invoiceId)
+			amounts = append(amounts, amount)
+		}
+
+		assert.Equal(t, []int{3}, invoiceIds)
+		assert.Equal(t, []int{15000}, amounts)
+		assert.Equal(t, "TARGETED INVOICE", strategy)
+	})
+
+	suite.T().Run("invoice reference not an unpaid invoice", func(t *testing.T) {
+		targeted := payment
+		targeted.InvoiceReference = "AD11223/19"
+		err := s.AddManualPayment(ctx, 12, targeted)
+		assert.Equal(t, apierror.ValidationError{Errors: apierror.ValidationErrors{
+			"InvoiceReference": {"unpaid-invoice": "The invoice reference does not match an unpaid invoice for this client"},
+		}}, err)
+	})
+
+	suite.T().Run("client not found", func(t *testing.T) {
+		err := s.AddManualPayment(ctx, 99, payment)
+		assert.ErrorAs(t, err, &apierror.NotFound{})
+	})
+}
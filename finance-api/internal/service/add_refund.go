@@ -21,7 +21,13 @@ func (s *Service) AddRefund(ctx context.Context, clientId int32, refund shared.A
 		return apierror.BadRequest{Reason: "NoCreditToRefund"}
 	}
 
-	_, err = s.store.CreateRefund(ctx, store.CreateRefundParams{
+	tx, err := s.BeginStoreTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.CreateRefund(ctx, store.CreateRefundParams{
 		ClientID:      clientId,
 		Amount:        refundableAmount,
 		Notes:         refund.RefundNotes,
@@ -35,5 +41,10 @@ func (s *Service) AddRefund(ctx context.Context, clientId int32, refund shared.A
 		return err
 	}
 
-	return s.dispatch.RefundAdded(ctx, event.RefundAdded{ClientID: clientId})
+	err = s.outbox(tx).RefundAdded(ctx, event.RefundAdded{ClientID: clientId})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
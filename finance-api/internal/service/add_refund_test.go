@@ -14,7 +14,7 @@ func (suite *IntegrationSuite) TestService_AddRefund() {
 	ctx := suite.ctx
 	seeder := suite.cm.Seeder(ctx, suite.T())
 	dispatch := &mockDispatch{}
-	s := Service{store: store.New(seeder.Conn), dispatch: dispatch}
+	s := Service{store: store.New(seeder.Conn), dispatch: dispatch, tx: seeder.Conn}
 
 	seeder.SeedData(
 		"INSERT INTO finance_client VALUES (24, 2401, '1234', 'DEMANDED', NULL);",
@@ -68,6 +68,7 @@ func (suite *IntegrationSuite) TestService_AddRefund() {
 	assert.Equal(suite.T(), params.AccountNumber, account)
 	assert.Equal(suite.T(), params.SortCode, sortCode)
 
+	assert.NoError(suite.T(), s.RelayOutbox(ctx))
 	assert.Equal(suite.T(), clientID, dispatch.event.(event.RefundAdded).ClientID)
 }
 
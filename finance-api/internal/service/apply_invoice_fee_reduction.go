@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+// ApplyInvoiceFeeReduction credits an invoice raised in Sirius with the fee reduction active on its raised date, as
+// the award will have been added before the invoice existed. Invoices that already have a fee reduction are skipped.
+func (s *Service) ApplyInvoiceFeeReduction(ctx context.Context, clientID int32, invoiceID int32) error {
+	tx, err := s.BeginStoreTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = s.checkPostingPeriod(ctx, tx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	invoice, err := tx.GetInvoiceBalanceForFeeReduction(ctx, invoiceID)
+	if err != nil {
+		s.Logger(ctx).Error(fmt.Sprintf("Error getting invoice %d for fee reduction", invoiceID), slog.String("err", err.Error()))
+		return err
+	}
+
+	if invoice.FeeReduced {
+		return nil
+	}
+
+	feeReduction, err := tx.GetFeeReductionForDate(ctx, store.GetFeeReductionForDateParams{
+		ClientID:     clientID,
+		DateReceived: invoice.Raiseddate,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	} else if err != nil {
+		s.Logger(ctx).Error(fmt.Sprintf("Error getting fee reduction for invoice %d", invoiceID), slog.String("err", err.Error()))
+		return err
+	}
+
+	feeReductionType := shared.ParseFeeReductionType(feeReduction.Type)
+	ledger, allocations := generateLedgerEntries(ctx, addLedgerVars{
+		amount:             calculateFeeReduction(feeReductionType, invoice.Amount, invoice.Feetype, invoice.GeneralSupervisionFee),
+		transactionType:    feeReductionType,
+		feeReductionId:     feeReduction.ID,
+		clientId:           clientID,
+		invoiceId:          invoiceID,
+		outstandingBalance: invoice.Outstanding,
+	})
+	ledgerID, err := tx.CreateLedger(ctx, ledger)
+	if err != nil {
+		s.Logger(ctx).Error(fmt.Sprintf("Error in apply fee reduction for ledger %d for client %d", ledgerID, clientID), slog.String("err", err.Error()))
+		return err
+	}
+
+	for _, allocation := range allocations {
+		allocation.LedgerID = ledgerID
+		err = tx.CreateLedgerAllocation(ctx, allocation)
+		if err != nil {
+			s.Logger(ctx).Error(fmt.Sprintf("Error in apply fee reduction for ledger allocation %d for client %d", ledgerID, clientID), slog.String("err", err.Error()))
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationSuite) TestService_ApplyInvoiceFeeReduction() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	seeder.SeedData(
+		"INSERT INTO finance_client VALUES (1, 1, '1234', 'DEMANDED', NULL);",
+		"INSERT INTO fee_reduction VALUES (1, 1, 'EXEMPTION', NULL, '2024-04-01', '2025-03-31', 'Exemption', FALSE, '2024-04-01');",
+		"INSERT INTO invoice VALUES (1, 1, 1, 'AD', 'AD000001/24', '2024-06-01', '2024-06-01', 10000, NULL, NULL, NULL, '2024-06-01', NULL, NULL, NULL, '2024-06-01', 1);",
+		"INSERT INTO invoice VALUES (2, 1, 1, 'AD', 'AD000002/25', '2025-06-01', '2025-06-01', 10000, NULL, NULL, NULL, '2025-06-01', NULL, NULL, NULL, '2025-06-01', 1);",
+	)
+
+	s := &Service{store: store.New(seeder.Conn), tx: seeder.Conn}
+
+	err := s.ApplyInvoiceFeeReduction(ctx, 1, 1)
+	assert.NoError(suite.T(), err)
+
+	// applying again does not credit the invoice twice
+	err = s.ApplyInvoiceFeeReduction(ctx, 1, 1)
+	assert.NoError(suite.T(), err)
+
+	// raised outside the award
+	err = s.ApplyInvoiceFeeReduction(ctx, 1, 2)
+	assert.NoError(suite.T(), err)
+
+	var (
+		count            int
+		ledgerType       string
+		ledgerAmount     int
+		feeReductionID   int
+		allocationStatus string
+	)
+
+	row := seeder.QueryRow(ctx, "SELECT COUNT(*) FROM supervision_finance.ledger")
+	_ = row.Scan(&count)
+	assert.Equal(suite.T(), 1, count)
+
+	row = seeder.QueryRow(ctx, "SELECT l.type, l.amount, l.fee_reduction_id, la.status FROM supervision_finance.ledger l JOIN supervision_finance.ledger_allocation la ON l.id = la.ledger_id WHERE la.invoice_id = 1")
+	_ = row.Scan(&ledgerType, &ledgerAmount, &feeReductionID, &allocationStatus)
+
+	assert.Equal(suite.T(), "CREDIT EXEMPTION", ledgerType)
+	assert.Equal(suite.T(), 10000, ledgerAmount)
+	assert.Equal(suite.T(), 1, feeReductionID)
+	assert.Equal(suite.T(), "ALLOCATED", allocationStatus)
+}
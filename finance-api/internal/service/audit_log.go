@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/auth"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+type auditEntry struct {
+	clientId   int32
+	action     string
+	entityType string
+	entityId   int32
+	before     map[string]string
+	after      map[string]string
+}
+
+// audit records a change to a client's finances in the audit log. It must be written in the same transaction as the
+// change itself, so that the log cannot disagree with the data. Changes that do not belong to a client, such as
+// reopening an accounting period, are recorded without a client id.
+func (s *Service) audit(ctx context.Context, tx *store.Tx, entry auditEntry) error {
+	params := store.CreateAuditLogParams{
+		Action:     entry.action,
+		EntityType: entry.entityType,
+		EntityID:   entry.entityId,
+		CreatedBy:  ctx.(auth.Context).User.ID,
+		ClientID:   entry.clientId,
+	}
+
+	var err error
+	if entry.before != nil {
+		if params.Before, err = json.Marshal(entry.before); err != nil {
+			return err
+		}
+	}
+	if entry.after != nil {
+		if params.After, err = json.Marshal(entry.after); err != nil {
+			return err
+		}
+	}
+
+	if entry.clientId == 0 {
+		_, err = tx.CreateSystemAuditLog(ctx, store.CreateSystemAuditLogParams{
+			Action:     params.Action,
+			EntityType: params.EntityType,
+			EntityID:   params.EntityID,
+			Before:     params.Before,
+			After:      params.After,
+			CreatedBy:  params.CreatedBy,
+		})
+		if err != nil {
+			s.Logger(ctx).Error(fmt.Sprintf("Error writing %s audit log", entry.action), slog.String("err", err.Error()))
+		}
+		return err
+	}
+
+	// the entry is only written if the client exists, so no row means the change would go unaudited
+	_, err = tx.CreateAuditLog(ctx, params)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = fmt.Errorf("finance client not found for client %d", entry.clientId)
+	}
+	if err != nil {
+		s.Logger(ctx).Error(fmt.Sprintf("Error writing %s audit log for client %d", entry.action, entry.clientId), slog.String("err", err.Error()))
+	}
+	return err
+}
+
+func (s *Service) GetAuditLogs(ctx context.Context, clientId int32) (shared.AuditLogs, error) {
+	rows, err := s.store.GetAuditLogs(ctx, clientId)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := shared.AuditLogs{}
+	for _, row := range rows {
+		log := shared.AuditLog{
+			ID:         int(row.ID),
+			Action:     row.Action,
+			EntityType: row.EntityType,
+			EntityID:   int(row.EntityID),
+			CreatedAt:  row.CreatedAt.Time,
+			CreatedBy:  int(row.CreatedBy),
+		}
+		if row.Before != nil {
+			if err = json.Unmarshal(row.Before, &log.Before); err != nil {
+				return nil, err
+			}
+		}
+		if row.After != nil {
+			if err = json.Unmarshal(row.After, &log.After); err != nil {
+				return nil, err
+			}
+		}
+		logs = append(logs, log)
+	}
+
+	return logs, nil
+}
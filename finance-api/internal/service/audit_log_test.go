@@ -0,0 +1,64 @@
+package service
+
+import (
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationSuite) TestService_AuditLog() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	seeder.SeedData(
+		"INSERT INTO finance_client VALUES (1, 1, 'audit-1', 'DEMANDED', NULL, '11111111');",
+		"INSERT INTO fee_reduction VALUES (1, 1, 'REMISSION', NULL, '2024-04-01', '2025-03-31', 'Remission notes', FALSE, '2024-03-31');",
+		"INSERT INTO refund VALUES (1, 1, '2024-01-01', 1000, 'PENDING', '', 99, '2025-06-04 00:00:00')",
+	)
+
+	s := Service{store: store.New(seeder.Conn), dispatch: &mockDispatch{}, tx: seeder.Conn}
+
+	err := s.UpdatePaymentMethod(ctx, 1, shared.PaymentMethodDirectDebit)
+	assert.NoError(suite.T(), err)
+
+	err = s.CancelFeeReduction(ctx, 1, shared.CancelFeeReduction{CancellationReason: "Awarded in error"})
+	assert.NoError(suite.T(), err)
+
+	err = s.UpdateRefundDecision(ctx, 1, 1, shared.RefundStatusRejected)
+	assert.NoError(suite.T(), err)
+
+	logs, err := s.GetAuditLogs(ctx, 1)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), logs, 3)
+
+	// most recent first
+	assert.Equal(suite.T(), shared.AuditActionRefundDecision, logs[0].Action)
+	assert.Equal(suite.T(), shared.AuditEntityRefund, logs[0].EntityType)
+	assert.Equal(suite.T(), 1, logs[0].EntityID)
+	assert.Equal(suite.T(), map[string]string{"status": "PENDING"}, logs[0].Before)
+	assert.Equal(suite.T(), map[string]string{"status": "REJECTED"}, logs[0].After)
+	assert.Equal(suite.T(), 10, logs[0].CreatedBy)
+
+	assert.Equal(suite.T(), shared.AuditActionFeeReductionCancelled, logs[1].Action)
+	assert.Equal(suite.T(), map[string]string{"cancelled": "false"}, logs[1].Before)
+	assert.Equal(suite.T(), map[string]string{
+		"cancelled":          "true",
+		"cancellationReason": "Awarded in error",
+		"creditsReversed":    "false",
+	}, logs[1].After)
+
+	assert.Equal(suite.T(), shared.AuditActionPaymentMethodChanged, logs[2].Action)
+	assert.Equal(suite.T(), map[string]string{"paymentMethod": "DEMANDED"}, logs[2].Before)
+	assert.Equal(suite.T(), map[string]string{"paymentMethod": "DIRECT DEBIT"}, logs[2].After)
+
+	tx, _ := s.BeginStoreTx(ctx)
+	err = s.audit(ctx, tx, auditEntry{clientId: 99, action: shared.AuditActionRefundDecision, entityType: shared.AuditEntityRefund, entityId: 1})
+	assert.EqualError(suite.T(), err, "finance client not found for client 99")
+	tx.Rollback(ctx)
+
+	_, err = seeder.Exec(ctx, "UPDATE audit_log SET created_by = 1")
+	assert.ErrorContains(suite.T(), err, "audit_log is append-only")
+
+	_, err = seeder.Exec(ctx, "DELETE FROM audit_log")
+	assert.ErrorContains(suite.T(), err, "audit_log is append-only")
+}
@@ -83,7 +83,7 @@ func (s *Service) CancelDirectDebitMandate(ctx context.Context, clientID int32,
 		)
 	}
 
-	err = s.dispatch.PaymentMethodChanged(ctx, event.PaymentMethod{
+	err = s.outbox(tx).PaymentMethodChanged(ctx, event.PaymentMethod{
 		ClientID:      int(clientID),
 		PaymentMethod: shared.PaymentMethodDemanded,
 	})
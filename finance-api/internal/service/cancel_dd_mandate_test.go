@@ -65,6 +65,7 @@ func (suite *IntegrationSuite) TestService_CancelDirectDebitMandate() {
 
 	assert.Equal(suite.T(), 2, id)
 
+	assert.NoError(suite.T(), s.RelayOutbox(ctx))
 	assert.Equal(suite.T(), event.PaymentMethod{
 		ClientID:      11,
 		PaymentMethod: shared.PaymentMethodDemanded,
@@ -112,7 +113,8 @@ func (suite *IntegrationSuite) TestService_CancelDirectDebitMandate_fails() {
 	_ = rows.Scan(&paymentMethod)
 
 	assert.Equal(suite.T(), "DIRECT DEBIT", paymentMethod) // not changed when unable to update in allpay
-	assert.Nil(suite.T(), dispatchMock.event)              // event should not have been sent
+	assert.NoError(suite.T(), s.RelayOutbox(ctx))
+	assert.Nil(suite.T(), dispatchMock.event) // event should not have been sent
 }
 
 func (suite *IntegrationSuite) TestService_CancelDirectDebitMandate_skips_allpay_when_disabled() {
@@ -156,6 +158,7 @@ func (suite *IntegrationSuite) TestService_CancelDirectDebitMandate_skips_allpay
 	_ = rows.Scan(&paymentMethod)
 	assert.Equal(suite.T(), "DEMANDED", paymentMethod)
 
+	assert.NoError(suite.T(), s.RelayOutbox(ctx))
 	assert.Equal(suite.T(), event.PaymentMethod{
 		ClientID:      11,
 		PaymentMethod: shared.PaymentMethodDemanded,
@@ -194,6 +197,7 @@ func (suite *IntegrationSuite) TestService_CancelDirectDebitMandate_skips_when_n
 	})
 	assert.NoError(suite.T(), err)
 	assert.Empty(suite.T(), allpayMock.called)
+	assert.NoError(suite.T(), s.RelayOutbox(ctx))
 	assert.Nil(suite.T(), dispatchMock.event)
 }
 
@@ -8,6 +8,8 @@ import (
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
 	"log/slog"
+	"strconv"
+	"time"
 )
 
 func (s *Service) CancelFeeReduction(ctx context.Context, id int32, cancelledFeeReduction shared.CancelFeeReduction) error {
@@ -19,7 +21,19 @@ func (s *Service) CancelFeeReduction(ctx context.Context, id int32, cancelledFee
 	_ = store.ToInt4(&cancelledBy, ctx.(auth.Context).User.ID)
 	_ = cancellationReason.Scan(cancelledFeeReduction.CancellationReason)
 
-	_, err := s.store.CancelFeeReduction(ctx, store.CancelFeeReductionParams{
+	tx, err := s.BeginStoreTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	cancelled, err := tx.IsFeeReductionCancelled(ctx, id)
+	if err != nil {
+		s.Logger(ctx).Error(fmt.Sprintf("Error in cancel fee reduction: %d", id), slog.String("err", err.Error()))
+		return err
+	}
+
+	feeReduction, err := tx.CancelFeeReduction(ctx, store.CancelFeeReductionParams{
 		ID:                 id,
 		CancelledBy:        cancelledBy,
 		CancellationReason: cancellationReason,
@@ -30,5 +44,78 @@ func (s *Service) CancelFeeReduction(ctx context.Context, id int32, cancelledFee
 		return err
 	}
 
-	return nil
+	if cancelledFeeReduction.ReverseCredits {
+		err = s.checkPostingPeriod(ctx, tx, time.Now())
+		if err != nil {
+			return err
+		}
+
+		err = s.reverseFeeReductionCredits(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	clientId, err := tx.GetClientIdByFinanceClientId(ctx, feeReduction.FinanceClientID.Int32)
+	if err != nil {
+		return err
+	}
+
+	err = s.audit(ctx, tx, auditEntry{
+		clientId:   clientId,
+		action:     shared.AuditActionFeeReductionCancelled,
+		entityType: shared.AuditEntityFeeReduction,
+		entityId:   id,
+		before:     map[string]string{"cancelled": strconv.FormatBool(cancelled)},
+		after: map[string]string{
+			"cancelled":          "true",
+			"cancellationReason": cancelledFeeReduction.CancellationReason,
+			"creditsReversed":    strconv.FormatBool(cancelledFeeReduction.ReverseCredits),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// reverseFeeReductionCredits creates a fee reduction reversal against each invoice for the credits the fee reduction
+// applied to it. The reversals are linked to the fee reduction so that the credits are not reversed a second time.
+func (s *Service) reverseFeeReductionCredits(ctx context.Context, tx *store.Tx, id int32) error {
+	credits, err := tx.GetFeeReductionCredits(ctx, id)
+	if err != nil {
+		s.Logger(ctx).Error(fmt.Sprintf("Error getting credits for cancelled fee reduction: %d", id), slog.String("err", err.Error()))
+		return err
+	}
+
+	if len(credits) == 0 {
+		return nil
+	}
+
+	for _, credit := range credits {
+		ledger, allocations := generateLedgerEntries(ctx, addLedgerVars{
+			amount:          -credit.Amount,
+			transactionType: shared.AdjustmentTypeFeeReductionReversal,
+			feeReductionId:  id,
+			clientId:        credit.ClientID,
+			invoiceId:       credit.InvoiceID,
+		})
+		ledgerID, err := tx.CreateLedger(ctx, ledger)
+		if err != nil {
+			s.Logger(ctx).Error(fmt.Sprintf("Error in reversing fee reduction %d for invoice %d", id, credit.InvoiceID), slog.String("err", err.Error()))
+			return err
+		}
+
+		for _, allocation := range allocations {
+			allocation.LedgerID = ledgerID
+			err = tx.CreateLedgerAllocation(ctx, allocation)
+			if err != nil {
+				s.Logger(ctx).Error(fmt.Sprintf("Error in reversing fee reduction %d for ledger allocation %d", id, ledgerID), slog.String("err", err.Error()))
+				return err
+			}
+		}
+	}
+
+	return s.PostLedgerActions(ctx, credits[0].ClientID, tx)
 }
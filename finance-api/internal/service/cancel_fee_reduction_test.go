@@ -45,3 +45,58 @@ func (suite *IntegrationSuite) TestService_CancelFeeReduction() {
 	}
 	suite.T().Error("Cancel fee reduction failed")
 }
+
+func (suite *IntegrationSuite) TestService_CancelFeeReduction_reverseCredits() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	seeder.SeedData(
+		"INSERT INTO finance_client VALUES (34, 34, '1235', 'DEMANDED', NULL);",
+		"INSERT INTO fee_reduction VALUES (34, 34, 'REMISSION', NULL, '2019-04-01', '2021-03-31', 'Remission to see the notes', FALSE, '2019-05-01');",
+		"INSERT INTO invoice VALUES (34, 34, 34, 'AD', 'AD000034/19', '2019-04-01', '2019-04-01', 10000, NULL, NULL, NULL, '2019-04-01', NULL, NULL, NULL, '2019-04-01', 1);",
+		"INSERT INTO ledger VALUES (34, '34', '2019-05-01T00:00:00+00:00', '', 5000, 'Credit due to approved remission', 'CREDIT REMISSION', 'CONFIRMED', 34, NULL, 34, NULL, NULL, NULL, NULL, NULL, NULL, '2019-05-01', 1);",
+		"INSERT INTO ledger_allocation VALUES (34, 34, 34, '2019-05-01T00:00:00+00:00', 5000, 'ALLOCATED', NULL, '', '2019-05-01', NULL);",
+		// credit on account from an overpayment, to be reapplied once the remission is reversed
+		"INSERT INTO ledger VALUES (35, '35', '2019-06-01T00:00:00+00:00', '', 2000, 'Overpayment', 'ONLINE CARD PAYMENT', 'CONFIRMED', 34, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, '2019-06-01', 1);",
+		"INSERT INTO ledger_allocation VALUES (35, 35, NULL, '2019-06-01T00:00:00+00:00', -2000, 'UNAPPLIED', NULL, '', '2019-06-01', NULL);",
+		"ALTER SEQUENCE ledger_id_seq RESTART WITH 36;",
+		"ALTER SEQUENCE ledger_allocation_id_seq RESTART WITH 36;",
+	)
+
+	s := &Service{
+		store:    store.New(seeder.Conn),
+		dispatch: &mockDispatch{},
+		tx:       seeder.Conn,
+	}
+
+	err := s.CancelFeeReduction(ctx, 34, shared.CancelFeeReduction{CancellationReason: "Awarded in error", ReverseCredits: true})
+	assert.NoError(suite.T(), err)
+
+	var (
+		ledgerType       string
+		ledgerAmount     int
+		feeReductionID   int
+		allocationAmount int
+		allocationStatus string
+	)
+
+	row := seeder.QueryRow(ctx, "SELECT l.type, l.amount, l.fee_reduction_id, la.amount, la.status FROM supervision_finance.ledger l JOIN supervision_finance.ledger_allocation la ON l.id = la.ledger_id WHERE l.id = 36")
+	_ = row.Scan(&ledgerType, &ledgerAmount, &feeReductionID, &allocationAmount, &allocationStatus)
+
+	assert.Equal(suite.T(), "FEE REDUCTION REVERSAL", ledgerType)
+	assert.Equal(suite.T(), -5000, ledgerAmount)
+	assert.Equal(suite.T(), 34, feeReductionID)
+	assert.Equal(suite.T(), -5000, allocationAmount)
+	assert.Equal(suite.T(), "ALLOCATED", allocationStatus)
+
+	var reapplied int
+	row = seeder.QueryRow(ctx, "SELECT SUM(la.amount) FROM supervision_finance.ledger_allocation la WHERE la.invoice_id = 34 AND la.status = 'REAPPLIED'")
+	_ = row.Scan(&reapplied)
+
+	assert.Equal(suite.T(), 2000, reapplied)
+
+	// the reversal nets off the credits, so they cannot be reversed again
+	credits, err := s.store.GetFeeReductionCredits(ctx, 34)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), credits)
+}
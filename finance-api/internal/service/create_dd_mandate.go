@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strconv"
 	"strings"
 
 	"github.com/jackc/pgx/v5/pgtype"
@@ -38,6 +39,11 @@ func (s *Service) CreateDirectDebitMandate(ctx context.Context, clientID int32,
 	_ = store.ToInt4(&id, clientID)
 	_ = store.ToInt4(&createdBy, ctx.(auth.Context).User.ID)
 
+	current, err := tx.GetPaymentMethod(ctx, clientID)
+	if err != nil {
+		return ScheduleData{}, err
+	}
+
 	// update payment method first, in case this fails
 	err = tx.SetPaymentMethod(ctx, store.SetPaymentMethodParams{
 		PaymentMethod: paymentMethod,
@@ -110,7 +116,25 @@ func (s *Service) CreateDirectDebitMandate(ctx context.Context, clientID int32,
 		return ScheduleData{}, apierror.BadRequestError("Allpay", "Failed", err)
 	}
 
-	err = s.dispatch.PaymentMethodChanged(ctx, event.PaymentMethod{
+	after := map[string]string{"paymentMethod": shared.PaymentMethodDirectDebit.Key()}
+	if pc.Amount > 0 {
+		after["scheduledAmount"] = strconv.Itoa(int(pc.Amount))
+		after["scheduledDate"] = pc.CollectionDate.Format("2006-01-02")
+	}
+
+	err = s.audit(ctx, tx, auditEntry{
+		clientId:   clientID,
+		action:     shared.AuditActionDirectDebitMandateCreated,
+		entityType: shared.AuditEntityFinanceClient,
+		entityId:   clientID,
+		before:     map[string]string{"paymentMethod": current},
+		after:      after,
+	})
+	if err != nil {
+		return ScheduleData{}, err
+	}
+
+	err = s.outbox(tx).PaymentMethodChanged(ctx, event.PaymentMethod{
 		ClientID:      int(clientID),
 		PaymentMethod: shared.PaymentMethodDirectDebit,
 	})
@@ -60,6 +60,7 @@ func (suite *IntegrationSuite) TestService_CreateDirectDebitMandate() {
 	_ = rows.Scan(&paymentMethod)
 
 	assert.Equal(suite.T(), "DIRECT DEBIT", paymentMethod)
+	assert.NoError(suite.T(), s.RelayOutbox(ctx))
 	assert.Equal(suite.T(), event.PaymentMethod{
 		ClientID:      11,
 		PaymentMethod: shared.PaymentMethodDirectDebit,
@@ -73,6 +74,11 @@ func (suite *IntegrationSuite) TestService_CreateDirectDebitMandate() {
 	// Verify PendingCollection is empty when no balance
 	assert.Equal(suite.T(), int32(0), pc.Amount)
 
+	logs, _ := s.GetAuditLogs(ctx, 11)
+	assert.Len(suite.T(), logs, 1)
+	assert.Equal(suite.T(), shared.AuditActionDirectDebitMandateCreated, logs[0].Action)
+	assert.Equal(suite.T(), map[string]string{"paymentMethod": "DEMANDED"}, logs[0].Before)
+	assert.Equal(suite.T(), map[string]string{"paymentMethod": "DIRECT DEBIT"}, logs[0].After)
 }
 
 func (suite *IntegrationSuite) TestService_CreateDirectDebitMandate_modulusCheckFails() {
@@ -124,7 +130,8 @@ func (suite *IntegrationSuite) TestService_CreateDirectDebitMandate_modulusCheck
 	_ = rows.Scan(&paymentMethod)
 
 	assert.Equal(suite.T(), "DEMANDED", paymentMethod) // not changed when unable to update in allpay
-	assert.Nil(suite.T(), dispatchMock.event)          // event should not have been sent
+	assert.NoError(suite.T(), s.RelayOutbox(ctx))
+	assert.Nil(suite.T(), dispatchMock.event) // event should not have been sent
 }
 
 func (suite *IntegrationSuite) TestService_CreateDirectDebitMandate_createMandateFails() {
@@ -182,5 +189,6 @@ func (suite *IntegrationSuite) TestService_CreateDirectDebitMandate_createMandat
 	_ = rows.Scan(&paymentMethod)
 
 	assert.Equal(suite.T(), "DEMANDED", paymentMethod) // not changed when unable to update in allpay
-	assert.Nil(suite.T(), dispatchMock.event)          // event should not have been sent
+	assert.NoError(suite.T(), s.RelayOutbox(ctx))
+	assert.Nil(suite.T(), dispatchMock.event) // event should not have been sent
 }
@@ -48,13 +48,52 @@ func (s *Service) CreateDirectDebitSchedule(ctx context.Context, details shared.
 		return nil
 	}
 
-	schedule, err := s.generateScheduleData(ctx, details.ClientID)
+	return s.createSchedule(ctx, client)
+}
+
+// RescheduleDirectDebit creates a new schedule for a client's remaining balance once their pending collections have
+// been removed, e.g. after voiding an invoice that was included in them. Each pending collection is for the whole
+// balance outstanding when it was scheduled, so the amount is recalculated rather than adjusted.
+func (s *Service) RescheduleDirectDebit(ctx context.Context, clientID int32) error {
+	logger := s.Logger(ctx)
+
+	if !s.env.AllpayEnabled {
+		logger.Info(fmt.Sprintf("skipping Direct Debit reschedule for client id %d as Allpay is disabled in this environment", clientID))
+		return nil
+	}
+
+	collections, err := s.store.GetPendingCollections(ctx, clientID)
+	if err != nil {
+		return err
+	}
+	if len(collections) > 0 {
+		// the removal of the remaining collections will trigger the reschedule
+		return nil
+	}
+
+	client, err := s.store.GetClientById(ctx, clientID)
+	if err != nil {
+		return err
+	}
+	if client.PaymentMethod != shared.PaymentMethodDirectDebit.Key() {
+		return nil
+	}
+
+	return s.createSchedule(ctx, client)
+}
+
+// createSchedule records a pending collection for the client's outstanding balance and creates the matching schedule
+// in Allpay
+func (s *Service) createSchedule(ctx context.Context, client store.GetClientByIdRow) error {
+	logger := s.Logger(ctx)
+
+	schedule, err := s.generateScheduleData(ctx, client.ClientID)
 	if err != nil {
 		return err
 	}
 
 	if schedule.Amount < 1 {
-		logger.Info(fmt.Sprintf("skipping Direct Debit schedule creation for client %d as there is no balance outstanding", details.ClientID))
+		logger.Info(fmt.Sprintf("skipping Direct Debit schedule creation for client %d as there is no balance outstanding", client.ClientID))
 		return nil
 	}
 
@@ -68,7 +107,7 @@ func (s *Service) CreateDirectDebitSchedule(ctx context.Context, details shared.
 	_ = collectionDate.Scan(schedule.CollectionDate)
 
 	err = tx.CreatePendingCollection(ctx, store.CreatePendingCollectionParams{
-		ClientID:       details.ClientID,
+		ClientID:       client.ClientID,
 		CollectionDate: collectionDate,
 		Amount:         schedule.Amount,
 		CreatedBy:      ctx.(auth.Context).User.ID,
@@ -91,8 +130,9 @@ func (s *Service) CreateDirectDebitSchedule(ctx context.Context, details shared.
 		if errors.As(err, &ve) {
 			logger.Error("validation errors returned from allpay", "errors", ve.Messages)
 		}
-		dispatchErr := s.dispatch.DirectDebitScheduleFailed(ctx, event.DirectDebitScheduleFailed{
-			ClientID: int(details.ClientID),
+		// the pending collection is rolled back, but the failure must still be published
+		dispatchErr := s.outbox(s.store).DirectDebitScheduleFailed(ctx, event.DirectDebitScheduleFailed{
+			ClientID: int(client.ClientID),
 		})
 		if dispatchErr != nil {
 			return dispatchErr
@@ -244,3 +244,37 @@ func (suite *IntegrationSuite) TestService_CreateDirectDebitSchedule_skipsWhenNo
 	assert.Equal(suite.T(), 0, c)
 	assert.Len(suite.T(), allPayMock.called, 0)
 }
+
+func (suite *IntegrationSuite) TestService_RescheduleDirectDebit() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	seeder.SeedData(
+		"INSERT INTO public.persons VALUES (11, NULL, NULL, 'Scheduleson', NULL, NULL, NULL, NULL, FALSE, FALSE, NULL, NULL, 'Client', NULL);",
+		"INSERT INTO finance_client VALUES (1, 11, '1234', 'DIRECT DEBIT', NULL, '1234567T');",
+		`INSERT INTO public.addresses VALUES (1, 11, '["1 Test Street"]', 'Testtown', NULL, 'TE1 1ST', NULL);`,
+		"INSERT INTO invoice VALUES (1, 11, 1, 'S2', 'S200123/24', '2024-01-01', '2025-03-31', 10000, NULL, '2024-01-01', NULL, '2024-01-01')",
+		"INSERT INTO pending_collection VALUES (1, 1, '2024-02-01', 25000, 'CANCELLED', NULL, '2024-01-01 00:00:00', 1)",
+		"INSERT INTO pending_collection VALUES (2, 1, '2024-03-01', 25000, 'PENDING', NULL, '2024-01-01 00:00:00', 1)",
+		"ALTER SEQUENCE supervision_finance.pending_collection_id_seq RESTART WITH 3",
+	)
+
+	allPayMock := &mockAllpay{}
+	govUKMock := &mockGovUK{}
+	s := Service{store: store.New(seeder.Conn), allpay: allPayMock, govUK: govUKMock, tx: seeder.Conn, env: &Env{AllpayEnabled: true}}
+
+	// waits for the remaining collection to be removed
+	err := s.RescheduleDirectDebit(ctx, 11)
+	assert.Nil(suite.T(), err)
+	assert.Len(suite.T(), allPayMock.called, 0)
+
+	seeder.SeedData("UPDATE pending_collection SET status = 'CANCELLED' WHERE id = 2")
+
+	err = s.RescheduleDirectDebit(ctx, 11)
+	assert.Nil(suite.T(), err)
+
+	var amount int32
+	_ = seeder.QueryRow(ctx, "SELECT amount FROM pending_collection WHERE status = 'PENDING'").Scan(&amount)
+	assert.Equal(suite.T(), int32(10000), amount)
+	assert.Equal(suite.T(), []string{"CreateSchedule"}, allPayMock.called)
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/auth"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+// AddCreditTransfer requests that unapplied credit is moved from a client to the client with the given court reference.
+// Nothing is posted to either account until the transfer is approved.
+func (s *Service) AddCreditTransfer(ctx context.Context, clientId int32, transfer shared.AddCreditTransfer) error {
+	courtRef, err := s.store.GetCourtRefByClientId(ctx, clientId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apierror.NotFoundError(err)
+	} else if err != nil {
+		return err
+	}
+
+	if courtRef.String == transfer.ToCourtRef {
+		return apierror.ValidationError{Errors: apierror.ValidationErrors{
+			"ToCourtRef": {"same-client": "Credit cannot be transferred to the same client"},
+		}}
+	}
+
+	var toCourtRef pgtype.Text
+	_ = toCourtRef.Scan(transfer.ToCourtRef)
+
+	exists, err := s.store.CheckClientExistsByCourtRef(ctx, toCourtRef)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return apierror.ValidationError{Errors: apierror.ValidationErrors{
+			"ToCourtRef": {"not-found": "Could not find a client with this court reference"},
+		}}
+	}
+
+	credit, err := s.store.GetRefundAmount(ctx, clientId)
+	if err != nil {
+		return err
+	}
+	if transfer.Amount > credit {
+		return apierror.ValidationError{Errors: apierror.ValidationErrors{
+			"Amount": {"credit-available": "The amount is more than the credit available to transfer"},
+		}}
+	}
+
+	_, err = s.store.CreateCreditTransfer(ctx, store.CreateCreditTransferParams{
+		Amount:       transfer.Amount,
+		Notes:        transfer.TransferNotes,
+		CreatedBy:    ctx.(auth.Context).User.ID,
+		FromClientID: clientId,
+		ToCourtRef:   toCourtRef,
+	})
+	if err != nil {
+		s.Logger(ctx).Error("Error creating credit transfer", slog.String("err", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) GetPendingCreditTransfers(ctx context.Context) (shared.CreditTransfers, error) {
+	rows, err := s.store.GetPendingCreditTransfers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers := shared.CreditTransfers{}
+	for _, row := range rows {
+		transfers = append(transfers, shared.CreditTransfer{
+			ID:           int(row.ID),
+			FromClientId: int(row.FromClientID),
+			FromCourtRef: row.FromCourtRef.String,
+			ToClientId:   int(row.ToClientID),
+			ToCourtRef:   row.ToCourtRef.String,
+			Amount:       int(row.Amount),
+			Notes:        row.Notes,
+			CreatedDate:  shared.Date{Time: row.CreatedAt.Time},
+			CreatedBy:    int(row.CreatedBy),
+		})
+	}
+
+	return transfers, nil
+}
+
+// UpdateCreditTransferDecision approves or rejects a pending credit transfer. On approval, a pair of credit transfer
+// ledgers moves the credit off the sending client and onto the receiving client as unapplied credit, which is then
+// reapplied to any open invoices.
+func (s *Service) UpdateCreditTransferDecision(ctx context.Context, id int32, status shared.AdjustmentStatus) error {
+	tx, err := s.BeginStoreTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	transfer, err := tx.GetCreditTransferForDecision(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apierror.NotFoundError(err)
+	} else if err != nil {
+		return err
+	}
+
+	var (
+		decisionBy   pgtype.Int4
+		fromLedgerID pgtype.Int4
+		toLedgerID   pgtype.Int4
+	)
+	_ = store.ToInt4(&decisionBy, ctx.(auth.Context).User.ID)
+
+	err = s.checkFourEyes(ctx, tx, "credit transfer", decisionVerb(status == shared.AdjustmentStatusApproved), transfer.FromClientID, shared.AuditEntityCreditTransfer, id, transfer.CreatedBy)
+	if err != nil {
+		return err
+	}
+
+	if status == shared.AdjustmentStatusApproved {
+		err = s.checkPostingPeriod(ctx, tx, time.Now())
+		if err != nil {
+			return err
+		}
+
+		credit, err := tx.GetRefundAmount(ctx, transfer.FromClientID)
+		if err != nil {
+			return err
+		}
+		if transfer.Amount > credit {
+			return apierror.BadRequestError("amount", "The client no longer has enough credit for this transfer", nil)
+		}
+
+		from, err := s.createCreditTransferLedger(ctx, tx, transfer.FromClientID, -transfer.Amount, "REAPPLIED", fmt.Sprintf("Credit transferred to client %d", transfer.ToClientID))
+		if err != nil {
+			return err
+		}
+		to, err := s.createCreditTransferLedger(ctx, tx, transfer.ToClientID, transfer.Amount, "UNAPPLIED", fmt.Sprintf("Credit transferred from client %d", transfer.FromClientID))
+		if err != nil {
+			return err
+		}
+
+		_ = store.ToInt4(&fromLedgerID, from)
+		_ = store.ToInt4(&toLedgerID, to)
+	}
+
+	err = tx.SetCreditTransferDecision(ctx, store.SetCreditTransferDecisionParams{
+		Status:       status.Key(),
+		DecisionBy:   decisionBy,
+		FromLedgerID: fromLedgerID,
+		ToLedgerID:   toLedgerID,
+		ID:           id,
+	})
+	if err != nil {
+		return err
+	}
+
+	if status == shared.AdjustmentStatusApproved {
+		err = s.PostLedgerActions(ctx, transfer.FromClientID, tx)
+		if err != nil {
+			return err
+		}
+		err = s.PostLedgerActions(ctx, transfer.ToClientID, tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// createCreditTransferLedger posts one side of a credit transfer. The allocation has no invoice and offsets the ledger,
+// so that it adds to or removes from the client's unapplied credit.
+func (s *Service) createCreditTransferLedger(ctx context.Context, tx *store.Tx, clientId int32, amount int32, status string, note string) (int32, error) {
+	var (
+		notes     pgtype.Text
+		createdBy pgtype.Int4
+	)
+	_ = notes.Scan(note)
+	_ = store.ToInt4(&createdBy, ctx.(auth.Context).User.ID)
+
+	ledgerID, err := tx.CreateLedger(ctx, store.CreateLedgerParams{
+		ClientID:  clientId,
+		Amount:    amount,
+		Notes:     notes,
+		Type:      shared.TransactionTypeCreditTransfer.Key(),
+		Status:    "CONFIRMED",
+		CreatedBy: createdBy,
+	})
+	if err != nil {
+		s.Logger(ctx).Error(fmt.Sprintf("Error creating credit transfer ledger for client %d", clientId), slog.String("err", err.Error()))
+		return 0, err
+	}
+
+	err = tx.CreateLedgerAllocation(ctx, store.CreateLedgerAllocationParams{
+		LedgerID: ledgerID,
+		Amount:   -amount,
+		Status:   status,
+	})
+	return ledgerID, err
+}
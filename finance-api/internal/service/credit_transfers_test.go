@@ -0,0 +1,107 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/auth"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationSuite) TestService_CreditTransfer() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	seeder.SeedData(
+		"INSERT INTO finance_client VALUES (1, 1, 'transfer-1', 'DEMANDED', NULL, '11111111');",
+		"INSERT INTO finance_client VALUES (2, 2, 'transfer-2', 'DEMANDED', NULL, '22222222');",
+		"INSERT INTO ledger VALUES (1, 'overpayment', '2024-01-02 15:32:10', '', 10000, 'payment 1', 'MOTO CARD PAYMENT', 'CONFIRMED', 1, NULL, NULL, NULL, '2024-01-01', NULL, NULL, NULL, NULL, '2024-01-02', 1);",
+		"INSERT INTO ledger_allocation VALUES (1, 1, NULL, '2024-01-02 15:32:10', -10000, 'UNAPPLIED', NULL, '', '2024-01-01', NULL);",
+		"INSERT INTO invoice VALUES (1, 2, 2, 'AD', 'AD11223/19', '2023-04-01', '2025-03-31', 4000, NULL, '2024-03-31', 11, '2024-03-31', NULL, NULL, NULL, '2024-03-31 00:00:00', '99');",
+		"ALTER SEQUENCE ledger_id_seq RESTART WITH 2;",
+		"ALTER SEQUENCE ledger_allocation_id_seq RESTART WITH 2;",
+	)
+
+	s := Service{store: store.New(seeder.Conn), dispatch: &mockDispatch{}, tx: seeder.Conn}
+
+	suite.T().Run("same client", func(t *testing.T) {
+		err := s.AddCreditTransfer(ctx, 1, shared.AddCreditTransfer{ToCourtRef: "11111111", Amount: 6000, TransferNotes: "note"})
+		assert.Equal(t, apierror.ValidationError{Errors: apierror.ValidationErrors{
+			"ToCourtRef": {"same-client": "Credit cannot be transferred to the same client"},
+		}}, err)
+	})
+
+	suite.T().Run("unknown court reference", func(t *testing.T) {
+		err := s.AddCreditTransfer(ctx, 1, shared.AddCreditTransfer{ToCourtRef: "99999999", Amount: 6000, TransferNotes: "note"})
+		assert.Equal(t, apierror.ValidationError{Errors: apierror.ValidationErrors{
+			"ToCourtRef": {"not-found": "Could not find a client with this court reference"},
+		}}, err)
+	})
+
+	suite.T().Run("more than the available credit", func(t *testing.T) {
+		err := s.AddCreditTransfer(ctx, 1, shared.AddCreditTransfer{ToCourtRef: "22222222", Amount: 10001, TransferNotes: "note"})
+		assert.Equal(t, apierror.ValidationError{Errors: apierror.ValidationErrors{
+			"Amount": {"credit-available": "The amount is more than the credit available to transfer"},
+		}}, err)
+	})
+
+	err := s.AddCreditTransfer(ctx, 1, shared.AddCreditTransfer{ToCourtRef: "22222222", Amount: 6000, TransferNotes: "Paid to the wrong client"})
+	assert.NoError(suite.T(), err)
+
+	transfers, err := s.GetPendingCreditTransfers(ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), transfers, 1)
+	assert.Equal(suite.T(), "11111111", transfers[0].FromCourtRef)
+	assert.Equal(suite.T(), "22222222", transfers[0].ToCourtRef)
+	assert.Equal(suite.T(), 6000, transfers[0].Amount)
+	assert.Equal(suite.T(), 10, transfers[0].CreatedBy)
+
+	id := int32(transfers[0].ID)
+
+	suite.T().Run("approved by the creator", func(t *testing.T) {
+		err := s.UpdateCreditTransferDecision(ctx, id, shared.AdjustmentStatusApproved)
+		var e *apierror.BadRequest
+		assert.ErrorAs(t, err, &e)
+	})
+
+	suite.T().Run("rejected by the creator", func(t *testing.T) {
+		err := s.UpdateCreditTransferDecision(ctx, id, shared.AdjustmentStatusRejected)
+		var e *apierror.BadRequest
+		assert.ErrorAs(t, err, &e)
+		assert.Equal(t, "This credit transfer cannot be rejected by the user who created it", e.Reason)
+	})
+
+	approver := auth.Context{
+		Context: ctx.(auth.Context).Context,
+		User:    &shared.User{ID: 20, Roles: []string{shared.RoleFinanceManager}},
+	}
+
+	err = s.UpdateCreditTransferDecision(approver, id, shared.AdjustmentStatusApproved)
+	assert.NoError(suite.T(), err)
+
+	fromCredit, _ := s.store.GetRefundAmount(ctx, 1)
+	assert.Equal(suite.T(), int32(4000), fromCredit)
+
+	// the transferred credit pays the receiving client's invoice, leaving the rest on account
+	toCredit, _ := s.store.GetRefundAmount(ctx, 2)
+	assert.Equal(suite.T(), int32(2000), toCredit)
+
+	var ledgerTypes []string
+	rows, _ := seeder.Query(ctx, "SELECT type FROM ledger WHERE id IN (SELECT from_ledger_id FROM credit_transfer UNION SELECT to_ledger_id FROM credit_transfer)")
+	for rows.Next() {
+		var ledgerType string
+		_ = rows.Scan(&ledgerType)
+		ledgerTypes = append(ledgerTypes, ledgerType)
+	}
+	assert.Equal(suite.T(), []string{"CREDIT TRANSFER", "CREDIT TRANSFER"}, ledgerTypes)
+
+	transfers, _ = s.GetPendingCreditTransfers(ctx)
+	assert.Empty(suite.T(), transfers)
+
+	suite.T().Run("already decided", func(t *testing.T) {
+		err := s.UpdateCreditTransferDecision(approver, id, shared.AdjustmentStatusRejected)
+		assert.ErrorAs(t, err, &apierror.NotFound{})
+	})
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/auth"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+// checkFourEyes prevents a user approving or rejecting a record they created, as a rejection is as much a decision on
+// the record as an approval. Users with the decision override role may do so, and each override is recorded in the
+// audit log as part of the deciding transaction. The decision is given as it reads in a sentence, e.g. "approved".
+func (s *Service) checkFourEyes(ctx context.Context, tx *store.Tx, record string, decision string, clientId int32, entityType string, id int32, createdBy int32) error {
+	user := ctx.(auth.Context).User
+	if user.ID != createdBy {
+		return nil
+	}
+
+	if !user.IsFinanceDecisionOverride() {
+		return apierror.BadRequestError("createdBy", fmt.Sprintf("This %s cannot be %s by the user who created it", record, decision), nil)
+	}
+
+	s.Logger(ctx).Info(fmt.Sprintf("User %d %s %s %d that they created using the decision override role", user.ID, decision, record, id))
+
+	return s.audit(ctx, tx, auditEntry{
+		clientId:   clientId,
+		action:     shared.AuditActionDecisionOverride,
+		entityType: entityType,
+		entityId:   id,
+		after:      map[string]string{"createdBy": strconv.Itoa(int(createdBy)), decision + "By": strconv.Itoa(int(user.ID))},
+	})
+}
+
+// decisionVerb returns how an approval or rejection reads in the four eyes messages
+func decisionVerb(approved bool) string {
+	if approved {
+		return "approved"
+	}
+	return "rejected"
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ministryofjustice/opg-go-common/telemetry"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/auth"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_checkFourEyes(t *testing.T) {
+	tests := []struct {
+		name      string
+		roles     []string
+		decision  string
+		createdBy int32
+		wantErr   string
+	}{
+		{name: "approved by another user", roles: []string{shared.RoleFinanceManager}, decision: "approved", createdBy: 2},
+		{name: "approved by creator", roles: []string{shared.RoleFinanceManager}, decision: "approved", createdBy: 1, wantErr: "This refund cannot be approved by the user who created it"},
+		{name: "rejected by another user", roles: []string{shared.RoleFinanceManager}, decision: "rejected", createdBy: 2},
+		{name: "rejected by creator", roles: []string{shared.RoleFinanceManager}, decision: "rejected", createdBy: 1, wantErr: "This refund cannot be rejected by the user who created it"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := auth.Context{
+				Context: telemetry.ContextWithLogger(context.Background(), telemetry.NewLogger("finance-api-test")),
+				User:    &shared.User{ID: 1, Roles: tt.roles},
+			}
+
+			s := &Service{}
+			err := s.checkFourEyes(ctx, nil, "refund", tt.decision, 1, shared.AuditEntityRefund, 5, tt.createdBy)
+
+			if tt.wantErr != "" {
+				var e *apierror.BadRequest
+				assert.ErrorAs(t, err, &e)
+				assert.Equal(t, tt.wantErr, e.Reason)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
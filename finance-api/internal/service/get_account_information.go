@@ -27,16 +27,44 @@ func (s *Service) GetAccountInformation(ctx context.Context, id int32) (*shared.
 
 
 func (s *Service) UpdatePaymentMethod(ctx context.Context, id int32, paymentMethod shared.PaymentMethod) error {
-	err := s.store.UpdatePaymentMethod(ctx, store.UpdatePaymentMethodParams{
+	tx, err := s.BeginStoreTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	current, err := tx.GetPaymentMethod(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = tx.UpdatePaymentMethod(ctx, store.UpdatePaymentMethodParams{
 		PaymentMethod: paymentMethod.Key(),
 		ClientID:      id,
 	})
+	if err != nil {
+		return err
+	}
 
-	if err == nil {
-		return s.dispatch.PaymentMethodChanged(ctx, event.PaymentMethod{
-			ClientID:      int(id),
-			PaymentMethod: paymentMethod,
-		})
+	err = s.audit(ctx, tx, auditEntry{
+		clientId:   id,
+		action:     shared.AuditActionPaymentMethodChanged,
+		entityType: shared.AuditEntityFinanceClient,
+		entityId:   id,
+		before:     map[string]string{"paymentMethod": current},
+		after:      map[string]string{"paymentMethod": paymentMethod.Key()},
+	})
+	if err != nil {
+		return err
 	}
-	return err
+
+	err = s.outbox(tx).PaymentMethodChanged(ctx, event.PaymentMethod{
+		ClientID:      int(id),
+		PaymentMethod: paymentMethod,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
@@ -42,6 +42,7 @@ func newInvoiceBuilder(invoices []store.GetInvoicesRow) *invoiceBuilder {
 				Status:             "Unpaid",
 				Received:           int(inv.Received),
 				OutstandingBalance: int(inv.Amount) - int(inv.Received),
+				Voided:             inv.Voided,
 			},
 			feeReductionType: inv.FeeReductionType,
 		}
@@ -64,7 +65,9 @@ func (ib *invoiceBuilder) Build() shared.Invoices {
 		inv := ib.invoices[key]
 		invoice := inv.invoice
 		var status string
-		if invoice.OutstandingBalance > 0 {
+		if invoice.Voided {
+			status = "Void"
+		} else if invoice.OutstandingBalance > 0 {
 			status = "Unpaid"
 		} else if invoice.OutstandingBalance < 0 {
 			status = "Overpaid"
@@ -80,7 +83,7 @@ func (ib *invoiceBuilder) Build() shared.Invoices {
 			inv.contextType = cases.Title(language.English).String(inv.feeReductionType)
 		}
 
-		if inv.contextType != "" {
+		if inv.contextType != "" && !invoice.Voided {
 			status = fmt.Sprintf("%s - %s", status, inv.contextType)
 		}
 
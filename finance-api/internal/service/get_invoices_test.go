@@ -330,6 +330,36 @@ func Test_invoiceBuilder_statuses(t *testing.T) {
 	}
 }
 
+func Test_invoiceBuilder_voided(t *testing.T) {
+	ib := newInvoiceBuilder([]store.GetInvoicesRow{
+		{
+			ID:               1,
+			Amount:           32000,
+			Received:         32000,
+			FeeReductionType: "REMISSION",
+			Voided:           true,
+		},
+	})
+	ib.addLedgerAllocations([]store.GetLedgerAllocationsRow{
+		{
+			InvoiceID: pgtype.Int4{Int32: 1, Valid: true},
+			Amount:    16000,
+			Type:      "REMISSION",
+			Status:    "ALLOCATED",
+		},
+		{
+			InvoiceID: pgtype.Int4{Int32: 1, Valid: true},
+			Amount:    16000,
+			Type:      "INVOICE VOID",
+			Status:    "ALLOCATED",
+		},
+	})
+	invoices := ib.Build()
+	assert.Equal(t, "Void", invoices[0].Status)
+	assert.True(t, invoices[0].Voided)
+	assert.Equal(t, 0, invoices[0].OutstandingBalance)
+}
+
 func Test_invoiceBuilder_OrdersByIndex(t *testing.T) {
 	ib2 := &invoiceBuilder{
 		invoices:            make(map[int]*invoiceMetadata),
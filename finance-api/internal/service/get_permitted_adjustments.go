@@ -14,6 +14,10 @@ func (s *Service) GetPermittedAdjustments(ctx context.Context, invoiceId int32)
 		return nil, err
 	}
 
+	if balance.Voided {
+		return nil, nil
+	}
+
 	var permitted []shared.AdjustmentType
 	if balance.WriteOffAmount == 0 {
 		permitted = append(permitted, shared.AdjustmentTypeCreditMemo)
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+func (s *Service) GetUploadJobs(ctx context.Context) (shared.UploadJobs, error) {
+	data, err := s.store.GetUploadJobs(ctx)
+	if err != nil {
+		s.Logger(ctx).Error("unable to get upload jobs", "error", err)
+		return nil, err
+	}
+
+	jobs := shared.UploadJobs{}
+	for _, job := range data {
+		j, err := transformUploadJob(job)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, j)
+	}
+
+	return jobs, nil
+}
+
+func (s *Service) GetUploadJob(ctx context.Context, id int32) (*shared.UploadJob, error) {
+	data, err := s.store.GetUploadJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := transformUploadJob(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func transformUploadJob(job store.UploadJob) (shared.UploadJob, error) {
+	j := shared.UploadJob{
+		ID:                 int(job.ID),
+		UploadType:         shared.ParseUploadType(job.UploadType),
+		Filename:           job.Filename,
+		FileHash:           job.FileHash,
+		Status:             job.Status,
+		LineCount:          shared.Nillable[int]{Value: int(job.LineCount.Int32), Valid: job.LineCount.Valid},
+		FailedLineCount:    shared.Nillable[int]{Value: int(job.FailedLineCount.Int32), Valid: job.FailedLineCount.Valid},
+		Error:              job.Error.String,
+		StartedAt:          job.StartedAt.Time,
+		EndedAt:            shared.Nillable[time.Time]{Value: job.EndedAt.Time, Valid: job.EndedAt.Valid},
+		CreatedBy:          int(job.CreatedBy),
+		UploadDate:         shared.TransformNillablePgDate(job.UploadDate),
+		EmailAddress:       job.EmailAddress.String,
+		ProcessedLineCount: shared.Nillable[int]{Value: int(job.ProcessedLineCount.Int32), Valid: job.ProcessedLineCount.Valid},
+	}
+
+	if job.FailedLines != nil {
+		err := json.Unmarshal(job.FailedLines, &j.FailedLines)
+		if err != nil {
+			return j, err
+		}
+	}
+
+	return j, nil
+}
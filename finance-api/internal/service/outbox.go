@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/event"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+// outboxBatchSize is the maximum number of events read from the outbox on each relay run
+const outboxBatchSize = 500
+
+// outboxMaxAttempts is the number of times an event is sent before it is dead-lettered. With the retry delay capped at
+// an hour, this covers an event bus outage of around eight hours.
+const outboxMaxAttempts = 20
+
+type outboxWriter interface {
+	CreateOutboxEvent(ctx context.Context, arg store.CreateOutboxEventParams) error
+}
+
+// outboxDispatch writes events to the outbox rather than sending them. When written through a transaction, an event is
+// only published if the change it describes is committed.
+type outboxDispatch struct {
+	writer outboxWriter
+}
+
+// outbox returns a Dispatch that queues events in the outbox, to be published by RelayOutbox
+func (s *Service) outbox(writer outboxWriter) Dispatch {
+	return &outboxDispatch{writer: writer}
+}
+
+func (o *outboxDispatch) CreditOnAccount(ctx context.Context, e event.CreditOnAccount) error {
+	return o.add(ctx, event.DetailTypeCreditOnAccount, clientOrderingKey(e.ClientID), e)
+}
+
+func (o *outboxDispatch) PaymentMethodChanged(ctx context.Context, e event.PaymentMethod) error {
+	return o.add(ctx, event.DetailTypePaymentMethodChanged, clientOrderingKey(e.ClientID), e)
+}
+
+func (o *outboxDispatch) DirectDebitScheduleFailed(ctx context.Context, e event.DirectDebitScheduleFailed) error {
+	return o.add(ctx, event.DetailTypeDirectDebitScheduleFailed, clientOrderingKey(e.ClientID), e)
+}
+
+func (o *outboxDispatch) RefundAdded(ctx context.Context, e event.RefundAdded) error {
+	return o.add(ctx, event.DetailTypeRefundAdded, clientOrderingKey(int(e.ClientID)), e)
+}
+
+func (o *outboxDispatch) DirectDebitCollection(ctx context.Context, e event.DirectDebitCollection) error {
+	return o.add(ctx, event.DetailTypeDirectDebitCollection, clientOrderingKey(int(e.ClientID)), e)
+}
+
+func (o *outboxDispatch) DirectDebitCollectionFailed(ctx context.Context, e event.DirectDebitCollectionFailed) error {
+	return o.add(ctx, event.DetailTypeDirectDebitCollectionFailed, clientOrderingKey(e.ClientID), e)
+}
+
+func (o *outboxDispatch) PendingInvoiceAdjustment(ctx context.Context, e event.PendingInvoiceAdjustment) error {
+	return o.add(ctx, event.DetailTypePendingInvoiceAdjustment, clientOrderingKey(e.ClientID), e)
+}
+
+func (o *outboxDispatch) ScheduleToRemove(ctx context.Context, e event.ScheduleToRemove) error {
+	return o.add(ctx, shared.DetailTypeScheduleToRemove, clientOrderingKey(int(e.ClientID)), e)
+}
+
+func (o *outboxDispatch) RefundReset(ctx context.Context, e event.RefundReset) error {
+	return o.add(ctx, event.DetailTypeRefundReset, clientOrderingKey(int(e.ClientID)), e)
+}
+
+func (o *outboxDispatch) add(ctx context.Context, eventType string, orderingKey string, detail any) error {
+	v, err := json.Marshal(detail)
+	if err != nil {
+		return err
+	}
+
+	return o.writer.CreateOutboxEvent(ctx, store.CreateOutboxEventParams{
+		OrderingKey: orderingKey,
+		EventType:   eventType,
+		Detail:      v,
+	})
+}
+
+func clientOrderingKey(clientID int) string {
+	return "client-" + strconv.Itoa(clientID)
+}
+
+// RelayOutbox publishes unpublished events from the outbox, oldest first. Delivery is at least once: an event is only
+// marked as published after it has been sent, so a failure to record that will see it sent again. If an event fails, it
+// is retried on a later run with an increasing delay, and any later events with the same ordering key are held back
+// until it succeeds so that each client's events arrive in order. An event that still fails after outboxMaxAttempts is
+// dead-lettered, which releases the events behind it, and must be followed up from GetDeadLetteredOutboxEvents.
+//
+// Only one relay can claim events at a time. The claimed events are leased, so that another relay treats them as not yet
+// due, and the claim is committed before any are sent so that no transaction is held open while publishing. The outcome
+// of each event is then recorded as soon as it is known.
+func (s *Service) RelayOutbox(ctx context.Context) error {
+	events, err := s.claimOutboxEvents(ctx)
+	if err != nil {
+		return err
+	}
+
+	var released []int32
+	held := make(map[string]bool)
+	for _, e := range events {
+		if held[e.OrderingKey] {
+			released = append(released, e.ID)
+			continue
+		}
+
+		err = s.publishOutboxEvent(ctx, e)
+		if err != nil {
+			s.Logger(ctx).Error(fmt.Sprintf("Error publishing %s event %d", e.EventType, e.ID), slog.String("err", err.Error()), slog.Int("attempts", int(e.Attempts)+1))
+			held[e.OrderingKey] = true
+
+			var lastError pgtype.Text
+			_ = lastError.Scan(err.Error())
+
+			var deadLettered bool
+			deadLettered, err = s.store.MarkOutboxEventFailed(ctx, store.MarkOutboxEventFailedParams{LastError: lastError, MaxAttempts: outboxMaxAttempts, ID: e.ID})
+			if deadLettered {
+				s.Logger(ctx).Error(fmt.Sprintf("%s event %d has been dead-lettered after %d attempts", e.EventType, e.ID, outboxMaxAttempts))
+			}
+		} else {
+			err = s.store.MarkOutboxEventPublished(ctx, e.ID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// events held back behind a failure are released from their lease, to be sent once the failed event succeeds
+	if len(released) > 0 {
+		return s.store.ReleaseOutboxEvents(ctx, released)
+	}
+
+	return nil
+}
+
+// claimOutboxEvents returns the events that are due to be sent, in order, leaving out any held back behind an earlier
+// event for the same ordering key. Nothing is returned if another relay is claiming events.
+func (s *Service) claimOutboxEvents(ctx context.Context) ([]store.GetUnpublishedOutboxEventsRow, error) {
+	tx, err := s.BeginStoreTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	locked, err := tx.LockOutboxRelay(ctx)
+	if err != nil || !locked {
+		return nil, err
+	}
+
+	events, err := tx.GetUnpublishedOutboxEvents(ctx, outboxBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		claimed []store.GetUnpublishedOutboxEventsRow
+		ids     []int32
+	)
+	held := make(map[string]bool)
+	for _, e := range events {
+		if held[e.OrderingKey] {
+			continue
+		}
+		if !e.Due {
+			held[e.OrderingKey] = true
+			continue
+		}
+		claimed = append(claimed, e)
+		ids = append(ids, e.ID)
+	}
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	err = tx.LeaseOutboxEvents(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return claimed, tx.Commit(ctx)
+}
+
+// GetDeadLetteredOutboxEvents returns the events that could not be published after the maximum number of attempts
+func (s *Service) GetDeadLetteredOutboxEvents(ctx context.Context) (shared.OutboxEvents, error) {
+	rows, err := s.store.GetDeadLetteredOutboxEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	events := shared.OutboxEvents{}
+	for _, row := range rows {
+		events = append(events, shared.OutboxEvent{
+			ID:             int(row.ID),
+			EventType:      row.EventType,
+			OrderingKey:    row.OrderingKey,
+			Attempts:       int(row.Attempts),
+			Error:          row.LastError.String,
+			CreatedAt:      row.CreatedAt.Time,
+			DeadLetteredAt: row.DeadLetteredAt.Time,
+		})
+	}
+
+	return events, nil
+}
+
+// StartOutboxRelay runs RelayOutbox at the given interval until the context is cancelled
+func (s *Service) StartOutboxRelay(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.RelayOutbox(ctx); err != nil {
+				s.Logger(ctx).Error("Error relaying outbox events", slog.String("err", err.Error()))
+			}
+		}
+	}
+}
+
+func (s *Service) publishOutboxEvent(ctx context.Context, e store.GetUnpublishedOutboxEventsRow) error {
+	switch e.EventType {
+	case event.DetailTypeCreditOnAccount:
+		return publish(ctx, e.Detail, s.dispatch.CreditOnAccount)
+	case event.DetailTypePaymentMethodChanged:
+		return publish(ctx, e.Detail, s.dispatch.PaymentMethodChanged)
+	case event.DetailTypeDirectDebitScheduleFailed:
+		return publish(ctx, e.Detail, s.dispatch.DirectDebitScheduleFailed)
+	case event.DetailTypeRefundAdded:
+		return publish(ctx, e.Detail, s.dispatch.RefundAdded)
+	case event.DetailTypeDirectDebitCollection:
+		return publish(ctx, e.Detail, s.dispatch.DirectDebitCollection)
+	case event.DetailTypeDirectDebitCollectionFailed:
+		return publish(ctx, e.Detail, s.dispatch.DirectDebitCollectionFailed)
+	case event.DetailTypePendingInvoiceAdjustment:
+		return publish(ctx, e.Detail, s.dispatch.PendingInvoiceAdjustment)
+	case shared.DetailTypeScheduleToRemove:
+		return publish(ctx, e.Detail, s.dispatch.ScheduleToRemove)
+	case event.DetailTypeRefundReset:
+		return publish(ctx, e.Detail, s.dispatch.RefundReset)
+	default:
+		return fmt.Errorf("unknown outbox event type: %s", e.EventType)
+	}
+}
+
+func publish[T any](ctx context.Context, detail []byte, send func(context.Context, T) error) error {
+	var e T
+	if err := json.Unmarshal(detail, &e); err != nil {
+		return err
+	}
+	return send(ctx, e)
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/event"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationSuite) TestService_RelayOutbox() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	dispatch := &mockDispatch{errs: map[string]error{"CreditOnAccount": errors.New("event bus unavailable")}}
+	s := Service{store: store.New(seeder.Conn), dispatch: dispatch, tx: seeder.Conn}
+
+	outbox := s.outbox(s.store)
+	_ = outbox.CreditOnAccount(ctx, event.CreditOnAccount{ClientID: 1, CreditRemaining: 500})
+	_ = outbox.RefundReset(ctx, event.RefundReset{ClientID: 1})
+	_ = outbox.RefundAdded(ctx, event.RefundAdded{ClientID: 2})
+
+	err := s.RelayOutbox(ctx)
+	assert.NoError(suite.T(), err)
+
+	// the refund reset is held back behind the failed event for the same client
+	assert.Equal(suite.T(), []string{"CreditOnAccount", "RefundAdded"}, dispatch.called)
+
+	var (
+		attempts    int
+		lastError   pgtype.Text
+		publishedAt pgtype.Timestamp
+	)
+	_ = seeder.QueryRow(ctx, "SELECT attempts, last_error, published_at FROM outbox_event WHERE event_type = $1", event.DetailTypeCreditOnAccount).Scan(&attempts, &lastError, &publishedAt)
+	assert.Equal(suite.T(), 1, attempts)
+	assert.Equal(suite.T(), "event bus unavailable", lastError.String)
+	assert.False(suite.T(), publishedAt.Valid)
+
+	var published int
+	_ = seeder.QueryRow(ctx, "SELECT COUNT(*) FROM outbox_event WHERE published_at IS NOT NULL").Scan(&published)
+	assert.Equal(suite.T(), 1, published)
+
+	// the failed event is not retried until it is due
+	dispatch.called = nil
+	err = s.RelayOutbox(ctx)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), dispatch.called)
+
+	dispatch.called = nil
+	dispatch.errs = nil
+	_, _ = seeder.Exec(ctx, "UPDATE outbox_event SET next_attempt_at = NOW() WHERE event_type = $1", event.DetailTypeCreditOnAccount)
+
+	err = s.RelayOutbox(ctx)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"CreditOnAccount", "RefundReset"}, dispatch.called)
+	assert.Equal(suite.T(), event.RefundReset{ClientID: 1}, dispatch.event)
+
+	_ = seeder.QueryRow(ctx, "SELECT attempts, last_error, published_at FROM outbox_event WHERE event_type = $1", event.DetailTypeCreditOnAccount).Scan(&attempts, &lastError, &publishedAt)
+	assert.Equal(suite.T(), 2, attempts)
+	assert.False(suite.T(), lastError.Valid)
+	assert.True(suite.T(), publishedAt.Valid)
+}
+
+func (suite *IntegrationSuite) TestService_RelayOutbox_deadLetter() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	dispatch := &mockDispatch{errs: map[string]error{"CreditOnAccount": errors.New("event bus unavailable")}}
+	s := Service{store: store.New(seeder.Conn), dispatch: dispatch, tx: seeder.Conn}
+
+	outbox := s.outbox(s.store)
+	_ = outbox.CreditOnAccount(ctx, event.CreditOnAccount{ClientID: 1, CreditRemaining: 500})
+	_ = outbox.RefundReset(ctx, event.RefundReset{ClientID: 1})
+
+	// the event is on its final attempt
+	_, _ = seeder.Exec(ctx, "UPDATE outbox_event SET attempts = $1 WHERE event_type = $2", outboxMaxAttempts-1, event.DetailTypeCreditOnAccount)
+
+	err := s.RelayOutbox(ctx)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"CreditOnAccount"}, dispatch.called)
+
+	deadLettered, err := s.GetDeadLetteredOutboxEvents(ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), deadLettered, 1)
+	assert.Equal(suite.T(), event.DetailTypeCreditOnAccount, deadLettered[0].EventType)
+	assert.Equal(suite.T(), "client-1", deadLettered[0].OrderingKey)
+	assert.Equal(suite.T(), outboxMaxAttempts, deadLettered[0].Attempts)
+	assert.Equal(suite.T(), "event bus unavailable", deadLettered[0].Error)
+
+	// the dead-lettered event is not retried, and no longer holds back the client's later events
+	dispatch.called = nil
+	err = s.RelayOutbox(ctx)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"RefundReset"}, dispatch.called)
+}
+
+func (suite *IntegrationSuite) TestService_outbox_rolledBack() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	dispatch := &mockDispatch{}
+	s := Service{store: store.New(seeder.Conn), dispatch: dispatch, tx: seeder.Conn}
+
+	tx, err := s.BeginStoreTx(ctx)
+	assert.NoError(suite.T(), err)
+
+	err = s.outbox(tx).RefundAdded(ctx, event.RefundAdded{ClientID: 1})
+	assert.NoError(suite.T(), err)
+	tx.Rollback(ctx)
+
+	err = s.RelayOutbox(ctx)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), dispatch.called)
+}
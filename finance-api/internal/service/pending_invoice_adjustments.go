@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+type PendingInvoiceAdjustmentsFilter struct {
+	AdjustmentType shared.AdjustmentType
+	CreatedBy      int32
+	OlderThanDays  int
+}
+
+// GetPendingInvoiceAdjustments returns the pending adjustments across all clients, oldest first
+func (s *Service) GetPendingInvoiceAdjustments(ctx context.Context, filter PendingInvoiceAdjustmentsFilter) (shared.PendingInvoiceAdjustments, error) {
+	params := store.GetPendingInvoiceAdjustmentsParams{
+		AdjustmentType: pgtype.Text{String: filter.AdjustmentType.Key(), Valid: filter.AdjustmentType.Valid()},
+		CreatedBy:      pgtype.Int4{Int32: filter.CreatedBy, Valid: filter.CreatedBy != 0},
+	}
+	if filter.OlderThanDays > 0 {
+		_ = params.RaisedBefore.Scan(time.Now().AddDate(0, 0, -filter.OlderThanDays))
+	}
+
+	data, err := s.store.GetPendingInvoiceAdjustments(ctx, params)
+	if err != nil {
+		s.Logger(ctx).Error("Error get pending invoice adjustments", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	adjustments := shared.PendingInvoiceAdjustments{}
+	for _, ia := range data {
+		adjustments = append(adjustments, shared.PendingInvoiceAdjustment{
+			Id:             int(ia.ID),
+			ClientId:       int(ia.ClientID),
+			CourtRef:       ia.CourtRef.String,
+			InvoiceRef:     ia.InvoiceRef,
+			RaisedDate:     shared.Date{Time: ia.RaisedDate.Time},
+			AdjustmentType: shared.ParseAdjustmentType(ia.AdjustmentType),
+			Amount:         int(ia.Amount),
+			Notes:          ia.Notes,
+			CreatedBy:      int(ia.CreatedBy),
+		})
+	}
+
+	return adjustments, nil
+}
+
+// UpdatePendingInvoiceAdjustments approves or rejects each adjustment in its own transaction, so that a failure only
+// affects that adjustment. The outcome of each is returned in the order given.
+func (s *Service) UpdatePendingInvoiceAdjustments(ctx context.Context, adjustments []shared.ClientInvoiceAdjustment, status shared.AdjustmentStatus) shared.InvoiceAdjustmentDecisions {
+	decisions := shared.InvoiceAdjustmentDecisions{}
+	for _, adjustment := range adjustments {
+		decision := shared.InvoiceAdjustmentDecision{ClientInvoiceAdjustment: adjustment}
+
+		err := s.UpdatePendingInvoiceAdjustment(ctx, int32(adjustment.ClientId), int32(adjustment.AdjustmentId), status)
+		if err != nil {
+			s.Logger(ctx).Error(fmt.Sprintf("Error updating invoice adjustment %d for client %d", adjustment.AdjustmentId, adjustment.ClientId), slog.String("err", err.Error()))
+			decision.Error = decisionError(err, "Unable to update the adjustment")
+		}
+
+		decisions = append(decisions, decision)
+	}
+
+	return decisions
+}
+
+// decisionError returns the reason for a bad request, which is safe to show to the user, or the fallback message for
+// any other error
+func decisionError(err error, fallback string) string {
+	var e *apierror.BadRequest
+	if errors.As(err, &e) {
+		return e.Reason
+	}
+	return fallback
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationSuite) TestService_GetPendingInvoiceAdjustments() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	seeder.SeedData(
+		"INSERT INTO finance_client VALUES (1, 1, '1234', 'DEMANDED', NULL, '12345678');",
+		"INSERT INTO invoice VALUES (1, 1, 1, 'S2', 'S200001/19', '2019-04-01', '2020-03-31', 12300, NULL, '2020-03-20',1, '2020-03-16', 10, NULL, NULL, '2019-06-06', NULL);",
+		"INSERT INTO invoice_adjustment VALUES (1, 1, 1, '2020-01-01', 'CREDIT WRITE OFF', 12300, 'old write off', 'PENDING', '2020-01-01', 1)",
+		"INSERT INTO invoice_adjustment VALUES (2, 1, 1, NOW(), 'CREDIT MEMO', 5000, 'new credit', 'PENDING', NOW(), 2)",
+		"INSERT INTO invoice_adjustment VALUES (3, 1, 1, '2020-01-01', 'CREDIT MEMO', 5000, 'approved credit', 'APPROVED', '2020-01-01', 1)",
+
+		"INSERT INTO finance_client VALUES (2, 2, '1235', 'DEMANDED', NULL, '87654321');",
+		"INSERT INTO invoice VALUES (2, 2, 2, 'S2', 'S200002/19', '2019-04-01', '2020-03-31', 12300, NULL, '2020-03-20',1, '2020-03-16', 10, NULL, NULL, '2019-06-06', NULL);",
+		"INSERT INTO invoice_adjustment VALUES (4, 2, 2, '2021-01-01', 'CREDIT WRITE OFF', 12300, 'other client', 'PENDING', '2021-01-01', 2)",
+	)
+
+	s := Service{store: store.New(seeder.Conn), tx: seeder.Conn}
+
+	all, err := s.GetPendingInvoiceAdjustments(ctx, PendingInvoiceAdjustmentsFilter{})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), shared.PendingInvoiceAdjustment{
+		Id:             1,
+		ClientId:       1,
+		CourtRef:       "12345678",
+		InvoiceRef:     "S200001/19",
+		RaisedDate:     shared.NewDate("2020-01-01"),
+		AdjustmentType: shared.AdjustmentTypeWriteOff,
+		Amount:         12300,
+		Notes:          "old write off",
+		CreatedBy:      1,
+	}, all[0])
+	assert.Equal(suite.T(), []int{1, 4, 2}, pendingAdjustmentIds(all))
+
+	writeOffs, err := s.GetPendingInvoiceAdjustments(ctx, PendingInvoiceAdjustmentsFilter{AdjustmentType: shared.AdjustmentTypeWriteOff})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []int{1, 4}, pendingAdjustmentIds(writeOffs))
+
+	byCreator, err := s.GetPendingInvoiceAdjustments(ctx, PendingInvoiceAdjustmentsFilter{CreatedBy: 2})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []int{4, 2}, pendingAdjustmentIds(byCreator))
+
+	old, err := s.GetPendingInvoiceAdjustments(ctx, PendingInvoiceAdjustmentsFilter{OlderThanDays: 30})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []int{1, 4}, pendingAdjustmentIds(old))
+}
+
+func (suite *IntegrationSuite) TestService_UpdatePendingInvoiceAdjustments() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	seeder.SeedData(
+		"INSERT INTO finance_client VALUES (1, 1, '1234', 'DEMANDED', NULL);",
+		"INSERT INTO invoice VALUES (1, 1, 1, 'S2', 'S200001/19', '2019-04-01', '2020-03-31', 12300, NULL, '2020-03-20',1, '2020-03-16', 10, NULL, NULL, '2019-06-06', NULL);",
+		"INSERT INTO invoice_adjustment VALUES (1, 1, 1, '2024-01-01', 'CREDIT MEMO', 5000, 'approve me', 'PENDING', '2024-01-01', 1)",
+
+		"INSERT INTO finance_client VALUES (2, 2, '1235', 'DEMANDED', NULL);",
+		"INSERT INTO invoice VALUES (2, 2, 2, 'S2', 'S200002/19', '2019-04-01', '2020-03-31', 12300, NULL, '2020-03-20',1, '2020-03-16', 10, NULL, NULL, '2019-06-06', NULL);",
+		"INSERT INTO invoice_adjustment VALUES (2, 2, 2, '2024-01-01', 'CREDIT MEMO', 5000, 'approve me too', 'PENDING', '2024-01-01', 1)",
+	)
+
+	s := Service{store: store.New(seeder.Conn), dispatch: &mockDispatch{}, tx: seeder.Conn}
+
+	adjustments := []shared.ClientInvoiceAdjustment{
+		{ClientId: 1, AdjustmentId: 1},
+		{ClientId: 1, AdjustmentId: 99},
+		{ClientId: 2, AdjustmentId: 2},
+	}
+	decisions := s.UpdatePendingInvoiceAdjustments(ctx, adjustments, shared.AdjustmentStatusApproved)
+
+	assert.Equal(suite.T(), shared.InvoiceAdjustmentDecisions{
+		{ClientInvoiceAdjustment: adjustments[0]},
+		{ClientInvoiceAdjustment: adjustments[1], Error: "Adjustment not found"},
+		{ClientInvoiceAdjustment: adjustments[2]},
+	}, decisions)
+
+	// adjustments that have already been decided, or that belong to another client, are not decided again
+	decided := []shared.ClientInvoiceAdjustment{
+		{ClientId: 1, AdjustmentId: 1},
+		{ClientId: 1, AdjustmentId: 2},
+	}
+	decisions = s.UpdatePendingInvoiceAdjustments(ctx, decided, shared.AdjustmentStatusRejected)
+
+	assert.Equal(suite.T(), shared.InvoiceAdjustmentDecisions{
+		{ClientInvoiceAdjustment: decided[0], Error: "Adjustment not found or is no longer pending"},
+		{ClientInvoiceAdjustment: decided[1], Error: "Adjustment not found or is no longer pending"},
+	}, decisions)
+
+	var approved int
+	_ = seeder.QueryRow(ctx, "SELECT COUNT(*) FROM supervision_finance.invoice_adjustment WHERE status = 'APPROVED' AND ledger_id IS NOT NULL").Scan(&approved)
+	assert.Equal(suite.T(), 2, approved)
+}
+
+func pendingAdjustmentIds(adjustments shared.PendingInvoiceAdjustments) []int {
+	var ids []int
+	for _, adjustment := range adjustments {
+		ids = append(ids, adjustment.Id)
+	}
+	return ids
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+// GetPendingRefunds returns the refunds across all clients that are awaiting a decision or approved but not yet
+// processed, soonest to expire first
+func (s *Service) GetPendingRefunds(ctx context.Context) (shared.PendingRefunds, error) {
+	data, err := s.store.GetPendingRefunds(ctx)
+	if err != nil {
+		s.Logger(ctx).Error("Error get pending refunds", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	refunds := shared.PendingRefunds{}
+	for _, refund := range data {
+		refunds = append(refunds, shared.PendingRefund{
+			ID:              int(refund.ID),
+			ClientId:        int(refund.ClientID),
+			CourtRef:        refund.CourtRef.String,
+			RaisedDate:      shared.Date{Time: refund.RaisedDate.Time},
+			Amount:          int(refund.Amount),
+			Status:          shared.ParseRefundStatus(refund.Status),
+			Notes:           refund.Notes,
+			CreatedBy:       int(refund.CreatedBy),
+			DaysUntilExpiry: int(refund.DaysUntilExpiry),
+		})
+	}
+
+	return refunds, nil
+}
+
+// UpdateRefundDecisions approves or rejects each pending refund in its own transaction, so that a failure only affects
+// that refund. The outcome of each is returned in the order given.
+func (s *Service) UpdateRefundDecisions(ctx context.Context, refunds []shared.ClientRefund, status shared.RefundStatus) shared.RefundDecisions {
+	decisions := shared.RefundDecisions{}
+	for _, refund := range refunds {
+		decision := shared.RefundDecision{ClientRefund: refund}
+
+		err := s.updatePendingRefundDecision(ctx, int32(refund.ClientId), int32(refund.RefundId), status)
+		if err != nil {
+			s.Logger(ctx).Error(fmt.Sprintf("Error updating refund %d for client %d", refund.RefundId, refund.ClientId), slog.String("err", err.Error()))
+			decision.Error = decisionError(err, "Unable to update the refund")
+		}
+
+		decisions = append(decisions, decision)
+	}
+
+	return decisions
+}
+
+func (s *Service) updatePendingRefundDecision(ctx context.Context, clientId int32, refundId int32, status shared.RefundStatus) error {
+	current, err := s.store.GetRefundStatus(ctx, store.GetRefundStatusParams{ClientID: clientId, RefundID: refundId})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apierror.BadRequestError("refundId", "Refund not found", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	if shared.ParseRefundStatus(current) != shared.RefundStatusPending {
+		return apierror.BadRequestError("status", "Refund is no longer pending", nil)
+	}
+
+	return s.UpdateRefundDecision(ctx, clientId, refundId, status)
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"time"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationSuite) TestService_GetPendingRefunds() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	today := time.Now()
+	tenDaysAgo := today.AddDate(0, 0, -10).Format("2006-01-02")
+	twoDaysAgo := today.AddDate(0, 0, -2).Format("2006-01-02")
+
+	seeder.SeedData(
+		"INSERT INTO finance_client VALUES (1, 1, '1234', 'DEMANDED', NULL, '12345678');",
+		"INSERT INTO finance_client VALUES (2, 2, '1235', 'DEMANDED', NULL, '87654321');",
+
+		"INSERT INTO refund VALUES (1, 1, '2025-01-01', 12300, 'PENDING', 'new pending', 99, '"+twoDaysAgo+"')",
+		"INSERT INTO refund VALUES (2, 2, '2025-01-01', 32100, 'APPROVED', 'approved', 99, '"+tenDaysAgo+"', 98, '"+tenDaysAgo+"')",
+		"INSERT INTO refund VALUES (3, 1, '2025-01-01', 32100, 'APPROVED', 'processing', 99, '"+tenDaysAgo+"', 98, '"+tenDaysAgo+"', '"+twoDaysAgo+"')",
+		"INSERT INTO refund VALUES (4, 2, '2025-01-01', 32100, 'REJECTED', 'rejected', 99, '"+tenDaysAgo+"', 98, '"+tenDaysAgo+"')",
+	)
+
+	s := Service{store: store.New(seeder.Conn)}
+
+	refunds, err := s.GetPendingRefunds(ctx)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), shared.PendingRefunds{
+		{
+			ID:              2,
+			ClientId:        2,
+			CourtRef:        "87654321",
+			RaisedDate:      shared.NewDate("2025-01-01"),
+			Amount:          32100,
+			Status:          shared.RefundStatusApproved,
+			Notes:           "approved",
+			CreatedBy:       99,
+			DaysUntilExpiry: 4,
+		},
+		{
+			ID:              1,
+			ClientId:        1,
+			CourtRef:        "12345678",
+			RaisedDate:      shared.NewDate("2025-01-01"),
+			Amount:          12300,
+			Status:          shared.RefundStatusPending,
+			Notes:           "new pending",
+			CreatedBy:       99,
+			DaysUntilExpiry: 12,
+		},
+	}, refunds)
+}
+
+func (suite *IntegrationSuite) TestService_UpdateRefundDecisions() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	seeder.SeedData(
+		"INSERT INTO finance_client VALUES (1, 1, '1234', 'DEMANDED', NULL);",
+		"INSERT INTO finance_client VALUES (2, 2, '1235', 'DEMANDED', NULL);",
+
+		"INSERT INTO refund VALUES (1, 1, '2025-01-01', 12300, 'PENDING', '', 99, NOW())",
+		"INSERT INTO refund VALUES (2, 2, '2025-01-01', 32100, 'PENDING', '', 99, NOW())",
+		"INSERT INTO refund VALUES (3, 2, '2025-01-01', 32100, 'APPROVED', '', 99, NOW(), 98, NOW())",
+
+		"INSERT INTO bank_details VALUES (1, 1, 'Clint Client', '12345678', '11-22-33');",
+		"INSERT INTO bank_details VALUES (2, 2, 'Clint Client', '12345678', '11-22-33');",
+		"INSERT INTO bank_details VALUES (3, 3, 'Clint Client', '12345678', '11-22-33');",
+	)
+
+	s := Service{store: store.New(seeder.Conn), tx: seeder.Conn}
+
+	refunds := []shared.ClientRefund{
+		{ClientId: 1, RefundId: 1},
+		{ClientId: 2, RefundId: 3},
+		{ClientId: 1, RefundId: 2},
+		{ClientId: 2, RefundId: 2},
+	}
+	decisions := s.UpdateRefundDecisions(ctx, refunds, shared.RefundStatusRejected)
+
+	assert.Equal(suite.T(), shared.RefundDecisions{
+		{ClientRefund: refunds[0]},
+		{ClientRefund: refunds[1], Error: "Refund is no longer pending"},
+		{ClientRefund: refunds[2], Error: "Refund not found"},
+		{ClientRefund: refunds[3]},
+	}, decisions)
+
+	var rejected int
+	_ = seeder.QueryRow(ctx, "SELECT COUNT(*) FROM supervision_finance.refund WHERE decision = 'REJECTED'").Scan(&rejected)
+	assert.Equal(suite.T(), 2, rejected)
+
+	var bankDetails int
+	_ = seeder.QueryRow(ctx, "SELECT COUNT(*) FROM supervision_finance.bank_details").Scan(&bankDetails)
+	assert.Equal(suite.T(), 1, bankDetails)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -41,12 +42,17 @@ func (s *Service) reapplyCredit(ctx context.Context, clientID int32, tx *store.T
 	case creditPosition.Credit < 1:
 		return nil
 	case !creditPosition.InvoiceID.Valid:
-		return s.dispatch.CreditOnAccount(ctx, event.CreditOnAccount{
+		return s.outbox(tx).CreditOnAccount(ctx, event.CreditOnAccount{
 			ClientID:        int(clientID),
 			CreditRemaining: int(creditPosition.Credit),
 		})
 	}
 
+	err = s.checkPostingPeriod(ctx, tx, time.Now())
+	if err != nil {
+		return err
+	}
+
 	logger := s.Logger(ctx)
 	logger.Info(fmt.Sprintf("reapplying credit for client %d", clientID))
 
@@ -103,7 +109,7 @@ func (s *Service) resetRefunds(ctx context.Context, clientID int32, tx *store.Tx
 		return err
 	}
 	if len(refunds) > 0 {
-		return s.dispatch.RefundReset(ctx, event.RefundReset{ClientID: clientID})
+		return s.outbox(tx).RefundReset(ctx, event.RefundReset{ClientID: clientID})
 	}
 	return nil
 
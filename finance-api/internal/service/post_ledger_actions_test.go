@@ -54,6 +54,7 @@ func (suite *IntegrationSuite) TestService_reapplyCredit_noInvoices() {
 		ClientID:        1,
 		CreditRemaining: 10000,
 	}
+	assert.NoError(suite.T(), s.RelayOutbox(ctx))
 	assert.Equal(suite.T(), expected, dispatch.event)
 }
 
@@ -108,6 +109,7 @@ func (suite *IntegrationSuite) TestService_reapplyCredit_oldestFirst() {
 	assert.Equal(suite.T(), 3000, amount)
 	assert.Equal(suite.T(), 7000, cachedDebtAmount)
 
+	assert.NoError(suite.T(), s.RelayOutbox(ctx))
 	assert.Nil(suite.T(), dispatch.event)
 }
 
@@ -184,6 +186,7 @@ func (suite *IntegrationSuite) TestService_postLedgerActions_resetsApprovedRefun
 	assert.Equal(suite.T(), 1, count)
 
 	expected := event.RefundReset{ClientID: 1}
+	assert.NoError(suite.T(), s.RelayOutbox(ctx))
 	assert.Equal(suite.T(), expected, dispatch.event)
 	assert.Equal(suite.T(), []string{"RefundReset"}, dispatch.called)
 }
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+// lineProcessedFunc is called after each upload line has been processed, with the IDs of the ledgers created by the line
+type lineProcessedFunc func(index int, ledgerIDs ...int32) error
+
+func (f lineProcessedFunc) call(index int, ledgerIDs ...int32) error {
+	if f == nil {
+		return nil
+	}
+	return f(index, ledgerIDs...)
+}
+
+// PreviewUpload processes the upload in a transaction that is always rolled back, and returns the ledgers, allocations
+// and resulting balances for each line, along with any lines that would fail. Events written to the outbox are rolled
+// back with it, so none are dispatched.
+func (s *Service) PreviewUpload(ctx context.Context, records [][]string, uploadType shared.ReportUploadType, uploadDate shared.Date, pisNumber int) (*shared.UploadPreview, error) {
+	if !uploadType.PostsLedgers() {
+		return nil, apierror.BadRequestError("uploadType", "Preview is not available for this upload type", nil)
+	}
+
+	tx, err := s.BeginStoreTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var lines []shared.UploadPreviewLine
+	onProcessed := func(index int, ledgerIDs ...int32) error {
+		line, err := getPreviewLine(ctx, tx, index, ledgerIDs)
+		if err != nil {
+			return err
+		}
+		lines = append(lines, line)
+		return nil
+	}
+
+	failedLines, err := s.processUploadRecords(ctx, tx, records, nil, uploadType, uploadDate, pisNumber, onProcessed)
+	if err != nil {
+		return nil, err
+	}
+
+	for index, reason := range failedLines {
+		lines = append(lines, shared.UploadPreviewLine{Line: index, Error: reason})
+	}
+
+	slices.SortFunc(lines, func(a, b shared.UploadPreviewLine) int {
+		return a.Line - b.Line
+	})
+
+	return &shared.UploadPreview{UploadType: uploadType, Lines: lines}, nil
+}
+
+func getPreviewLine(ctx context.Context, tx *store.Tx, index int, ledgerIDs []int32) (shared.UploadPreviewLine, error) {
+	line := shared.UploadPreviewLine{Line: index}
+
+	for _, ledgerID := range ledgerIDs {
+		allocations, err := tx.GetLedgerAllocationsForPreview(ctx, ledgerID)
+		if err != nil {
+			return line, err
+		}
+		if len(allocations) == 0 {
+			continue
+		}
+
+		ledger := shared.UploadPreviewLedger{
+			ClientID:   int(allocations[0].ClientID),
+			CourtRef:   allocations[0].CourtRef.String,
+			LedgerType: allocations[0].Type,
+			Amount:     int(allocations[0].LedgerAmount),
+		}
+
+		for _, allocation := range allocations {
+			ledger.Allocations = append(ledger.Allocations, shared.UploadPreviewAllocation{
+				InvoiceReference: allocation.InvoiceReference.String,
+				Amount:           int(allocation.Amount),
+				Status:           allocation.Status,
+			})
+		}
+
+		balance, err := tx.GetAccountInformation(ctx, allocations[0].ClientID)
+		if err != nil {
+			return line, err
+		}
+		ledger.OutstandingBalance = int(balance.Outstanding)
+		ledger.CreditBalance = int(balance.Credit)
+
+		line.Ledgers = append(line.Ledgers, ledger)
+	}
+
+	return line, nil
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/validation"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationSuite) Test_previewUpload() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	seeder.SeedData(
+		"INSERT INTO finance_client VALUES (1, 1, 'preview-1', 'DEMANDED', NULL, '1234');",
+		"INSERT INTO invoice VALUES (1, 1, 1, 'AD', 'AD11223/19', '2023-04-01', '2025-03-31', 10000, NULL, '2024-03-31', 11, '2024-03-31', NULL, NULL, NULL, '2024-03-31 00:00:00', '99');",
+		"ALTER SEQUENCE ledger_id_seq RESTART WITH 1;",
+		"ALTER SEQUENCE ledger_allocation_id_seq RESTART WITH 1;",
+	)
+
+	dispatch := &mockDispatch{}
+	s := Service{store: store.New(seeder.Conn), dispatch: dispatch, tx: seeder.Conn}
+
+	records := [][]string{
+		{"Case number (confirmed on Sirius)", "Cheque number", "Cheque Value (£)", "Comments", "Date in Bank"},
+		{"1234", "11111", "150", "", "01/01/2024"},
+		{"9999", "22222", "100", "", "01/01/2024"},
+	}
+
+	preview, err := s.PreviewUpload(ctx, records, shared.ReportTypeUploadPaymentsSupervisionCheque, shared.NewDate("2024-01-17"), 100)
+	assert.NoError(suite.T(), err)
+
+	assert.Equal(suite.T(), &shared.UploadPreview{
+		UploadType: shared.ReportTypeUploadPaymentsSupervisionCheque,
+		Lines: []shared.UploadPreviewLine{
+			{
+				Line: 1,
+				Ledgers: []shared.UploadPreviewLedger{
+					{
+						ClientID:   1,
+						CourtRef:   "1234",
+						LedgerType: "SUPERVISION CHEQUE PAYMENT",
+						Amount:     15000,
+						Allocations: []shared.UploadPreviewAllocation{
+							{InvoiceReference: "AD11223/19", Amount: 10000, Status: "ALLOCATED"},
+							{Amount: -5000, Status: "UNAPPLIED"},
+						},
+						OutstandingBalance: 0,
+						CreditBalance:      5000,
+					},
+				},
+			},
+			{Line: 2, Error: validation.UploadErrorHeldInSuspense},
+		},
+	}, preview)
+
+	var count int
+	_ = seeder.QueryRow(ctx, "SELECT COUNT(*) FROM ledger").Scan(&count)
+	assert.Equal(suite.T(), 0, count)
+	assert.Nil(suite.T(), dispatch.event)
+}
+
+func (suite *IntegrationSuite) Test_previewUpload_unsupportedType() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	s := Service{store: store.New(seeder.Conn), dispatch: &mockDispatch{}, tx: seeder.Conn}
+
+	_, err := s.PreviewUpload(ctx, [][]string{}, shared.ReportTypeUploadRemoveSchedules, shared.Date{}, 0)
+
+	var expected *apierror.BadRequest
+	assert.ErrorAs(suite.T(), err, &expected)
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/validation"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+const (
+	deputyScheduleDeputyNumber = iota
+	deputyScheduleDeputyName
+	deputyScheduleCourtRef
+	deputyScheduleClientForename
+	deputyScheduleClientSurname
+	deputyScheduleDoNotInvoice
+	deputyScheduleTotalOutstanding
+)
+
+// ProcessDeputySchedule validates each line of a deputy schedule against the deputy and client held in Sirius, applies
+// the "Do not invoice" flag and reconciles the total outstanding against the client's balance. Lines that fail
+// validation or do not reconcile are returned as failed lines; the flags on all other lines are still applied.
+func (s *Service) ProcessDeputySchedule(ctx context.Context, records [][]string) (map[int]string, error) {
+	tx, err := s.BeginStoreTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	failedLines := make(map[int]string)
+
+	for i, record := range records {
+		if isHeaderRow(shared.ReportTypeUploadDeputySchedule, i) || safeRead(record, deputyScheduleCourtRef) == "" {
+			continue
+		}
+
+		failure, err := s.processDeputyScheduleLine(ctx, tx, record)
+		if err != nil {
+			return nil, err
+		}
+		if failure != "" {
+			failedLines[i] = failure
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return failedLines, nil
+}
+
+func (s *Service) processDeputyScheduleLine(ctx context.Context, tx *store.Tx, record []string) (string, error) {
+	var (
+		deputyNumber pgtype.Int4
+		courtRef     pgtype.Text
+	)
+
+	number, err := strconv.Atoi(strings.TrimSpace(safeRead(record, deputyScheduleDeputyNumber)))
+	if err != nil {
+		return validation.UploadErrorDeputyNumberParse, nil
+	}
+	_ = deputyNumber.Scan(int64(number))
+	_ = courtRef.Scan(strings.TrimSpace(safeRead(record, deputyScheduleCourtRef)))
+
+	client, err := tx.GetDeputyScheduleClient(ctx, store.GetDeputyScheduleClientParams{
+		DeputyNumber: deputyNumber,
+		CourtRef:     courtRef,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return validation.UploadErrorClientNotFound, nil
+	} else if err != nil {
+		return "", err
+	}
+
+	if !client.DeputyFound {
+		return validation.UploadErrorDeputyNotFound, nil
+	}
+
+	doNotInvoice, ok := parseYesNo(safeRead(record, deputyScheduleDoNotInvoice))
+	if !ok {
+		return validation.UploadErrorDoNotInvoiceParse, nil
+	}
+
+	totalOutstanding, err := parseAmount(strings.TrimSpace(safeRead(record, deputyScheduleTotalOutstanding)))
+	if err != nil {
+		return validation.UploadErrorAmountParse, nil
+	}
+
+	// the flag is held as a Sirius warning, as that is where Sirius and invoicing read it from
+	if doNotInvoice && !client.DoNotInvoice {
+		err = tx.AddDoNotInvoiceWarning(ctx, client.ClientID)
+	} else if !doNotInvoice && client.DoNotInvoice {
+		err = tx.RemoveDoNotInvoiceWarning(ctx, client.ClientID)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var outstanding int32
+	balance, err := tx.GetAccountInformation(ctx, client.ClientID)
+	if err == nil {
+		outstanding = balance.Outstanding
+	} else if !errors.Is(err, pgx.ErrNoRows) { // clients without a finance record have nothing outstanding
+		return "", err
+	}
+
+	if outstanding != totalOutstanding {
+		return validation.UploadErrorBalanceMismatch, nil
+	}
+
+	return "", nil
+}
+
+func parseYesNo(value string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "yes":
+		return true, true
+	case "no":
+		return false, true
+	}
+	return false, false
+}
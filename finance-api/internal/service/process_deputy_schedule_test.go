@@ -0,0 +1,68 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationSuite) Test_processDeputySchedule() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	seeder.SeedData(
+		"INSERT INTO public.persons (id, firstname, surname, deputynumber, type) VALUES (21, 'Dee', 'Puty', 1001, 'actor_deputy');",
+		"INSERT INTO public.persons (id, firstname, surname, caserecnumber, type) VALUES (11, 'Ian', 'Voice', '11111111', 'actor_client');",
+		"INSERT INTO public.persons (id, firstname, surname, caserecnumber, type) VALUES (12, 'Nora', 'Invoice', '22222222', 'actor_client');",
+		"INSERT INTO public.persons (id, firstname, surname, caserecnumber, type) VALUES (13, 'Oliver', 'Deputy', '33333333', 'actor_client');",
+		"INSERT INTO public.cases (id, client_id) VALUES (31, 11), (32, 12), (33, 13);",
+		"INSERT INTO supervision.order_deputy (id, order_id, deputy_id) VALUES (41, 31, 21), (42, 32, 21);",
+		"INSERT INTO supervision.warnings VALUES (NEXTVAL('supervision.warnings_id_seq'), 'Do not invoice', TRUE, 12);",
+		"INSERT INTO finance_client VALUES (1, 11, '11111111', 'DEMANDED', NULL);",
+		"INSERT INTO invoice VALUES (1, 11, 1, 'S2', 'S203531/19', '2019-04-01', '2020-03-31', 12300, NULL, NULL, NULL, '2019-04-01', NULL, NULL, NULL, '2019-04-01', 1);",
+	)
+
+	records := [][]string{
+		{"Deputy number", "Deputy name", "Case number", "Client forename", "Client surname", "Do not invoice", "Total outstanding"},
+		{"1001", "Dee Puty", "11111111", "Ian", "Voice", "Yes", "123.00"},
+		{"1001", "Dee Puty", "22222222", "Nora", "Invoice", "No", "0"},
+		{"1001", "Dee Puty", "33333333", "Oliver", "Deputy", "No", "0"},        // not this deputy's client
+		{"1001", "Dee Puty", "99999999", "Ned", "Client", "No", "0"},           // client not found
+		{"1001", "Dee Puty", "11111111", "Ian", "Voice", "Maybe", "123.00"},    // do not invoice not parsed
+		{"1001", "Dee Puty", "22222222", "Nora", "Invoice", "No", "50.00"},     // balance mismatch
+		{"ABC", "Dee Puty", "11111111", "Ian", "Voice", "Yes", "123.00"},       // deputy number not parsed
+		{"1001", "Dee Puty", "11111111", "Ian", "Voice", "Yes", "one hundred"}, // amount not parsed
+	}
+
+	s := Service{store: store.New(seeder.Conn), tx: seeder.Conn}
+	failedLines, err := s.ProcessDeputySchedule(ctx, records)
+	assert.NoError(suite.T(), err)
+
+	assert.Equal(suite.T(), map[int]string{
+		3: validation.UploadErrorDeputyNotFound,
+		4: validation.UploadErrorClientNotFound,
+		5: validation.UploadErrorDoNotInvoiceParse,
+		6: validation.UploadErrorBalanceMismatch,
+		7: validation.UploadErrorDeputyNumberParse,
+		8: validation.UploadErrorAmountParse,
+	}, failedLines)
+
+	suite.T().Run("sets the do not invoice warnings", func(t *testing.T) {
+		var flagged []int32
+		rows, err := seeder.Conn.Query(ctx, "SELECT client_id FROM supervision.warnings WHERE warningtype = 'Do not invoice' AND isactive = TRUE ORDER BY client_id")
+		assert.NoError(t, err)
+		for rows.Next() {
+			var clientID int32
+			_ = rows.Scan(&clientID)
+			flagged = append(flagged, clientID)
+		}
+		assert.Equal(t, []int32{11}, flagged)
+
+		client, err := s.store.GetDeputyScheduleClient(ctx, store.GetDeputyScheduleClientParams{CourtRef: pgtype.Text{String: "22222222", Valid: true}})
+		assert.NoError(t, err)
+		assert.False(t, client.DoNotInvoice)
+	})
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/allpay"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/auth"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+// failedCollectionsWindow is the number of working days to look back when polling for failed collections, as BACS can
+// take up to six days to credit the reversed payment (see ADR 31)
+const failedCollectionsWindow = 7
+
+// ProcessFailedDirectDebitCollections fetches failed Direct Debit collections from Allpay for a rolling window ending on
+// the given date and reverses the matching payments. As the window overlaps on each run, and events may be delivered more
+// than once, payments that have already been reversed are skipped.
+func (s *Service) ProcessFailedDirectDebitCollections(ctx context.Context, date time.Time) error {
+	logger := s.Logger(ctx)
+
+	from, err := s.govUK.SubWorkingDays(ctx, date, failedCollectionsWindow)
+	if err != nil {
+		logger.Error("unable to calculate failed collections window", "error", err)
+		return err
+	}
+
+	failedPayments, err := s.allpay.FetchFailedPayments(ctx, allpay.FetchFailedPaymentsInput{
+		From: from,
+		To:   date,
+	})
+	if err != nil {
+		logger.Error("unable to fetch failed payments", "error", err)
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("%d failed Direct Debit collections found between %s and %s", len(failedPayments), from.Format("2006-01-02"), date.Format("2006-01-02")))
+
+	tx, err := s.BeginStoreTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var reversed int
+	for _, payment := range failedPayments {
+		collection, reversal, err := getFailedCollectionDetails(ctx, payment)
+		if err != nil {
+			logger.Error("unable to parse failed payment", "courtRef", payment.ClientReference, "error", err)
+			continue
+		}
+
+		if !s.validateFailedCollection(ctx, tx, collection, reversal) {
+			continue
+		}
+
+		closed, err := s.closedPostingPeriod(ctx, tx, reversal.BankDate.Time, reversal.ReceivedDate.Time)
+		if err != nil {
+			return err
+		}
+		if !closed.IsZero() {
+			logger.Error("unable to reverse failed collection as the accounting period is closed", "courtRef", payment.ClientReference, "period", closed.Format("January 2006"))
+			continue
+		}
+
+		_, err = s.ProcessReversalUploadLine(ctx, tx, reversal)
+		if err != nil {
+			logger.Error("unable to reverse failed payment", "courtRef", payment.ClientReference, "error", err)
+			return err
+		}
+		reversed++
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("%d failed Direct Debit collections reversed", reversed))
+	return nil
+}
+
+// getFailedCollectionDetails returns the details of the original collection, used to match the payment ledger, and the
+// details of the reversal, which is dated on the date the payment was reversed by the bank
+func getFailedCollectionDetails(ctx context.Context, payment allpay.FailedPayment) (shared.ReversalDetails, shared.ReversalDetails, error) {
+	var (
+		courtRef       pgtype.Text
+		collectionDate pgtype.Timestamp
+		processedDate  pgtype.Timestamp
+		bankDate       pgtype.Date
+		createdBy      pgtype.Int4
+		notes          pgtype.Text
+		skipBankDate   pgtype.Bool
+	)
+
+	cd, err := time.Parse("02/01/2006 15:04:05", payment.CollectionDate)
+	if err != nil {
+		return shared.ReversalDetails{}, shared.ReversalDetails{}, err
+	}
+
+	pd, err := time.Parse("02/01/2006 15:04:05", payment.ProcessedDate)
+	if err != nil {
+		return shared.ReversalDetails{}, shared.ReversalDetails{}, err
+	}
+
+	_ = courtRef.Scan(payment.ClientReference)
+	_ = collectionDate.Scan(cd)
+	_ = processedDate.Scan(pd)
+	_ = bankDate.Scan(pd)
+	_ = notes.Scan(payment.ReasonCode)
+	_ = skipBankDate.Scan(true)
+	_ = store.ToInt4(&createdBy, ctx.(auth.Context).User.ID)
+
+	collection := shared.ReversalDetails{
+		PaymentType:     shared.TransactionTypeDirectDebitPayment,
+		ErroredCourtRef: courtRef,
+		ReceivedDate:    collectionDate,
+		Amount:          payment.Amount,
+		CreatedBy:       createdBy,
+		SkipBankDate:    skipBankDate,
+	}
+
+	reversal := collection
+	reversal.BankDate = bankDate
+	reversal.ReceivedDate = processedDate
+	reversal.Notes = notes
+
+	return collection, reversal, nil
+}
+
+/*
+A failed collection is only reversed if a matching Direct Debit payment exists and has not already been reversed, either
+by a previous run of this job or by a failed Direct Debit collections upload. Reversals created in the current run are
+visible to the transaction, so duplicate failed payments are only reversed as many times as the payment was collected.
+*/
+func (s *Service) validateFailedCollection(ctx context.Context, tx *store.Tx, collection shared.ReversalDetails, reversal shared.ReversalDetails) bool {
+	logger := s.Logger(ctx)
+
+	ledgerCount, err := tx.CountDuplicateLedger(ctx, store.CountDuplicateLedgerParams{
+		CourtRef:     collection.ErroredCourtRef,
+		Amount:       collection.Amount,
+		Type:         collection.PaymentType.Key(),
+		ReceivedDate: collection.ReceivedDate,
+		SkipBankDate: collection.SkipBankDate,
+	})
+	if err != nil || ledgerCount == 0 {
+		logger.Error("unable to find Direct Debit payment for failed collection", "courtRef", collection.ErroredCourtRef.String, "amount", collection.Amount, "collectionDate", collection.ReceivedDate.Time)
+		return false
+	}
+
+	// reversals from uploads are dated on the collection date, whereas those created by this job use the processed date
+	reversalCount, _ := tx.CountDuplicateLedger(ctx, store.CountDuplicateLedgerParams{
+		CourtRef:     collection.ErroredCourtRef,
+		Amount:       -collection.Amount,
+		Type:         collection.PaymentType.Key(),
+		ReceivedDate: collection.ReceivedDate,
+		SkipBankDate: collection.SkipBankDate,
+	})
+
+	if !reversal.ReceivedDate.Time.Equal(collection.ReceivedDate.Time) {
+		processedCount, _ := tx.CountDuplicateLedger(ctx, store.CountDuplicateLedgerParams{
+			CourtRef:     reversal.ErroredCourtRef,
+			Amount:       -reversal.Amount,
+			Type:         reversal.PaymentType.Key(),
+			ReceivedDate: reversal.ReceivedDate,
+			SkipBankDate: reversal.SkipBankDate,
+		})
+		reversalCount += processedCount
+	}
+
+	if reversalCount >= ledgerCount {
+		logger.Info("failed collection has already been reversed", "courtRef", collection.ErroredCourtRef.String, "amount", collection.Amount, "collectionDate", collection.ReceivedDate.Time)
+		return false
+	}
+
+	reversible, _ := tx.GetReversibleBalanceByCourtRef(ctx, collection.ErroredCourtRef)
+	if reversible < collection.Amount {
+		logger.Error("unable to reverse failed collection as maximum invoice debt exceeded", "courtRef", collection.ErroredCourtRef.String, "amount", collection.Amount)
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/allpay"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/event"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/validation"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationSuite) Test_processFailedDirectDebitCollections() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	seeder.SeedData(
+		"INSERT INTO finance_client VALUES (1, 1, 'failed collection', 'DIRECT DEBIT', NULL, '1111');",
+		"INSERT INTO invoice VALUES (1, 1, 1, 'AD', 'AD11111/25', '2025-04-01', '2026-03-31', 10000, NULL, '2026-03-31', NULL, '2025-04-01', NULL, NULL, NULL, '2025-04-01 00:00:00', '99');",
+		"INSERT INTO ledger VALUES (1, 'dd payment', '2025-09-30 00:00:00', '', 6000, '', 'DIRECT DEBIT PAYMENT', 'CONFIRMED', 1, NULL, NULL, NULL, '2025-10-01', NULL, NULL, NULL, NULL, '2025-10-01', 1);",
+		"INSERT INTO ledger_allocation VALUES (1, 1, 1, '2025-09-30 00:00:00', 6000, 'ALLOCATED', NULL, '', '2025-09-30', NULL);",
+		"ALTER SEQUENCE ledger_id_seq RESTART WITH 2;",
+		"ALTER SEQUENCE ledger_allocation_id_seq RESTART WITH 2;",
+	)
+
+	dispatch := &mockDispatch{}
+	allpayMock := &mockAllpay{
+		failedPayments: allpay.FailedPayments{
+			{
+				Amount:          6000,
+				ClientReference: "1111",
+				CollectionDate:  "30/09/2025 00:00:00",
+				ProcessedDate:   "03/10/2025 00:00:00",
+				ReasonCode:      "BACS 0 : Refer to payer",
+			},
+			{
+				Amount:          6000,
+				ClientReference: "unknown",
+				CollectionDate:  "30/09/2025 00:00:00",
+				ProcessedDate:   "03/10/2025 00:00:00",
+				ReasonCode:      "BACS 0 : Refer to payer",
+			},
+		},
+	}
+	govUK := &mockGovUK{}
+	s := Service{store: store.New(seeder.Conn), dispatch: dispatch, allpay: allpayMock, govUK: govUK, tx: seeder.Conn}
+
+	date := time.Date(2025, 10, 13, 0, 0, 0, 0, time.UTC)
+
+	err := s.ProcessFailedDirectDebitCollections(ctx, date)
+	assert.NoError(suite.T(), err)
+
+	assert.Equal(suite.T(), 7, govUK.nWorkingDays)
+	assert.Equal(suite.T(), allpay.FetchFailedPaymentsInput{
+		From: time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC),
+		To:   date,
+	}, allpayMock.lastCalledParams[0])
+
+	var (
+		amount       int
+		receivedDate time.Time
+		bankDate     time.Time
+		notes        string
+		allocated    int
+	)
+	_ = seeder.QueryRow(ctx, "SELECT amount, datetime, bankdate, notes FROM ledger WHERE id = 2").Scan(&amount, &receivedDate, &bankDate, &notes)
+	assert.Equal(suite.T(), -6000, amount)
+	assert.Equal(suite.T(), time.Date(2025, 10, 3, 0, 0, 0, 0, time.UTC), receivedDate)
+	assert.Equal(suite.T(), time.Date(2025, 10, 3, 0, 0, 0, 0, time.UTC), bankDate)
+	assert.Equal(suite.T(), "BACS 0 : Refer to payer", notes)
+
+	_ = seeder.QueryRow(ctx, "SELECT amount FROM ledger_allocation WHERE ledger_id = 2 AND invoice_id = 1").Scan(&allocated)
+	assert.Equal(suite.T(), -6000, allocated)
+	assert.NoError(suite.T(), s.RelayOutbox(ctx))
+	assert.Equal(suite.T(), event.DirectDebitCollectionFailed{ClientID: 1}, dispatch.event)
+
+	suite.T().Run("is idempotent", func(t *testing.T) {
+		err := s.ProcessFailedDirectDebitCollections(ctx, date)
+		assert.NoError(t, err)
+
+		var count int
+		_ = seeder.QueryRow(ctx, "SELECT COUNT(*) FROM ledger WHERE finance_client_id = 1 AND amount = -6000").Scan(&count)
+		assert.Equal(t, 1, count)
+	})
+
+	suite.T().Run("is not reversed again by an upload", func(t *testing.T) {
+		failedLines, err := s.ProcessPaymentReversals(ctx, [][]string{
+			{"Court reference", "Bank date", "Received date", "Amount"},
+			{"1111", "03/10/2025", "30/09/2025", "60.00"},
+		}, shared.ReportTypeUploadFailedDirectDebitCollections)
+		assert.NoError(t, err)
+		assert.Equal(t, map[int]string{1: validation.UploadErrorDuplicateReversal}, failedLines)
+
+		var count int
+		_ = seeder.QueryRow(ctx, "SELECT COUNT(*) FROM ledger WHERE finance_client_id = 1 AND amount = -6000").Scan(&count)
+		assert.Equal(t, 1, count)
+	})
+}
+
+func (suite *IntegrationSuite) Test_processFailedDirectDebitCollections_fetchError() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	allpayMock := &mockAllpay{errs: map[string]error{"FetchFailedPayments": errors.New("fetch failed")}}
+	s := Service{store: store.New(seeder.Conn), allpay: allpayMock, govUK: &mockGovUK{}, tx: seeder.Conn}
+
+	err := s.ProcessFailedDirectDebitCollections(ctx, time.Date(2025, 10, 13, 0, 0, 0, 0, time.UTC))
+	assert.EqualError(suite.T(), err, "fetch failed")
+}
@@ -12,15 +12,30 @@ import (
 )
 
 func (s *Service) ProcessFulfilledRefunds(ctx context.Context, records [][]string, bankDate shared.Date) (map[int]string, error) {
-	failedLines := make(map[int]string)
-
 	tx, err := s.BeginStoreTx(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback(ctx)
 
-	for index, record := range records {
+	failedLines, err := s.processFulfilledRefunds(ctx, tx, records, nil, bankDate, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return failedLines, nil
+}
+
+func (s *Service) processFulfilledRefunds(ctx context.Context, tx *store.Tx, records [][]string, batch *uploadBatch, bankDate shared.Date, onProcessed lineProcessedFunc) (map[int]string, error) {
+	failedLines := make(map[int]string)
+
+	for i, record := range records {
+		index := batch.lineIndex(i)
 		if !isHeaderRow(shared.ReportTypeUploadFulfilledRefunds, index) && safeRead(record, 0) != "" {
 			details := getRefundDetails(ctx, record, bankDate, index, &failedLines)
 
@@ -37,7 +52,20 @@ func (s *Service) ProcessFulfilledRefunds(ctx context.Context, records [][]strin
 					continue
 				}
 
-				err := s.ProcessFulfilledRefundsLine(ctx, tx, id, details)
+				valid, err := s.validatePostingPeriod(ctx, tx, index, &failedLines, details.BankDate.Time)
+				if err != nil {
+					return nil, err
+				}
+				if !valid {
+					continue
+				}
+
+				ledgerID, err := s.ProcessFulfilledRefundsLine(ctx, tx, id, details)
+				if err != nil {
+					return nil, err
+				}
+
+				err = onProcessed.call(index, ledgerID)
 				if err != nil {
 					return nil, err
 				}
@@ -45,11 +73,6 @@ func (s *Service) ProcessFulfilledRefunds(ctx context.Context, records [][]strin
 		}
 	}
 
-	err = tx.Commit(ctx)
-	if err != nil {
-		return nil, err
-	}
-
 	return failedLines, nil
 }
 
@@ -90,10 +113,15 @@ func getRefundDetails(ctx context.Context, record []string, formDate shared.Date
 	}
 }
 
-func (s *Service) ProcessFulfilledRefundsLine(ctx context.Context, tx *store.Tx, refundID int32, details shared.FulfilledRefundDetails) error {
+func (s *Service) ProcessFulfilledRefundsLine(ctx context.Context, tx *store.Tx, refundID int32, details shared.FulfilledRefundDetails) (int32, error) {
 	var now pgtype.Timestamp
 	_ = now.Scan(time.Now())
 
+	err := s.checkPostingPeriod(ctx, tx, details.BankDate.Time, now.Time)
+	if err != nil {
+		return 0, err
+	}
+
 	params := store.CreateLedgerForCourtRefParams{
 		CourtRef:     details.CourtRef,
 		Amount:       -details.Amount.Int32,
@@ -106,7 +134,7 @@ func (s *Service) ProcessFulfilledRefundsLine(ctx context.Context, tx *store.Tx,
 	ledgerID, err := tx.CreateLedgerForCourtRef(ctx, params)
 
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	err = tx.CreateLedgerAllocation(ctx, store.CreateLedgerAllocationParams{
@@ -115,13 +143,13 @@ func (s *Service) ProcessFulfilledRefundsLine(ctx context.Context, tx *store.Tx,
 		LedgerID: ledgerID,
 	})
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	err = tx.MarkRefundsAsFulfilled(ctx, refundID)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	return tx.RemoveBankDetails(ctx, refundID)
+	return ledgerID, tx.RemoveBankDetails(ctx, refundID)
 }
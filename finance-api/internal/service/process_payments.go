@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -16,24 +17,61 @@ import (
 )
 
 func (s *Service) ProcessPayments(ctx context.Context, records [][]string, uploadType shared.ReportUploadType, bankDate shared.Date, pisNumber int) (map[int]string, error) {
-	failedLines := make(map[int]string)
-
 	tx, err := s.BeginStoreTx(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback(ctx)
 
-	for index, record := range records {
+	failedLines, err := s.processPayments(ctx, tx, records, nil, uploadType, bankDate, pisNumber, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return failedLines, nil
+}
+
+func (s *Service) processPayments(ctx context.Context, tx *store.Tx, records [][]string, batch *uploadBatch, uploadType shared.ReportUploadType, bankDate shared.Date, pisNumber int, onProcessed lineProcessedFunc) (map[int]string, error) {
+	failedLines := make(map[int]string)
+
+	for i, record := range records {
+		index := batch.lineIndex(i)
 		if !isHeaderRow(uploadType, index) && safeRead(record, 0) != "" {
 			details := getPaymentDetails(ctx, record, uploadType, bankDate, pisNumber, index, &failedLines)
 
 			if details != (shared.PaymentDetails{}) {
-				if !s.validatePaymentLine(ctx, details, index, &failedLines) {
+				if !s.validatePaymentLine(ctx, details, batch.committedPayments(details), index, &failedLines) {
+					if failedLines[index] == validation.UploadErrorClientNotFound && details.Amount > 0 {
+						err := s.holdPaymentInSuspense(ctx, tx, details, uploadType, record)
+						if err != nil {
+							return nil, err
+						}
+						batch.addPayment(details)
+						failedLines[index] = validation.UploadErrorHeldInSuspense
+					}
 					continue
 				}
 
-				_, err := s.ProcessPaymentsUploadLine(ctx, tx, details)
+				valid, err := s.validatePostingPeriod(ctx, tx, index, &failedLines, details.BankDate.Time, details.ReceivedDate.Time)
+				if err != nil {
+					return nil, err
+				}
+				if !valid {
+					continue
+				}
+
+				ledgerID, err := s.ProcessPaymentsUploadLine(ctx, tx, details)
+				if err != nil {
+					return nil, err
+				}
+				batch.addPayment(details)
+
+				err = onProcessed.call(index, ledgerID)
 				if err != nil {
 					return nil, err
 				}
@@ -41,12 +79,34 @@ func (s *Service) ProcessPayments(ctx context.Context, records [][]string, uploa
 		}
 	}
 
-	err = tx.Commit(ctx)
-	if err != nil {
-		return nil, err
+	return failedLines, nil
+}
+
+const (
+	allocationStrategyOldestFirst = "OLDEST FIRST"
+	allocationStrategyTargeted    = "TARGETED INVOICE"
+)
+
+// prioritiseInvoice moves the unpaid invoice matching the reference to the front of the list, so that a payment is
+// allocated to it first and any surplus goes to the remaining invoices oldest-first. If no reference is given or it does
+// not match an unpaid invoice, the invoices are left in date order. The strategy applied is returned for the ledger.
+func prioritiseInvoice(invoices []store.GetUnpaidInvoicesByCourtRefRow, reference pgtype.Text) ([]store.GetUnpaidInvoicesByCourtRefRow, string) {
+	if !reference.Valid || reference.String == "" {
+		return invoices, allocationStrategyOldestFirst
 	}
 
-	return failedLines, nil
+	i := slices.IndexFunc(invoices, func(invoice store.GetUnpaidInvoicesByCourtRefRow) bool {
+		return strings.EqualFold(invoice.Reference, reference.String)
+	})
+	if i == -1 {
+		return invoices, allocationStrategyOldestFirst
+	}
+
+	prioritised := make([]store.GetUnpaidInvoicesByCourtRefRow, 0, len(invoices))
+	prioritised = append(prioritised, invoices[i])
+	prioritised = append(prioritised, invoices[:i]...)
+	prioritised = append(prioritised, invoices[i+1:]...)
+	return prioritised, allocationStrategyTargeted
 }
 
 func getLedgerType(uploadType shared.ReportUploadType) (shared.TransactionType, error) {
@@ -83,6 +143,8 @@ func parseAmount(amount string) (int32, error) {
 	return int32(intAmount), err
 }
 
+// getPaymentDetails parses a payment line. Card and cheque uploads may include an optional trailing column holding the
+// reference of the invoice the payment is intended for.
 func getPaymentDetails(ctx context.Context, record []string, uploadType shared.ReportUploadType, formDate shared.Date, pisNumber int, index int, failedLines *map[int]string) shared.PaymentDetails {
 	var (
 		paymentType  shared.TransactionType
@@ -91,6 +153,7 @@ func getPaymentDetails(ctx context.Context, record []string, uploadType shared.R
 		receivedDate pgtype.Timestamp
 		createdBy    pgtype.Int4
 		pis          pgtype.Int4
+		invoiceRef   pgtype.Text
 		amount       int32
 		err          error
 	)
@@ -123,6 +186,9 @@ func getPaymentDetails(ctx context.Context, record []string, uploadType shared.R
 			return shared.PaymentDetails{}
 		}
 		_ = receivedDate.Scan(rd)
+		if ref := strings.TrimSpace(safeRead(record, 3)); ref != "" {
+			_ = invoiceRef.Scan(ref)
+		}
 	case shared.ReportTypeUploadPaymentsSupervisionBACS, shared.ReportTypeUploadPaymentsOPGBACS:
 		_ = courtRef.Scan(safeRead(record, 10))
 
@@ -153,6 +219,9 @@ func getPaymentDetails(ctx context.Context, record []string, uploadType shared.R
 			return shared.PaymentDetails{}
 		}
 		_ = receivedDate.Scan(rd)
+		if ref := strings.TrimSpace(safeRead(record, 5)); ref != "" {
+			_ = invoiceRef.Scan(ref)
+		}
 	case shared.ReportTypeUploadDirectDebitsCollections:
 		_ = courtRef.Scan(strings.TrimSpace(safeRead(record, 1)))
 		amount, err = parseAmount(strings.TrimSpace(safeRead(record, 2)))
@@ -180,16 +249,18 @@ func getPaymentDetails(ctx context.Context, record []string, uploadType shared.R
 		(*failedLines)[index] = validation.UploadErrorPaymentTypeParse
 	}
 
-	return shared.PaymentDetails{Amount: amount, BankDate: bankDate, CourtRef: courtRef, LedgerType: paymentType, ReceivedDate: receivedDate, CreatedBy: createdBy, PisNumber: pis}
+	return shared.PaymentDetails{Amount: amount, BankDate: bankDate, CourtRef: courtRef, LedgerType: paymentType, ReceivedDate: receivedDate, CreatedBy: createdBy, PisNumber: pis, InvoiceReference: invoiceRef}
 }
 
 /*
 Payment lines are invalid if either the payment cannot be matched to a client, or if the payment has already been processed
-(to prevent duplicates being created if the file is uploaded more than once).
+(to prevent duplicates being created if the file is uploaded more than once). Unmatched lines are held in suspense, so
+payments already held in suspense also count as processed.
 Duplicate payments within the same file will be processed due to the transaction only being committed once all lines have
 been processed, which is expected behaviour as there are legitimate reasons for a payment being duplicated, but duplicates will always appear in the same file.
+When an upload is committed in batches, matching payments committed by earlier batches of the same file are not duplicates.
 */
-func (s *Service) validatePaymentLine(ctx context.Context, details shared.PaymentDetails, index int, failedLines *map[int]string) bool {
+func (s *Service) validatePaymentLine(ctx context.Context, details shared.PaymentDetails, committed int64, index int, failedLines *map[int]string) bool {
 	count, _ := s.store.CountDuplicateLedger(ctx, store.CountDuplicateLedgerParams{
 		CourtRef:     details.CourtRef,
 		Amount:       details.Amount,
@@ -199,7 +270,16 @@ func (s *Service) validatePaymentLine(ctx context.Context, details shared.Paymen
 		PisNumber:    details.PisNumber,
 	})
 
-	if count > 0 {
+	suspended, _ := s.store.CountDuplicateSuspense(ctx, store.CountDuplicateSuspenseParams{
+		CourtRef:     details.CourtRef,
+		Amount:       details.Amount,
+		Type:         details.LedgerType.Key(),
+		BankDate:     details.BankDate,
+		ReceivedDate: details.ReceivedDate,
+		PisNumber:    details.PisNumber,
+	})
+
+	if count+suspended > committed {
 		(*failedLines)[index] = validation.UploadErrorDuplicatePayment
 		return false
 	}
@@ -219,12 +299,19 @@ func (s *Service) ProcessPaymentsUploadLine(ctx context.Context, tx *store.Tx, d
 		return 0, nil
 	}
 
+	err := s.checkPostingPeriod(ctx, tx, details.BankDate.Time, details.ReceivedDate.Time)
+	if err != nil {
+		return 0, err
+	}
+
 	invoices, err := tx.GetUnpaidInvoicesByCourtRef(ctx, details.CourtRef)
 
 	if err != nil {
 		return 0, err
 	}
 
+	invoices, strategy := prioritiseInvoice(invoices, details.InvoiceReference)
+
 	remaining := details.Amount
 	var allocations []store.CreateLedgerAllocationParams
 
@@ -272,6 +359,7 @@ func (s *Service) ProcessPaymentsUploadLine(ctx context.Context, tx *store.Tx, d
 		ReceivedDate: details.ReceivedDate,
 		PisNumber:    details.PisNumber,
 	}
+	_ = params.AllocationStrategy.Scan(strategy)
 	ledgerID, err := tx.CreateLedgerForCourtRef(ctx, params)
 
 	if err != nil {
@@ -288,7 +376,7 @@ func (s *Service) ProcessPaymentsUploadLine(ctx context.Context, tx *store.Tx, d
 
 	if remaining > 0 {
 		client, _ := tx.GetClientIdsByCourtRef(ctx, details.CourtRef)
-		err = s.dispatch.CreditOnAccount(ctx, event.CreditOnAccount{
+		err = s.outbox(tx).CreditOnAccount(ctx, event.CreditOnAccount{
 			ClientID:        int(client.ClientID),
 			CreditRemaining: int(remaining),
 		})
@@ -147,14 +147,14 @@ func (suite *IntegrationSuite) Test_processPayments() {
 			name: "failure cases",
 			records: [][]string{
 				{"Ordercode", "Date", "Amount"},
-				{"1234567890", "01/01/2024", "50"}, // client not found
+				{"1234567890", "01/01/2024", "50"}, // client not found, held in suspense
 				{"1234567", "01/01/2024", "100"},   // duplicate
 			},
 			paymentType:      shared.ReportTypeUploadPaymentsMOTOCard,
 			bankDate:         shared.NewDate("2024-01-01"),
 			expectedClientId: 3,
 			expectedFailedLines: map[int]string{
-				1: "CLIENT_NOT_FOUND",
+				1: "HELD_IN_SUSPENSE",
 				2: "DUPLICATE_PAYMENT",
 			},
 		},
@@ -209,6 +209,7 @@ func (suite *IntegrationSuite) Test_processPayments() {
 			}
 
 			assert.Equal(t, tt.expectedLedgerAllocations, createdLedgerAllocations)
+			assert.NoError(t, s.RelayOutbox(suite.ctx))
 			assert.Equal(t, tt.expectedDispatch, dispatch.event)
 
 			if tt.paymentType == shared.ReportTypeUploadDirectDebitsCollections && len(tt.expectedLedgerAllocations) > 0 {
@@ -303,6 +304,32 @@ func Test_getPaymentDetails(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:       "Moto card with invoice reference",
+			record:     []string{"12345678", "02/01/2025", "320.00", " AD11223/19 "},
+			uploadType: shared.ReportTypeUploadPaymentsMOTOCard,
+			index:      0,
+			expectedPaymentDetails: shared.PaymentDetails{
+				Amount: 32000,
+				ReceivedDate: pgtype.Timestamp{
+					Time:             time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+					InfinityModifier: 0,
+					Valid:            true,
+				},
+				CourtRef:   pgtype.Text{String: "12345678", Valid: true},
+				LedgerType: shared.TransactionTypeMotoCardPayment,
+				BankDate: pgtype.Date{
+					Time:             time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					InfinityModifier: 0,
+					Valid:            true,
+				},
+				CreatedBy: pgtype.Int4{
+					Int32: 10,
+					Valid: true,
+				},
+				InvoiceReference: pgtype.Text{String: "AD11223/19", Valid: true},
+			},
+		},
 		{
 			name:       "BACS",
 			record:     []string{"", "", "", "", "01/06/2024", "", "20.50", "", "", "", "87654321"},
@@ -446,6 +473,51 @@ func Test_getPaymentDetails(t *testing.T) {
 	}
 }
 
+func Test_prioritiseInvoice(t *testing.T) {
+	invoices := []store.GetUnpaidInvoicesByCourtRefRow{
+		{ID: 1, Reference: "AD00001/24", Outstanding: 10000},
+		{ID: 2, Reference: "S200002/24", Outstanding: 20000},
+		{ID: 3, Reference: "AD00003/25", Outstanding: 10000},
+	}
+
+	tests := []struct {
+		name             string
+		reference        pgtype.Text
+		expectedIds      []int32
+		expectedStrategy string
+	}{
+		{
+			name:             "no reference",
+			expectedIds:      []int32{1, 2, 3},
+			expectedStrategy: "OLDEST FIRST",
+		},
+		{
+			name:             "targeted invoice moved to the front",
+			reference:        pgtype.Text{String: "s200002/24", Valid: true},
+			expectedIds:      []int32{2, 1, 3},
+			expectedStrategy: "TARGETED INVOICE",
+		},
+		{
+			name:             "reference does not match an unpaid invoice",
+			reference:        pgtype.Text{String: "AD99999/24", Valid: true},
+			expectedIds:      []int32{1, 2, 3},
+			expectedStrategy: "OLDEST FIRST",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prioritised, strategy := prioritiseInvoice(invoices, tt.reference)
+
+			var ids []int32
+			for _, invoice := range prioritised {
+				ids = append(ids, invoice.ID)
+			}
+			assert.Equal(t, tt.expectedIds, ids)
+			assert.Equal(t, tt.expectedStrategy, strategy)
+		})
+	}
+}
+
 func Test_safeRead(t *testing.T) {
 	type args struct {
 		record []string
@@ -17,24 +17,53 @@ type refundReversalDetails struct {
 }
 
 func (s *Service) ProcessRefundReversals(ctx context.Context, records [][]string, bankDate shared.Date) (map[int]string, error) {
-	failedLines := make(map[int]string)
-
 	tx, err := s.BeginStoreTx(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback(ctx)
 
-	for index, record := range records {
+	failedLines, err := s.processRefundReversals(ctx, tx, records, nil, bankDate, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return failedLines, nil
+}
+
+func (s *Service) processRefundReversals(ctx context.Context, tx *store.Tx, records [][]string, batch *uploadBatch, bankDate shared.Date, onProcessed lineProcessedFunc) (map[int]string, error) {
+	failedLines := make(map[int]string)
+
+	for i, record := range records {
+		index := batch.lineIndex(i)
 		if !isHeaderRow(shared.ReportTypeUploadReverseFulfilledRefunds, index) && safeRead(record, 0) != "" {
 			details := getRefundReversalDetails(ctx, record, bankDate, index, &failedLines)
 
 			if details != (refundReversalDetails{}) {
-				if !s.validateRefundReversalLine(ctx, details, index, &failedLines) {
+				if !s.validateRefundReversalLine(ctx, details, batch.committedPayments(details.PaymentDetails), index, &failedLines) {
 					continue
 				}
 
-				_, err := s.ProcessPaymentsUploadLine(ctx, tx, details.PaymentDetails)
+				valid, err := s.validatePostingPeriod(ctx, tx, index, &failedLines, details.BankDate.Time, details.ReceivedDate.Time)
+				if err != nil {
+					return nil, err
+				}
+				if !valid {
+					continue
+				}
+
+				ledgerID, err := s.ProcessPaymentsUploadLine(ctx, tx, details.PaymentDetails)
+				if err != nil {
+					return nil, err
+				}
+				batch.addPayment(details.PaymentDetails)
+
+				err = onProcessed.call(index, ledgerID)
 				if err != nil {
 					return nil, err
 				}
@@ -42,11 +71,6 @@ func (s *Service) ProcessRefundReversals(ctx context.Context, records [][]string
 		}
 	}
 
-	err = tx.Commit(ctx)
-	if err != nil {
-		return nil, err
-	}
-
 	return failedLines, nil
 }
 
@@ -91,7 +115,7 @@ func getRefundReversalDetails(ctx context.Context, record []string, formDate sha
 	}
 }
 
-func (s *Service) validateRefundReversalLine(ctx context.Context, details refundReversalDetails, index int, failedLines *map[int]string) bool {
+func (s *Service) validateRefundReversalLine(ctx context.Context, details refundReversalDetails, committed int64, index int, failedLines *map[int]string) bool {
 	var amount pgtype.Int4
 	_ = store.ToInt4(&amount, details.Amount)
 
@@ -107,5 +131,5 @@ func (s *Service) validateRefundReversalLine(ctx context.Context, details refund
 	}
 
 	// check for already processed reversal
-	return s.validatePaymentLine(ctx, details.PaymentDetails, index, failedLines)
+	return s.validatePaymentLine(ctx, details.PaymentDetails, committed, index, failedLines)
 }
@@ -154,6 +154,7 @@ func (suite *IntegrationSuite) Test_processRefundReversals() {
 			}
 
 			assert.Equal(t, tt.expectedLedgerAllocations, createdLedgerAllocations)
+			assert.NoError(t, s.RelayOutbox(suite.ctx))
 			assert.Equal(t, tt.expectedDispatch, dispatch.event)
 		})
 	}
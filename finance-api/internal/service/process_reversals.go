@@ -5,6 +5,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/auth"
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/event"
@@ -14,40 +15,74 @@ import (
 )
 
 func (s *Service) ProcessPaymentReversals(ctx context.Context, records [][]string, uploadType shared.ReportUploadType) (map[int]string, error) {
-	failedLines := make(map[int]string)
-
 	tx, err := s.BeginStoreTx(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback(ctx)
 
-	var processedRecords []shared.ReversalDetails
+	failedLines, err := s.processPaymentReversals(ctx, tx, records, nil, uploadType, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return failedLines, nil
+}
+
+func (s *Service) processPaymentReversals(ctx context.Context, tx *store.Tx, records [][]string, batch *uploadBatch, uploadType shared.ReportUploadType, onProcessed lineProcessedFunc) (map[int]string, error) {
+	failedLines := make(map[int]string)
+
+	if batch == nil {
+		batch = &uploadBatch{}
+	}
 
-	for index, record := range records {
+	for i, record := range records {
+		index := batch.lineIndex(i)
 		if index != 0 && safeRead(record, 0) != "" {
 			details := getReversalLines(ctx, record, uploadType, index, &failedLines)
 
 			if details != (shared.ReversalDetails{}) {
-				if !s.validateReversalLine(ctx, details, uploadType, processedRecords, index, &failedLines) {
+				valid, err := s.validateReversalLine(ctx, tx, details, uploadType, batch.reversals, index, &failedLines)
+				if err != nil {
+					return nil, err
+				}
+				if !valid {
 					continue
 				}
 
 				if uploadType == shared.ReportTypeUploadMisappliedPayments {
-					if !s.validateApplyLine(ctx, details, index, &failedLines) {
+					valid, err = s.validateApplyLine(ctx, tx, details, index, &failedLines)
+					if err != nil {
+						return nil, err
+					}
+					if !valid {
 						continue
 					}
 				}
 
-				err = s.ProcessReversalUploadLine(ctx, tx, details)
+				valid, err = s.validatePostingPeriod(ctx, tx, index, &failedLines, details.BankDate.Time, details.ReceivedDate.Time)
 				if err != nil {
 					return nil, err
 				}
+				if !valid {
+					continue
+				}
 
-				processedRecords = append(processedRecords, details)
+				reversalID, err := s.ProcessReversalUploadLine(ctx, tx, details)
+				if err != nil {
+					return nil, err
+				}
+
+				batch.reversals = append(batch.reversals, details)
+				ledgerIDs := []int32{reversalID}
 
 				if uploadType == shared.ReportTypeUploadMisappliedPayments {
-					_, err = s.ProcessPaymentsUploadLine(ctx, tx, shared.PaymentDetails{
+					paymentID, err := s.ProcessPaymentsUploadLine(ctx, tx, shared.PaymentDetails{
 						Amount:       details.Amount,
 						BankDate:     details.BankDate,
 						CourtRef:     details.CorrectCourtRef,
@@ -59,16 +94,17 @@ func (s *Service) ProcessPaymentReversals(ctx context.Context, records [][]strin
 					if err != nil {
 						return nil, err
 					}
+					ledgerIDs = append(ledgerIDs, paymentID)
+				}
+
+				err = onProcessed.call(index, ledgerIDs...)
+				if err != nil {
+					return nil, err
 				}
 			}
 		}
 	}
 
-	err = tx.Commit(ctx)
-	if err != nil {
-		return nil, err
-	}
-
 	return failedLines, nil
 }
 
@@ -215,8 +251,10 @@ func getReversalLines(ctx context.Context, record []string, uploadType shared.Re
 	}
 }
 
-func (s *Service) validateReversalLine(ctx context.Context, details shared.ReversalDetails, uploadType shared.ReportUploadType, processedRecords []shared.ReversalDetails, index int, failedLines *map[int]string) bool {
-	ledgerCount, _ := s.store.CountDuplicateLedger(ctx, store.CountDuplicateLedgerParams{
+// validateReversalLine reads through the upload transaction, so that reversals made earlier in the same upload are
+// counted against the payments and balance available to reverse.
+func (s *Service) validateReversalLine(ctx context.Context, tx *store.Tx, details shared.ReversalDetails, uploadType shared.ReportUploadType, processedRecords []shared.ReversalDetails, index int, failedLines *map[int]string) (bool, error) {
+	ledgerCount, err := tx.CountDuplicateLedger(ctx, store.CountDuplicateLedgerParams{
 		CourtRef:     details.ErroredCourtRef,
 		Amount:       details.Amount,
 		Type:         details.PaymentType.Key(),
@@ -225,21 +263,27 @@ func (s *Service) validateReversalLine(ctx context.Context, details shared.Rever
 		PisNumber:    details.PisNumber,
 		SkipBankDate: details.SkipBankDate,
 	})
+	if err != nil {
+		return false, err
+	}
 
 	if ledgerCount == 0 {
 		(*failedLines)[index] = validation.UploadErrorNoMatchedPayment
-		return false
+		return false, nil
 	}
 
 	if uploadType == shared.ReportTypeUploadMisappliedPayments {
-		exists, _ := s.store.CheckClientExistsByCourtRef(ctx, details.CorrectCourtRef)
+		exists, err := tx.CheckClientExistsByCourtRef(ctx, details.CorrectCourtRef)
+		if err != nil {
+			return false, err
+		}
 		if !exists {
 			(*failedLines)[index] = validation.UploadErrorReversalClientNotFound
-			return false
+			return false, nil
 		}
 	}
 
-	reversalCount, _ := s.store.CountDuplicateLedger(ctx, store.CountDuplicateLedgerParams{
+	reversalCount, err := tx.CountDuplicateLedger(ctx, store.CountDuplicateLedgerParams{
 		CourtRef:     details.ErroredCourtRef,
 		Amount:       -details.Amount,
 		Type:         details.PaymentType.Key(),
@@ -247,39 +291,72 @@ func (s *Service) validateReversalLine(ctx context.Context, details shared.Rever
 		ReceivedDate: details.ReceivedDate,
 		PisNumber:    details.PisNumber,
 	})
+	if err != nil {
+		return false, err
+	}
+
+	// failed collections reversed by the Direct Debit job are dated on the date Allpay processed the failure, which is the
+	// bank date of the upload line, rather than on the collection date
+	if uploadType == shared.ReportTypeUploadFailedDirectDebitCollections && !details.BankDate.Time.Equal(details.ReceivedDate.Time) {
+		var processedDate pgtype.Timestamp
+		_ = processedDate.Scan(details.BankDate.Time)
+
+		processedCount, err := tx.CountDuplicateLedger(ctx, store.CountDuplicateLedgerParams{
+			CourtRef:     details.ErroredCourtRef,
+			Amount:       -details.Amount,
+			Type:         details.PaymentType.Key(),
+			ReceivedDate: processedDate,
+			SkipBankDate: details.SkipBankDate,
+		})
+		if err != nil {
+			return false, err
+		}
+		reversalCount += processedCount
+	}
 
 	if reversalCount >= ledgerCount {
 		(*failedLines)[index] = validation.UploadErrorDuplicateReversal
-		return false
+		return false, nil
 	}
 
 	if !hasPaymentToReverse(processedRecords, details, int(ledgerCount)) {
 		(*failedLines)[index] = validation.UploadErrorDuplicateReversal
-		return false
+		return false, nil
 	}
 
-	reversible, _ := s.store.GetReversibleBalanceByCourtRef(ctx, details.ErroredCourtRef)
+	reversible, err := tx.GetReversibleBalanceByCourtRef(ctx, details.ErroredCourtRef)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return false, err
+	}
 
 	if reversible < details.Amount {
 		(*failedLines)[index] = validation.UploadErrorMaximumDebt
-		return false
+		return false, nil
 	}
 
-	return true
+	return true, nil
 }
 
-func (s *Service) validateApplyLine(ctx context.Context, details shared.ReversalDetails, index int, failedLines *map[int]string) bool {
-	exists, _ := s.store.CheckClientExistsByCourtRef(ctx, details.CorrectCourtRef)
+func (s *Service) validateApplyLine(ctx context.Context, tx *store.Tx, details shared.ReversalDetails, index int, failedLines *map[int]string) (bool, error) {
+	exists, err := tx.CheckClientExistsByCourtRef(ctx, details.CorrectCourtRef)
+	if err != nil {
+		return false, err
+	}
 
 	if !exists {
 		(*failedLines)[index] = validation.UploadErrorReversalClientNotFound
-		return false
+		return false, nil
 	}
 
-	return true
+	return true, nil
 }
 
-func (s *Service) ProcessReversalUploadLine(ctx context.Context, tx *store.Tx, details shared.ReversalDetails) error {
+func (s *Service) ProcessReversalUploadLine(ctx context.Context, tx *store.Tx, details shared.ReversalDetails) (int32, error) {
+	err := s.checkPostingPeriod(ctx, tx, details.BankDate.Time, details.ReceivedDate.Time)
+	if err != nil {
+		return 0, err
+	}
+
 	ledgerID, err := tx.CreateLedgerForCourtRef(ctx, store.CreateLedgerForCourtRefParams{
 		CourtRef:     details.ErroredCourtRef,
 		Amount:       -details.Amount,
@@ -293,7 +370,7 @@ func (s *Service) ProcessReversalUploadLine(ctx context.Context, tx *store.Tx, d
 	})
 
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	// get credit balance and apply there first
@@ -312,20 +389,20 @@ func (s *Service) ProcessReversalUploadLine(ctx context.Context, tx *store.Tx, d
 			LedgerID: ledgerID,
 		})
 		if err != nil {
-			return err
+			return 0, err
 		}
 
 		remaining -= allocationAmount
 	}
 
 	if remaining == 0 {
-		return nil
+		return ledgerID, nil
 	}
 
 	invoices, err := tx.GetInvoicesForReversalByCourtRef(ctx, details.ErroredCourtRef)
 
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	for _, invoice := range invoices {
@@ -344,7 +421,7 @@ func (s *Service) ProcessReversalUploadLine(ctx context.Context, tx *store.Tx, d
 			LedgerID:  ledgerID,
 		})
 		if err != nil {
-			return err
+			return 0, err
 		}
 
 		remaining -= allocationAmount
@@ -354,7 +431,7 @@ func (s *Service) ProcessReversalUploadLine(ctx context.Context, tx *store.Tx, d
 	}
 
 	if details.PaymentType == shared.TransactionTypeRefund {
-		return tx.CreateLedgerAllocation(ctx, store.CreateLedgerAllocationParams{
+		return ledgerID, tx.CreateLedgerAllocation(ctx, store.CreateLedgerAllocationParams{
 			Amount:   remaining,
 			Status:   "UNAPPLIED",
 			LedgerID: ledgerID,
@@ -363,23 +440,24 @@ func (s *Service) ProcessReversalUploadLine(ctx context.Context, tx *store.Tx, d
 
 	if remaining != 0 {
 		s.Logger(ctx).Error("process reversal upload line failed as amount remaining after applying to all available invoices", "amount", remaining)
-		return errors.New("unexpected error - remaining not zero")
+		return 0, errors.New("unexpected error - remaining not zero")
 	}
 
 	if details.PaymentType == shared.TransactionTypeDirectDebitPayment {
 		client, err := tx.GetClientIdsByCourtRef(ctx, details.ErroredCourtRef)
 		if err != nil {
-			return err
+			return 0, err
 		}
-		err = s.dispatch.DirectDebitCollectionFailed(ctx, event.DirectDebitCollectionFailed{
+		err = s.outbox(tx).DirectDebitCollectionFailed(ctx, event.DirectDebitCollectionFailed{
 			ClientID: int(client.ClientID),
 		})
 		if err != nil {
 			s.Logger(ctx).Error("error dispatching \"direct-debit-collection-failed\" event", "error", err)
+			return 0, err
 		}
 	}
 
-	return nil
+	return ledgerID, nil
 }
 
 func hasPaymentToReverse(processedRecords []shared.ReversalDetails, details shared.ReversalDetails, totalPayments int) bool {
@@ -510,6 +510,7 @@ func (suite *IntegrationSuite) Test_processReversals() {
 			}
 
 			assert.Equal(t, tt.allocations, allocations)
+			assert.NoError(t, s.RelayOutbox(suite.ctx))
 			assert.Equal(t, tt.expectedDispatch, dispatch.event)
 		})
 	}
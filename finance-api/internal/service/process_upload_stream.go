@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"maps"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+type UploadStream struct {
+	JobID      int32
+	UploadType shared.ReportUploadType
+	UploadDate shared.Date
+	PisNumber  int
+	BatchSize  int
+}
+
+// uploadBatch carries state between the transactions of an upload that is committed in batches
+type uploadBatch struct {
+	offset    int
+	payments  map[shared.PaymentDetails]int64
+	reversals []shared.ReversalDetails
+}
+
+// lineIndex returns the index of the line within the whole file
+func (b *uploadBatch) lineIndex(i int) int {
+	if b == nil {
+		return i
+	}
+	return b.offset + i
+}
+
+// committedPayments returns the number of matching payments committed by earlier batches of the same file
+func (b *uploadBatch) committedPayments(details shared.PaymentDetails) int64 {
+	if b == nil {
+		return 0
+	}
+	return b.payments[duplicateKey(details)]
+}
+
+func (b *uploadBatch) addPayment(details shared.PaymentDetails) {
+	if b != nil {
+		b.payments[duplicateKey(details)]++
+	}
+}
+
+// duplicateKey drops the invoice reference from the payment, as it is not considered when checking for duplicates
+func duplicateKey(details shared.PaymentDetails) shared.PaymentDetails {
+	details.InvoiceReference = pgtype.Text{}
+	return details
+}
+
+// ProcessUploadStream processes an upload from the reader a batch of lines at a time, committing each batch and recording
+// progress against the upload job, so that large files are neither held in memory nor processed in one long transaction.
+// If the upload fails, batches that have already been committed are not rolled back. A BatchSize of zero processes the
+// whole file in a single transaction, as with ProcessPayments.
+func (s *Service) ProcessUploadStream(ctx context.Context, reader *csv.Reader, upload UploadStream) (int, map[int]string, error) {
+	if !upload.UploadType.PostsLedgers() {
+		return 0, nil, apierror.BadRequestError("uploadType", "Streaming is not available for this upload type", nil)
+	}
+
+	failedLines := make(map[int]string)
+	batch := &uploadBatch{payments: make(map[shared.PaymentDetails]int64)}
+
+	for {
+		records, readErr := readRecords(reader, upload.BatchSize)
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			s.Logger(ctx).Error("unable to read upload", "jobId", upload.JobID, "line", batch.offset+len(records), "error", readErr)
+			return countLines(upload.UploadType, batch.offset), failedLines, readErr
+		}
+
+		if len(records) > 0 {
+			failed, err := s.processUploadBatch(ctx, records, batch, upload)
+			if err != nil {
+				s.Logger(ctx).Error("unable to process upload batch", "jobId", upload.JobID, "line", batch.offset, "error", err)
+				return countLines(upload.UploadType, batch.offset), failedLines, err
+			}
+			maps.Copy(failedLines, failed)
+			batch.offset += len(records)
+		}
+
+		if readErr != nil {
+			break
+		}
+	}
+
+	return countLines(upload.UploadType, batch.offset), failedLines, nil
+}
+
+func (s *Service) processUploadBatch(ctx context.Context, records [][]string, batch *uploadBatch, upload UploadStream) (map[int]string, error) {
+	tx, err := s.BeginStoreTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	failedLines, err := s.processUploadRecords(ctx, tx, records, batch, upload.UploadType, upload.UploadDate, upload.PisNumber, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.UpdateUploadJobProgress(ctx, store.UpdateUploadJobProgressParams{
+		ProcessedLineCount: pgtype.Int4{Int32: int32(countLines(upload.UploadType, batch.offset+len(records))), Valid: true},
+		ID:                 upload.JobID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return failedLines, tx.Commit(ctx)
+}
+
+func (s *Service) processUploadRecords(ctx context.Context, tx *store.Tx, records [][]string, batch *uploadBatch, uploadType shared.ReportUploadType, uploadDate shared.Date, pisNumber int, onProcessed lineProcessedFunc) (map[int]string, error) {
+	switch {
+	case uploadType.IsPayment():
+		return s.processPayments(ctx, tx, records, batch, uploadType, uploadDate, pisNumber, onProcessed)
+	case uploadType.IsReversal():
+		return s.processPaymentReversals(ctx, tx, records, batch, uploadType, onProcessed)
+	case uploadType.IsRefund():
+		return s.processFulfilledRefunds(ctx, tx, records, batch, uploadDate, onProcessed)
+	case uploadType.IsRefundReversal():
+		return s.processRefundReversals(ctx, tx, records, batch, uploadDate, onProcessed)
+	default:
+		return nil, apierror.BadRequestError("uploadType", "Unknown upload type", nil)
+	}
+}
+
+// readRecords reads up to n records from the reader, or all remaining records if n is zero
+func readRecords(reader *csv.Reader, n int) ([][]string, error) {
+	var records [][]string
+	for n <= 0 || len(records) < n {
+		record, err := reader.Read()
+		if err != nil {
+			return records, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// countLines returns the number of data lines in the given number of records
+func countLines(uploadType shared.ReportUploadType, records int) int {
+	if uploadType.HasHeader() && records > 0 {
+		return records - 1
+	}
+	return records
+}
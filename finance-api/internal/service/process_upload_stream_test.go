@@ -0,0 +1,119 @@
+package service
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/validation"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationSuite) Test_processUploadStream() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	seeder.SeedData(
+		"INSERT INTO finance_client VALUES (1, 1, 'invoice-1', 'DEMANDED', NULL, '123456');",
+		"INSERT INTO invoice VALUES (1, 1, 1, 'AD', 'AD11223/19', '2023-04-01', '2025-03-31', 10000, NULL, '2024-03-31', 11, '2024-03-31', NULL, NULL, NULL, '2024-03-31 00:00:00', '99');",
+	)
+
+	s := Service{store: store.New(seeder.Conn), dispatch: &mockDispatch{}, tx: seeder.Conn}
+
+	jobID, err := s.CreateUploadJob(ctx, NewUploadJob{UploadType: shared.ReportTypeUploadPaymentsMOTOCard, Filename: "moto.csv", FileHash: "abc", UploadDate: shared.NewDate("2024-01-17")})
+	assert.NoError(suite.T(), err)
+
+	file := "Ordercode,Date,Amount\n" +
+		"123456,01/01/2024,20\n" +
+		"1234567890,01/01/2024,20\n" +
+		"123456,01/01/2024,20\n"
+
+	lineCount, failedLines, err := s.ProcessUploadStream(ctx, csv.NewReader(strings.NewReader(file)), UploadStream{
+		JobID:      jobID,
+		UploadType: shared.ReportTypeUploadPaymentsMOTOCard,
+		UploadDate: shared.NewDate("2024-01-17"),
+		BatchSize:  1,
+	})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 3, lineCount)
+	assert.Equal(suite.T(), map[int]string{2: validation.UploadErrorHeldInSuspense}, failedLines)
+
+	var ledgerCount int
+	_ = seeder.QueryRow(ctx, `SELECT COUNT(*) FROM ledger WHERE finance_client_id = 1`).Scan(&ledgerCount)
+	assert.Equal(suite.T(), 2, ledgerCount, "duplicate payments within the same file are processed across batches")
+
+	job, err := s.GetUploadJob(ctx, jobID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), shared.Nillable[int]{Value: 3, Valid: true}, job.ProcessedLineCount)
+}
+
+func (suite *IntegrationSuite) Test_processUploadStream_reversals() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	seeder.SeedData(
+		"INSERT INTO finance_client VALUES (1, 1, 'invoice-1', 'DEMANDED', NULL, '1010');",
+		"INSERT INTO invoice VALUES (1, 1, 1, 'AD', 'AD11223/19', '2023-04-01', '2025-03-31', 10000, NULL, '2024-03-31', 11, '2024-03-31', NULL, NULL, NULL, '2024-03-31 00:00:00', '99');",
+		"INSERT INTO ledger VALUES (1, 'payment-1', '2025-01-02 15:32:10', '', 5000, 'payment 1', 'ONLINE CARD PAYMENT', 'CONFIRMED', 1, NULL, NULL, NULL, '2025-01-02', NULL, NULL, NULL, NULL, '2025-01-02', 1);",
+		"INSERT INTO ledger_allocation VALUES (1, 1, 1, '2025-01-02 15:32:10', 5000, 'ALLOCATED', NULL, '', '2025-01-02', NULL);",
+	)
+
+	s := Service{store: store.New(seeder.Conn), dispatch: &mockDispatch{}, tx: seeder.Conn}
+
+	jobID, err := s.CreateUploadJob(ctx, NewUploadJob{UploadType: shared.ReportTypeUploadDuplicatedPayments, Filename: "duplicated.csv", FileHash: "abc"})
+	assert.NoError(suite.T(), err)
+
+	file := "Payment type,Current (errored) court reference,Bank date,Received date,Amount,PIS number (cheque only)\n" +
+		"ONLINE CARD PAYMENT,1010,02/01/2025,02/01/2025,50.00,\n" +
+		"ONLINE CARD PAYMENT,1010,02/01/2025,02/01/2025,50.00,\n"
+
+	lineCount, failedLines, err := s.ProcessUploadStream(ctx, csv.NewReader(strings.NewReader(file)), UploadStream{
+		JobID:      jobID,
+		UploadType: shared.ReportTypeUploadDuplicatedPayments,
+		BatchSize:  1,
+	})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, lineCount)
+	assert.Equal(suite.T(), map[int]string{2: validation.UploadErrorDuplicateReversal}, failedLines)
+
+	var reversalCount int
+	_ = seeder.QueryRow(ctx, `SELECT COUNT(*) FROM ledger WHERE finance_client_id = 1 AND amount < 0`).Scan(&reversalCount)
+	assert.Equal(suite.T(), 1, reversalCount, "a payment is only reversed once across batches")
+}
+
+func (suite *IntegrationSuite) Test_processUploadStream_unsupportedType() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	s := Service{store: store.New(seeder.Conn), tx: seeder.Conn}
+
+	_, _, err := s.ProcessUploadStream(ctx, csv.NewReader(strings.NewReader("")), UploadStream{UploadType: shared.ReportTypeUploadDebtChase})
+	assert.Error(suite.T(), err)
+}
+
+func Test_readRecords(t *testing.T) {
+	reader := csv.NewReader(strings.NewReader("a\nb\nc\n"))
+
+	records, err := readRecords(reader, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"a"}, {"b"}}, records)
+
+	records, err = readRecords(reader, 2)
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, [][]string{{"c"}}, records)
+
+	records, err = readRecords(csv.NewReader(strings.NewReader("a\nb\nc\n")), 0)
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Len(t, records, 3)
+}
+
+func Test_countLines(t *testing.T) {
+	assert.Equal(t, 2, countLines(shared.ReportTypeUploadPaymentsMOTOCard, 3))
+	assert.Equal(t, 0, countLines(shared.ReportTypeUploadPaymentsMOTOCard, 0))
+	assert.Equal(t, 3, countLines(shared.ReportTypeUploadDirectDebitsCollections, 3))
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+type InboundEvent struct {
+	Source     string
+	DetailType string
+	Key        string // the event ID, or a hash of its content where it has none
+	Payload    []byte
+}
+
+// StartProcessedEvent records an inbound event before it is processed. EventBridge delivers events at least once, so
+// false is returned if the event has already been processed, or is being processed, and should be acknowledged without
+// repeating its side effects. An event that previously failed is processed again, as is one that was still processing
+// after an hour, which is assumed to have been interrupted before it could complete.
+func (s *Service) StartProcessedEvent(ctx context.Context, event InboundEvent) (int32, bool, error) {
+	id, err := s.store.StartProcessedEvent(ctx, store.StartProcessedEventParams{
+		Source:     event.Source,
+		DetailType: event.DetailType,
+		EventKey:   event.Key,
+		Payload:    event.Payload,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	} else if err != nil {
+		s.Logger(ctx).Error("unable to record inbound event", "error", err)
+		return 0, false, err
+	}
+	return id, true, nil
+}
+
+// ReplayProcessedEvent marks a failed or interrupted event as being processed again and returns its original payload
+func (s *Service) ReplayProcessedEvent(ctx context.Context, id int32) ([]byte, error) {
+	payload, err := s.store.ReplayProcessedEvent(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apierror.BadRequestError("id", "Only failed or interrupted events can be replayed", err)
+	}
+	return payload, err
+}
+
+// CompleteProcessedEvent records the outcome of processing an event, along with the error if it failed
+func (s *Service) CompleteProcessedEvent(ctx context.Context, id int32, processErr error) error {
+	params := store.CompleteProcessedEventParams{
+		Status: shared.ProcessedEventStatusProcessed,
+		ID:     id,
+	}
+
+	if processErr != nil {
+		params.Status = shared.ProcessedEventStatusFailed
+		params.Error = pgtype.Text{String: processErr.Error(), Valid: true}
+	}
+
+	err := s.store.CompleteProcessedEvent(ctx, params)
+	if err != nil {
+		s.Logger(ctx).Error("unable to complete inbound event", "id", id, "error", err)
+	}
+	return err
+}
+
+func (s *Service) GetFailedProcessedEvents(ctx context.Context) (shared.ProcessedEvents, error) {
+	rows, err := s.store.GetFailedProcessedEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	events := shared.ProcessedEvents{}
+	for _, row := range rows {
+		events = append(events, shared.ProcessedEvent{
+			ID:          int(row.ID),
+			Source:      row.Source,
+			DetailType:  row.DetailType,
+			EventKey:    row.EventKey,
+			Attempts:    int(row.Attempts),
+			Error:       row.Error.String,
+			ReceivedAt:  row.ReceivedAt.Time,
+			ProcessedAt: shared.Nillable[time.Time]{Value: row.ProcessedAt.Time, Valid: row.ProcessedAt.Valid},
+		})
+	}
+
+	return events, nil
+}
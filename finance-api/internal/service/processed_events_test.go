@@ -0,0 +1,114 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationSuite) TestService_ProcessedEvents() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	s := Service{store: store.New(seeder.Conn), tx: seeder.Conn}
+
+	event := InboundEvent{
+		Source:     shared.EventSourceSirius,
+		DetailType: shared.DetailTypeInvoiceCreated,
+		Key:        "a1b2c3",
+		Payload:    []byte(`{"id":"a1b2c3","source":"opg.supervision.sirius","detail-type":"invoice-created"}`),
+	}
+
+	id, process, err := s.StartProcessedEvent(ctx, event)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), process)
+
+	// a redelivery while the event is being processed is a duplicate
+	_, process, err = s.StartProcessedEvent(ctx, event)
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), process)
+
+	err = s.CompleteProcessedEvent(ctx, id, errors.New("allpay unavailable"))
+	assert.NoError(suite.T(), err)
+
+	failed, err := s.GetFailedProcessedEvents(ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), failed, 1)
+	assert.Equal(suite.T(), int(id), failed[0].ID)
+	assert.Equal(suite.T(), "a1b2c3", failed[0].EventKey)
+	assert.Equal(suite.T(), 1, failed[0].Attempts)
+	assert.Equal(suite.T(), "allpay unavailable", failed[0].Error)
+	assert.True(suite.T(), failed[0].ProcessedAt.Valid)
+
+	// a failed event is processed again when it is redelivered
+	retryID, process, err := s.StartProcessedEvent(ctx, event)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), process)
+	assert.Equal(suite.T(), id, retryID)
+
+	err = s.CompleteProcessedEvent(ctx, id, errors.New("allpay still unavailable"))
+	assert.NoError(suite.T(), err)
+
+	payload, err := s.ReplayProcessedEvent(ctx, id)
+	assert.NoError(suite.T(), err)
+	assert.JSONEq(suite.T(), string(event.Payload), string(payload))
+
+	err = s.CompleteProcessedEvent(ctx, id, nil)
+	assert.NoError(suite.T(), err)
+
+	var (
+		status   string
+		attempts int
+	)
+	_ = seeder.QueryRow(ctx, "SELECT status, attempts FROM processed_event WHERE id = $1", id).Scan(&status, &attempts)
+	assert.Equal(suite.T(), shared.ProcessedEventStatusProcessed, status)
+	assert.Equal(suite.T(), 3, attempts)
+
+	failed, err = s.GetFailedProcessedEvents(ctx)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), failed)
+
+	// once processed, neither a redelivery nor a replay repeats it
+	_, process, err = s.StartProcessedEvent(ctx, event)
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), process)
+
+	_, err = s.ReplayProcessedEvent(ctx, id)
+	var e *apierror.BadRequest
+	assert.ErrorAs(suite.T(), err, &e)
+}
+
+func (suite *IntegrationSuite) TestService_ProcessedEvents_interrupted() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	seeder.SeedData(
+		`INSERT INTO processed_event VALUES (1, 'opg.supervision.sirius', 'invoice-created', 'stale', '{"id":"stale"}', 'PROCESSING', 1, NULL, NOW() - INTERVAL '2 hours', NOW() - INTERVAL '2 hours', NULL);`,
+		`INSERT INTO processed_event VALUES (2, 'opg.supervision.sirius', 'invoice-created', 'running', '{"id":"running"}', 'PROCESSING', 1, NULL, NOW() - INTERVAL '2 hours', NOW() - INTERVAL '5 minutes', NULL);`,
+		"ALTER SEQUENCE processed_event_id_seq RESTART WITH 3;",
+	)
+
+	s := Service{store: store.New(seeder.Conn), tx: seeder.Conn}
+
+	failed, err := s.GetFailedProcessedEvents(ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), failed, 1)
+	assert.Equal(suite.T(), 1, failed[0].ID)
+
+	// an event left processing is retried once it has timed out
+	id, process, err := s.StartProcessedEvent(ctx, InboundEvent{Source: shared.EventSourceSirius, DetailType: shared.DetailTypeInvoiceCreated, Key: "stale", Payload: []byte(`{"id":"stale"}`)})
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), process)
+	assert.Equal(suite.T(), int32(1), id)
+
+	_, process, err = s.StartProcessedEvent(ctx, InboundEvent{Source: shared.EventSourceSirius, DetailType: shared.DetailTypeInvoiceCreated, Key: "running", Payload: []byte(`{"id":"running"}`)})
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), process)
+
+	_, err = s.ReplayProcessedEvent(ctx, 2)
+	var e *apierror.BadRequest
+	assert.ErrorAs(suite.T(), err, &e)
+}
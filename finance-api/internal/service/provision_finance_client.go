@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+// ProvisionFinanceClient ensures a finance client exists for the client of a newly created order and opens a billing
+// period for the order. Existing finance clients keep their payment method but take the court reference and SOP number
+// from the event, unless the event does not include them. Redelivered events do not open a second billing period for
+// the same order.
+func (s *Service) ProvisionFinanceClient(ctx context.Context, detail shared.OrderCreatedEvent) error {
+	var (
+		courtRef  pgtype.Text
+		orderID   pgtype.Int4
+		startDate pgtype.Date
+	)
+
+	if detail.CourtRef != "" {
+		_ = courtRef.Scan(detail.CourtRef)
+	}
+	_ = orderID.Scan(int64(detail.OrderID))
+
+	if detail.OrderDate.IsNull() {
+		_ = startDate.Scan(time.Now())
+	} else {
+		_ = startDate.Scan(detail.OrderDate.Time)
+	}
+
+	tx, err := s.BeginStoreTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	financeClientID, err := tx.ProvisionFinanceClient(ctx, store.ProvisionFinanceClientParams{
+		ClientID:  detail.ClientID,
+		SopNumber: detail.SopNumber,
+		CourtRef:  courtRef,
+	})
+	if err != nil {
+		s.Logger(ctx).Error("failed to provision finance client", "clientId", detail.ClientID, "err", err)
+		return err
+	}
+
+	err = tx.CreateBillingPeriod(ctx, store.CreateBillingPeriodParams{
+		FinanceClientID: pgtype.Int4{Int32: financeClientID, Valid: true},
+		OrderID:         orderID,
+		StartDate:       startDate,
+	})
+	if err != nil {
+		s.Logger(ctx).Error("failed to create billing period", "clientId", detail.ClientID, "orderId", detail.OrderID, "err", err)
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
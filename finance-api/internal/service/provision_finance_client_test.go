@@ -0,0 +1,90 @@
+package service
+
+import (
+	"time"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationSuite) TestService_ProvisionFinanceClient() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	seeder.SeedData(
+		"INSERT INTO finance_client VALUES (100, 11, '1234', 'DIRECT DEBIT', NULL, '11111111');",
+	)
+
+	s := Service{store: store.New(seeder.Conn), tx: seeder.Conn}
+
+	type financeClient struct {
+		ClientID      int32
+		SopNumber     string
+		CourtRef      string
+		PaymentMethod string
+	}
+
+	type billingPeriod struct {
+		ClientID  int32
+		OrderID   int32
+		StartDate time.Time
+	}
+
+	// new client
+	err := s.ProvisionFinanceClient(ctx, shared.OrderCreatedEvent{
+		ClientID:  12,
+		OrderID:   21,
+		CourtRef:  "22222222",
+		SopNumber: "5678",
+		OrderDate: shared.NewDate("2025-04-01"),
+	})
+	assert.NoError(suite.T(), err)
+
+	// existing client, with the event delivered twice
+	for range 2 {
+		err = s.ProvisionFinanceClient(ctx, shared.OrderCreatedEvent{
+			ClientID:  11,
+			OrderID:   22,
+			CourtRef:  "11111111",
+			SopNumber: "4321",
+			OrderDate: shared.NewDate("2025-05-01"),
+		})
+		assert.NoError(suite.T(), err)
+	}
+
+	// existing client, with an event that does not include the court reference or SOP number
+	err = s.ProvisionFinanceClient(ctx, shared.OrderCreatedEvent{
+		ClientID:  11,
+		OrderID:   23,
+		OrderDate: shared.NewDate("2025-06-01"),
+	})
+	assert.NoError(suite.T(), err)
+
+	var clients []financeClient
+	rows, _ := seeder.Query(ctx, "SELECT client_id, sop_number, court_ref, payment_method FROM finance_client ORDER BY client_id")
+	for rows.Next() {
+		var c financeClient
+		_ = rows.Scan(&c.ClientID, &c.SopNumber, &c.CourtRef, &c.PaymentMethod)
+		clients = append(clients, c)
+	}
+
+	assert.Equal(suite.T(), []financeClient{
+		{ClientID: 11, SopNumber: "4321", CourtRef: "11111111", PaymentMethod: "DIRECT DEBIT"},
+		{ClientID: 12, SopNumber: "5678", CourtRef: "22222222", PaymentMethod: "DEMANDED"},
+	}, clients)
+
+	var periods []billingPeriod
+	rows, _ = seeder.Query(ctx, "SELECT fc.client_id, bp.order_id, bp.start_date FROM billing_period bp JOIN finance_client fc ON fc.id = bp.finance_client_id ORDER BY bp.order_id")
+	for rows.Next() {
+		var p billingPeriod
+		_ = rows.Scan(&p.ClientID, &p.OrderID, &p.StartDate)
+		periods = append(periods, p)
+	}
+
+	assert.Equal(suite.T(), []billingPeriod{
+		{ClientID: 12, OrderID: 21, StartDate: shared.NewDate("2025-04-01").Time},
+		{ClientID: 11, OrderID: 22, StartDate: shared.NewDate("2025-05-01").Time},
+		{ClientID: 11, OrderID: 23, StartDate: shared.NewDate("2025-06-01").Time},
+	}, periods)
+}
@@ -21,7 +21,7 @@ func (s *Service) QueueScheduleRemovals(ctx context.Context, schedules [][]strin
 
 		_ = courtRef.Scan(schedule[0])
 
-		_, err := s.store.GetClientIdsByCourtRef(ctx, courtRef)
+		client, err := s.store.GetClientIdsByCourtRef(ctx, courtRef)
 		if err != nil {
 			failedLines[i] = validation.UploadErrorClientNotFound
 			continue
@@ -33,11 +33,12 @@ func (s *Service) QueueScheduleRemovals(ctx context.Context, schedules [][]strin
 			continue
 		}
 
-		err = s.dispatch.ScheduleToRemove(ctx, event.ScheduleToRemove{
+		err = s.outbox(s.store).ScheduleToRemove(ctx, event.ScheduleToRemove{
 			CourtRef: schedule[0],
 			Surname:  schedule[1],
 			Amount:   amount,
 			Date:     scheduleDate,
+			ClientID: client.ClientID,
 		})
 		if err != nil {
 			s.Logger(ctx).Error("failed to queue schedule removal", "err", err, "category", "allpay")
@@ -31,11 +31,13 @@ func (suite *IntegrationSuite) Test_queueScheduleRemovals() {
 
 		assert.Len(t, failedRows, 1)
 
+		assert.NoError(t, s.RelayOutbox(suite.ctx))
 		assert.Len(t, dispatch.called, 1)
 		lastEvent := dispatch.event.(event.ScheduleToRemove)
 		assert.Equal(t, "321CBA", lastEvent.CourtRef)
 		assert.Equal(t, "Brian", lastEvent.Surname)
 		assert.Equal(t, int32(32123), lastEvent.Amount)
 		assert.Equal(t, uploadDate, lastEvent.Date)
+		assert.Equal(t, int32(11), lastEvent.ClientID)
 	})
 }
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/auth"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+// CreateReportRequest records a report request against the requesting user before the report is generated
+func (s *Service) CreateReportRequest(ctx context.Context, reportRequest shared.ReportRequest) (int32, error) {
+	params, err := json.Marshal(reportRequest)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := s.store.CreateReportRequest(ctx, store.CreateReportRequestParams{
+		ReportRequest: params,
+		RequestedBy:   ctx.(auth.Context).User.ID,
+	})
+	if err != nil {
+		s.Logger(ctx).Error("unable to record report request", "error", err)
+	}
+	return id, err
+}
+
+// CompleteReportRequest records the outcome of generating a report, along with the error if it failed. A request that
+// has already been marked as interrupted is left as it is.
+func (s *Service) CompleteReportRequest(ctx context.Context, id int32, report shared.GeneratedReport, reportErr error) error {
+	params := store.CompleteReportRequestParams{
+		Status: shared.RequestedReportStatusCompleted,
+		ID:     id,
+	}
+
+	if report.Key != "" {
+		params.S3Key = pgtype.Text{String: report.Key, Valid: true}
+	}
+
+	if reportErr != nil {
+		params.Status = shared.RequestedReportStatusFailed
+		params.Error = pgtype.Text{String: reportErr.Error(), Valid: true}
+	} else {
+		params.RowCount = pgtype.Int4{Int32: int32(report.Rows), Valid: true}
+		if report.VersionID != "" {
+			params.S3VersionID = pgtype.Text{String: report.VersionID, Valid: true}
+		}
+	}
+
+	err := s.store.CompleteReportRequest(ctx, params)
+	if err != nil {
+		s.Logger(ctx).Error("unable to complete report request", "id", id, "error", err)
+	}
+	return err
+}
+
+// InterruptReportRequests marks reports that were still being generated when the server stopped as interrupted
+func (s *Service) InterruptReportRequests(ctx context.Context, ids []int32) error {
+	interrupted, err := s.store.InterruptReportRequests(ctx, ids)
+	if err != nil {
+		s.Logger(ctx).Error("unable to interrupt report requests", "error", err)
+		return err
+	}
+	if interrupted > 0 {
+		s.Logger(ctx).Info(fmt.Sprintf("%d report requests interrupted", interrupted))
+	}
+	return nil
+}
+
+// InterruptStaleReportRequests marks reports that have been running for longer than any report takes as interrupted.
+// These were lost when a server stopped without shutting down cleanly. Reports running on other servers are left alone.
+func (s *Service) InterruptStaleReportRequests(ctx context.Context) error {
+	interrupted, err := s.store.InterruptStaleReportRequests(ctx)
+	if err != nil {
+		s.Logger(ctx).Error("unable to interrupt stale report requests", "error", err)
+		return err
+	}
+	if interrupted > 0 {
+		s.Logger(ctx).Info(fmt.Sprintf("%d stale report requests interrupted", interrupted))
+	}
+	return nil
+}
+
+// GetReportRequests returns the most recent reports requested by the current user
+func (s *Service) GetReportRequests(ctx context.Context) (shared.RequestedReports, error) {
+	rows, err := s.store.GetReportRequestsByUser(ctx, ctx.(auth.Context).User.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := shared.RequestedReports{}
+	for _, row := range rows {
+		report, err := toRequestedReport(row)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+func (s *Service) GetReportRequest(ctx context.Context, id int32) (*shared.RequestedReport, error) {
+	row, err := s.store.GetReportRequest(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apierror.NotFoundError(err)
+	} else if err != nil {
+		return nil, err
+	}
+
+	report, err := toRequestedReport(row)
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func toRequestedReport(row store.ReportRequest) (shared.RequestedReport, error) {
+	report := shared.RequestedReport{
+		ID:          int(row.ID),
+		Status:      row.Status,
+		RequestedAt: row.RequestedAt.Time,
+		RequestedBy: int(row.RequestedBy),
+		CompletedAt: shared.Nillable[time.Time]{Value: row.CompletedAt.Time, Valid: row.CompletedAt.Valid},
+		RowCount:    int(row.RowCount.Int32),
+		Key:         row.S3Key.String,
+		VersionID:   row.S3VersionID.String,
+		Error:       row.Error.String,
+	}
+
+	if row.CompletedAt.Valid {
+		report.DurationMs = row.CompletedAt.Time.Sub(row.RequestedAt.Time).Milliseconds()
+	}
+
+	if err := json.Unmarshal(row.ReportRequest, &report.ReportRequest); err != nil {
+		return shared.RequestedReport{}, err
+	}
+	return report, nil
+}
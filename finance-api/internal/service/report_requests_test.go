@@ -0,0 +1,93 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationSuite) TestService_ReportRequests() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	s := Service{store: store.New(seeder.Conn), tx: seeder.Conn}
+
+	debtType := shared.DebtTypeFeeChase
+	reportRequest := shared.ReportRequest{ReportType: shared.ReportsTypeDebt, DebtType: &debtType, Email: "test@example.com"}
+
+	completedID, err := s.CreateReportRequest(ctx, reportRequest)
+	assert.NoError(suite.T(), err)
+	failedID, _ := s.CreateReportRequest(ctx, reportRequest)
+	interruptedID, _ := s.CreateReportRequest(ctx, reportRequest)
+
+	// another user's report is not listed
+	_, _ = seeder.Exec(ctx, "INSERT INTO report_request VALUES (NEXTVAL('report_request_id_seq'), '{}', 'RUNNING', NOW(), 99)")
+
+	err = s.CompleteReportRequest(ctx, completedID, shared.GeneratedReport{Key: "debt_FeeChase_16:10:2026.csv", VersionID: "v1", Rows: 12}, nil)
+	assert.NoError(suite.T(), err)
+
+	err = s.CompleteReportRequest(ctx, failedID, shared.GeneratedReport{Key: "debt_FeeChase_16:10:2026.csv"}, errors.New("bucket unavailable"))
+	assert.NoError(suite.T(), err)
+
+	err = s.InterruptReportRequests(ctx, []int32{completedID, interruptedID})
+	assert.NoError(suite.T(), err)
+
+	// a report that completes after being interrupted stays interrupted
+	err = s.CompleteReportRequest(ctx, interruptedID, shared.GeneratedReport{Key: "debt_FeeChase_16:10:2026.csv", VersionID: "v2", Rows: 12}, nil)
+	assert.NoError(suite.T(), err)
+
+	reports, err := s.GetReportRequests(ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), reports, 3)
+
+	assert.Equal(suite.T(), int(interruptedID), reports[0].ID)
+	assert.Equal(suite.T(), shared.RequestedReportStatusInterrupted, reports[0].Status)
+	assert.True(suite.T(), reports[0].CompletedAt.Valid)
+	assert.Equal(suite.T(), "", reports[0].VersionID)
+
+	assert.Equal(suite.T(), int(failedID), reports[1].ID)
+	assert.Equal(suite.T(), shared.RequestedReportStatusFailed, reports[1].Status)
+	assert.Equal(suite.T(), "bucket unavailable", reports[1].Error)
+
+	completed, err := s.GetReportRequest(ctx, completedID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), shared.RequestedReportStatusCompleted, completed.Status)
+	assert.Equal(suite.T(), reportRequest, completed.ReportRequest)
+	assert.Equal(suite.T(), 10, completed.RequestedBy)
+	assert.Equal(suite.T(), 12, completed.RowCount)
+	assert.Equal(suite.T(), "debt_FeeChase_16:10:2026.csv", completed.Key)
+	assert.Equal(suite.T(), "v1", completed.VersionID)
+	assert.GreaterOrEqual(suite.T(), completed.DurationMs, int64(0))
+
+	_, err = s.GetReportRequest(ctx, 999)
+	var e *apierror.NotFound
+	assert.ErrorAs(suite.T(), err, &e)
+}
+
+func (suite *IntegrationSuite) TestService_InterruptStaleReportRequests() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	seeder.SeedData(
+		"INSERT INTO report_request VALUES (1, '{}', 'RUNNING', NOW() - INTERVAL '1 day', 1)",
+		"INSERT INTO report_request VALUES (2, '{}', 'RUNNING', NOW() - INTERVAL '5 minutes', 1)",
+		"INSERT INTO report_request VALUES (3, '{}', 'COMPLETED', NOW() - INTERVAL '1 day', 1)",
+	)
+
+	s := Service{store: store.New(seeder.Conn), tx: seeder.Conn}
+
+	err := s.InterruptStaleReportRequests(ctx)
+	assert.NoError(suite.T(), err)
+
+	var statuses []string
+	rows, _ := seeder.Query(ctx, "SELECT status FROM report_request ORDER BY id")
+	for rows.Next() {
+		var status string
+		_ = rows.Scan(&status)
+		statuses = append(statuses, status)
+	}
+	assert.Equal(suite.T(), []string{"INTERRUPTED", "RUNNING", "COMPLETED"}, statuses)
+}
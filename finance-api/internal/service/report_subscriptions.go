@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/auth"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+// SubscribedReport is a report to be generated for each of the recipients of a subscription
+type SubscribedReport struct {
+	ReportRequest shared.ReportRequest
+	Recipients    []string
+}
+
+// AddReportSubscription saves a report request to be run on a schedule. The email address and any relative dates are
+// set each time it runs.
+func (s *Service) AddReportSubscription(ctx context.Context, data shared.AddReportSubscription) error {
+	template := data.ReportRequest
+	template.Email = ""
+	template.TransactionDate = nil
+	template.FromDate = nil
+	template.ToDate = nil
+
+	reportRequest, err := json.Marshal(template)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.store.CreateReportSubscription(ctx, store.CreateReportSubscriptionParams{
+		Name:            data.Name,
+		ReportRequest:   reportRequest,
+		Recipients:      data.Recipients,
+		Cadence:         data.Cadence,
+		TransactionDate: relativeDateText(data.TransactionDate),
+		FromDate:        relativeDateText(data.FromDate),
+		ToDate:          relativeDateText(data.ToDate),
+		CreatedBy:       ctx.(auth.Context).User.ID,
+	})
+	if err != nil {
+		s.Logger(ctx).Error("Error creating report subscription", slog.String("err", err.Error()))
+	}
+	return err
+}
+
+func (s *Service) GetReportSubscriptions(ctx context.Context) (shared.ReportSubscriptions, error) {
+	rows, err := s.store.GetReportSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions := shared.ReportSubscriptions{}
+	for _, row := range rows {
+		subscription := shared.ReportSubscription{
+			ID:              int(row.ID),
+			Name:            row.Name,
+			Recipients:      row.Recipients,
+			Cadence:         row.Cadence,
+			TransactionDate: toRelativeDate(row.TransactionDate),
+			FromDate:        toRelativeDate(row.FromDate),
+			ToDate:          toRelativeDate(row.ToDate),
+			CreatedAt:       row.CreatedAt.Time,
+			CreatedBy:       int(row.CreatedBy),
+		}
+		if err := json.Unmarshal(row.ReportRequest, &subscription.ReportRequest); err != nil {
+			return nil, err
+		}
+		if row.LastRunOn.Valid {
+			subscription.LastRunOn = &shared.Date{Time: row.LastRunOn.Time}
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, nil
+}
+
+func (s *Service) CancelReportSubscription(ctx context.Context, id int32) error {
+	var cancelledBy pgtype.Int4
+	_ = store.ToInt4(&cancelledBy, ctx.(auth.Context).User.ID)
+
+	cancelled, err := s.store.CancelReportSubscription(ctx, store.CancelReportSubscriptionParams{
+		CancelledBy: cancelledBy,
+		ID:          id,
+	})
+	if err != nil {
+		return err
+	}
+	if cancelled == 0 {
+		return apierror.NotFoundError(fmt.Errorf("report subscription %d not found", id))
+	}
+	return nil
+}
+
+// StartReportSubscriptions returns the reports for the subscriptions due on the run date, with their relative dates
+// resolved. Each subscription is marked as run before it is returned, so it will not be run twice on the same day if the
+// trigger is repeated.
+func (s *Service) StartReportSubscriptions(ctx context.Context, runDate time.Time) ([]SubscribedReport, error) {
+	runDate = time.Date(runDate.Year(), runDate.Month(), runDate.Day(), 0, 0, 0, 0, time.UTC)
+
+	previousWorkingDay, err := s.govUK.SubWorkingDays(ctx, runDate, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	due, err := s.dueReportCadences(ctx, runDate, previousWorkingDay)
+	if err != nil || len(due) == 0 {
+		return nil, err
+	}
+
+	var date pgtype.Date
+	_ = date.Scan(runDate)
+
+	subscriptions, err := s.store.GetReportSubscriptionsToRun(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+
+	var reports []SubscribedReport
+	for _, subscription := range subscriptions {
+		if !due[subscription.Cadence] {
+			continue
+		}
+
+		var template shared.ReportRequest
+		if err := json.Unmarshal(subscription.ReportRequest, &template); err != nil {
+			s.Logger(ctx).Error(fmt.Sprintf("Unable to read report subscription %d", subscription.ID), slog.String("err", err.Error()))
+			continue
+		}
+
+		claimed, err := s.store.MarkReportSubscriptionRun(ctx, store.MarkReportSubscriptionRunParams{RunDate: date, ID: subscription.ID})
+		if err != nil {
+			return nil, err
+		}
+		if claimed == 0 {
+			continue
+		}
+
+		template.TransactionDate = resolveRelativeDate(subscription.TransactionDate, runDate, previousWorkingDay)
+		template.FromDate = resolveRelativeDate(subscription.FromDate, runDate, previousWorkingDay)
+		template.ToDate = resolveRelativeDate(subscription.ToDate, runDate, previousWorkingDay)
+
+		reports = append(reports, SubscribedReport{ReportRequest: template, Recipients: subscription.Recipients})
+	}
+
+	return reports, nil
+}
+
+// dueReportCadences works out which cadences are due on the run date. Nothing runs on a day that is not a working day;
+// weekly reports run on the first working day of the week, and month-end reports on the last working day of the month.
+func (s *Service) dueReportCadences(ctx context.Context, runDate time.Time, previousWorkingDay time.Time) (map[string]bool, error) {
+	nextWorkingDay, err := s.govUK.AddWorkingDays(ctx, runDate, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	// the run date is a working day if it follows on from the working day before it
+	followingWorkingDay, err := s.govUK.AddWorkingDays(ctx, previousWorkingDay, 1)
+	if err != nil {
+		return nil, err
+	}
+	if !followingWorkingDay.Equal(runDate) {
+		return nil, nil
+	}
+
+	runYear, runWeek := runDate.ISOWeek()
+	previousYear, previousWeek := previousWorkingDay.ISOWeek()
+
+	return map[string]bool{
+		shared.ReportCadenceDaily:    true,
+		shared.ReportCadenceWeekly:   runYear != previousYear || runWeek != previousWeek,
+		shared.ReportCadenceMonthEnd: nextWorkingDay.Month() != runDate.Month(),
+	}, nil
+}
+
+func resolveRelativeDate(relative pgtype.Text, runDate time.Time, previousWorkingDay time.Time) *shared.Date {
+	if !relative.Valid {
+		return nil
+	}
+
+	switch shared.RelativeDate(relative.String) {
+	case shared.RelativeDateRunDate:
+		return &shared.Date{Time: runDate}
+	case shared.RelativeDatePreviousWorkingDay:
+		return &shared.Date{Time: previousWorkingDay}
+	case shared.RelativeDateStartOfMonth:
+		return &shared.Date{Time: startOfMonth(runDate)}
+	default:
+		return nil
+	}
+}
+
+func relativeDateText(relative *shared.RelativeDate) pgtype.Text {
+	if relative == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: string(*relative), Valid: true}
+}
+
+func toRelativeDate(text pgtype.Text) *shared.RelativeDate {
+	if !text.Valid {
+		return nil
+	}
+	relative := shared.RelativeDate(text.String)
+	return &relative
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"time"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationSuite) TestService_ReportSubscriptions() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	saturday := time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC)
+	sunday := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	govUK := &mockGovUK{NonWorkingDays: []time.Time{saturday, sunday}}
+
+	s := Service{store: store.New(seeder.Conn), tx: seeder.Conn, govUK: govUK}
+
+	feeChase := shared.DebtTypeFeeChase
+	agedDebt := shared.AccountsReceivableTypeAgedDebt
+	transactionDate := shared.NewDate("2026-01-01")
+	previousWorkingDay := shared.RelativeDatePreviousWorkingDay
+	startOfMonth := shared.RelativeDateStartOfMonth
+	runDate := shared.RelativeDateRunDate
+
+	subscriptions := []shared.AddReportSubscription{
+		{
+			Name:            "Daily fee chase",
+			ReportRequest:   shared.ReportRequest{ReportType: shared.ReportsTypeDebt, DebtType: &feeChase, Email: "ignored@example.com", TransactionDate: &transactionDate},
+			Recipients:      []string{"finance@example.com", "debt@example.com"},
+			Cadence:         shared.ReportCadenceDaily,
+			TransactionDate: &previousWorkingDay,
+		},
+		{
+			Name:          "Weekly fee chase",
+			ReportRequest: shared.ReportRequest{ReportType: shared.ReportsTypeDebt, DebtType: &feeChase},
+			Recipients:    []string{"finance@example.com"},
+			Cadence:       shared.ReportCadenceWeekly,
+		},
+		{
+			Name:          "Month-end aged debt",
+			ReportRequest: shared.ReportRequest{ReportType: shared.ReportsTypeAccountsReceivable, AccountsReceivableType: &agedDebt},
+			Recipients:    []string{"finance@example.com"},
+			Cadence:       shared.ReportCadenceMonthEnd,
+			FromDate:      &startOfMonth,
+			ToDate:        &runDate,
+		},
+	}
+	for _, subscription := range subscriptions {
+		err := s.AddReportSubscription(ctx, subscription)
+		assert.NoError(suite.T(), err)
+	}
+
+	saved, err := s.GetReportSubscriptions(ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), saved, 3)
+	assert.Equal(suite.T(), "Daily fee chase", saved[0].Name)
+	assert.Equal(suite.T(), "", saved[0].ReportRequest.Email)
+	assert.Nil(suite.T(), saved[0].ReportRequest.TransactionDate)
+	assert.Equal(suite.T(), &previousWorkingDay, saved[0].TransactionDate)
+	assert.Equal(suite.T(), 10, saved[0].CreatedBy)
+	assert.Nil(suite.T(), saved[0].LastRunOn)
+
+	// Friday 30 October is the last working day of the month, but not the first of the week
+	reports, err := s.StartReportSubscriptions(ctx, time.Date(2026, 10, 30, 0, 0, 0, 0, time.UTC))
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), reports, 2)
+
+	assert.Equal(suite.T(), []string{"finance@example.com", "debt@example.com"}, reports[0].Recipients)
+	assert.Equal(suite.T(), shared.NewDate("2026-10-29"), *reports[0].ReportRequest.TransactionDate)
+	assert.Equal(suite.T(), &feeChase, reports[0].ReportRequest.DebtType)
+
+	assert.Equal(suite.T(), shared.NewDate("2026-10-01"), *reports[1].ReportRequest.FromDate)
+	assert.Equal(suite.T(), shared.NewDate("2026-10-30"), *reports[1].ReportRequest.ToDate)
+
+	// repeating the trigger on the same day does not run them again
+	reports, err = s.StartReportSubscriptions(ctx, time.Date(2026, 10, 30, 0, 0, 0, 0, time.UTC))
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), reports)
+
+	// nothing runs on a non-working day
+	reports, err = s.StartReportSubscriptions(ctx, saturday)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), reports)
+
+	// Monday 2 November is the first working day of the week
+	reports, err = s.StartReportSubscriptions(ctx, time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC))
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), reports, 2)
+	assert.Equal(suite.T(), shared.NewDate("2026-10-30"), *reports[0].ReportRequest.TransactionDate)
+	assert.Nil(suite.T(), reports[1].ReportRequest.TransactionDate)
+
+	saved, err = s.GetReportSubscriptions(ctx)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), shared.NewDate("2026-11-02"), *saved[0].LastRunOn)
+	assert.Equal(suite.T(), shared.NewDate("2026-10-30"), *saved[2].LastRunOn)
+
+	err = s.CancelReportSubscription(ctx, int32(saved[0].ID))
+	assert.NoError(suite.T(), err)
+
+	saved, err = s.GetReportSubscriptions(ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), saved, 2)
+
+	reports, err = s.StartReportSubscriptions(ctx, time.Date(2026, 11, 3, 0, 0, 0, 0, time.UTC))
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), reports)
+
+	err = s.CancelReportSubscription(ctx, 999)
+	var e *apierror.NotFound
+	assert.ErrorAs(suite.T(), err, &e)
+}
@@ -7,7 +7,7 @@ import (
 )
 
 func (s *Service) SendDirectDebitCollectionEvent(ctx context.Context, clientID int32, pendingCollection ScheduleData) error {
-	return s.dispatch.DirectDebitCollection(ctx, event.DirectDebitCollection{
+	return s.outbox(s.store).DirectDebitCollection(ctx, event.DirectDebitCollection{
 		ClientID:       clientID,
 		Amount:         pendingCollection.Amount,
 		CollectionDate: pendingCollection.CollectionDate,
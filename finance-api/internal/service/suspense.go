@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/auth"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+// holdPaymentInSuspense records a payment line that cannot be matched to a client, along with the line as uploaded, so
+// that it can be allocated to the correct client later
+func (s *Service) holdPaymentInSuspense(ctx context.Context, tx *store.Tx, details shared.PaymentDetails, uploadType shared.ReportUploadType, record []string) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return tx.CreateSuspense(ctx, store.CreateSuspenseParams{
+		CourtRef:     details.CourtRef,
+		Amount:       details.Amount,
+		Type:         details.LedgerType.Key(),
+		BankDate:     details.BankDate,
+		ReceivedDate: details.ReceivedDate,
+		PisNumber:    details.PisNumber,
+		UploadType:   uploadType.Key(),
+		Line:         line,
+		CreatedBy:    details.CreatedBy.Int32,
+	})
+}
+
+func (s *Service) GetSuspenseItems(ctx context.Context) (shared.SuspenseItems, error) {
+	rows, err := s.store.GetUnallocatedSuspense(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items := shared.SuspenseItems{}
+	for _, row := range rows {
+		var line []string
+		_ = json.Unmarshal(row.Line, &line)
+
+		items = append(items, shared.SuspenseItem{
+			ID:           int(row.ID),
+			CourtRef:     row.CourtRef.String,
+			Amount:       int(row.Amount),
+			LedgerType:   shared.ParseTransactionType(row.Type),
+			BankDate:     shared.Date{Time: row.BankDate.Time},
+			ReceivedDate: shared.Date{Time: row.ReceivedDate.Time},
+			PisNumber:    int(row.PisNumber.Int32),
+			UploadType:   shared.ParseUploadType(row.UploadType),
+			Line:         line,
+			CreatedDate:  shared.Date{Time: row.CreatedAt.Time},
+			CreatedBy:    int(row.CreatedBy),
+		})
+	}
+
+	return items, nil
+}
+
+// AllocateSuspenseItem posts a payment held in suspense to the client with the given court reference, as if the
+// payment had been uploaded against that court reference. If the payment was received in a period that has since been
+// closed, it is posted on the first day of the next open period instead.
+func (s *Service) AllocateSuspenseItem(ctx context.Context, id int32, allocation shared.AllocateSuspense) error {
+	tx, err := s.BeginStoreTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	item, err := tx.GetSuspenseForAllocation(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apierror.NotFoundError(err)
+	} else if err != nil {
+		return err
+	}
+
+	var (
+		courtRef    pgtype.Text
+		allocatedBy pgtype.Int4
+	)
+	_ = courtRef.Scan(allocation.CourtRef)
+	_ = store.ToInt4(&allocatedBy, ctx.(auth.Context).User.ID)
+
+	exists, err := tx.CheckClientExistsByCourtRef(ctx, courtRef)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return apierror.BadRequestError("courtRef", "Could not find a client with this court reference", nil)
+	}
+
+	bankDate, receivedDate := item.BankDate, item.ReceivedDate
+	if bankDate.Valid {
+		date, err := s.openPostingDate(ctx, tx, bankDate.Time)
+		if err != nil {
+			return err
+		}
+		_ = bankDate.Scan(date)
+	}
+	if receivedDate.Valid {
+		date, err := s.openPostingDate(ctx, tx, receivedDate.Time)
+		if err != nil {
+			return err
+		}
+		_ = receivedDate.Scan(date)
+	}
+
+	ledgerID, err := s.ProcessPaymentsUploadLine(ctx, tx, shared.PaymentDetails{
+		Amount:       item.Amount,
+		BankDate:     bankDate,
+		CourtRef:     courtRef,
+		LedgerType:   shared.ParseTransactionType(item.Type),
+		ReceivedDate: receivedDate,
+		CreatedBy:    allocatedBy,
+		PisNumber:    item.PisNumber,
+	})
+	if err != nil {
+		s.Logger(ctx).Error("failed to allocate suspense item", "id", id, "err", err)
+		return err
+	}
+
+	var lID pgtype.Int4
+	_ = store.ToInt4(&lID, ledgerID)
+
+	err = tx.AllocateSuspense(ctx, store.AllocateSuspenseParams{
+		AllocatedCourtRef: courtRef,
+		LedgerID:          lID,
+		AllocatedBy:       allocatedBy,
+		ID:                id,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
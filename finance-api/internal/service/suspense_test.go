@@ -0,0 +1,75 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/validation"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationSuite) TestService_Suspense() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	seeder.SeedData(
+		"INSERT INTO finance_client VALUES (1, 1, 'invoice-1', 'DEMANDED', NULL, '1234');",
+		"INSERT INTO invoice VALUES (1, 1, 1, 'AD', 'AD11223/19', '2023-04-01', '2025-03-31', 10000, NULL, '2024-03-31', 11, '2024-03-31', NULL, NULL, NULL, '2024-03-31 00:00:00', '99');",
+		"ALTER SEQUENCE ledger_id_seq RESTART WITH 2;",
+		"ALTER SEQUENCE ledger_allocation_id_seq RESTART WITH 2;",
+	)
+
+	s := Service{store: store.New(seeder.Conn), dispatch: &mockDispatch{}, tx: seeder.Conn}
+
+	records := [][]string{
+		{"Ordercode", "Date", "Amount"},
+		{"9999", "01/01/2024", "100.00"},
+	}
+
+	failedLines, err := s.ProcessPayments(ctx, records, shared.ReportTypeUploadPaymentsMOTOCard, shared.NewDate("2024-01-01"), 0)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), map[int]string{1: validation.UploadErrorHeldInSuspense}, failedLines)
+
+	items, err := s.GetSuspenseItems(ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), items, 1)
+	assert.Equal(suite.T(), "9999", items[0].CourtRef)
+	assert.Equal(suite.T(), 10000, items[0].Amount)
+	assert.Equal(suite.T(), shared.TransactionTypeMotoCardPayment, items[0].LedgerType)
+	assert.Equal(suite.T(), records[1], items[0].Line)
+
+	suite.T().Run("unknown suspense item", func(t *testing.T) {
+		err := s.AllocateSuspenseItem(ctx, 99, shared.AllocateSuspense{CourtRef: "1234"})
+		assert.ErrorAs(t, err, &apierror.NotFound{})
+	})
+
+	suite.T().Run("unknown court reference", func(t *testing.T) {
+		err := s.AllocateSuspenseItem(ctx, int32(items[0].ID), shared.AllocateSuspense{CourtRef: "8888"})
+		assert.ErrorAs(t, err, &apierror.BadRequest{})
+	})
+
+	suite.T().Run("allocates to the client", func(t *testing.T) {
+		err := s.AllocateSuspenseItem(ctx, int32(items[0].ID), shared.AllocateSuspense{CourtRef: "1234"})
+		assert.NoError(t, err)
+
+		var (
+			ledgerAmount    int
+			financeClientID int
+			allocatedAt     time.Time
+		)
+		row := seeder.QueryRow(ctx, "SELECT l.amount, l.finance_client_id, s.allocated_at FROM suspense s JOIN ledger l ON l.id = s.ledger_id WHERE s.id = $1", items[0].ID)
+		assert.NoError(t, row.Scan(&ledgerAmount, &financeClientID, &allocatedAt))
+		assert.Equal(t, 10000, ledgerAmount)
+		assert.Equal(t, 1, financeClientID)
+
+		remaining, err := s.GetSuspenseItems(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, remaining)
+
+		err = s.AllocateSuspenseItem(ctx, int32(items[0].ID), shared.AllocateSuspense{CourtRef: "1234"})
+		assert.ErrorAs(t, err, &apierror.NotFound{})
+	})
+}
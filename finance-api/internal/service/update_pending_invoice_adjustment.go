@@ -2,10 +2,14 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/auth"
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
@@ -22,19 +26,42 @@ func (s *Service) UpdatePendingInvoiceAdjustment(ctx context.Context, clientId i
 	_ = store.ToInt4(&updatedBy, ctx.(auth.Context).User.ID)
 
 	decisionParams := store.SetAdjustmentDecisionParams{
-		ID:        adjustmentId,
 		Status:    status.Key(),
 		UpdatedBy: updatedBy,
+		ID:        adjustmentId,
+		ClientID:  clientId,
+	}
+
+	current, err := tx.GetInvoiceAdjustmentStatus(ctx, adjustmentId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apierror.BadRequestError("adjustmentId", "Adjustment not found", err)
+	} else if err != nil {
+		s.Logger(ctx).Error(fmt.Sprintf("Get adjustment status in updating invoice adjustment has an issue %s for client %d", err.Error(), clientId))
+		return err
 	}
 
+	// the decision is only recorded against a pending adjustment belonging to the client, so one that has already been
+	// decided, or that was listed against a different client, is rejected rather than decided again
 	adjustment, err := tx.SetAdjustmentDecision(ctx, decisionParams)
-	if err != nil {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apierror.BadRequestError("adjustmentId", "Adjustment not found or is no longer pending", err)
+	} else if err != nil {
 		s.Logger(ctx).Error(fmt.Sprintf("Set adjustment decision in updating invoice adjustment has an issue %s for client %d", err.Error(), clientId))
 
 		return err
 	}
 
+	err = s.checkFourEyes(ctx, tx, "adjustment", decisionVerb(status == shared.AdjustmentStatusApproved), clientId, shared.AuditEntityInvoiceAdjustment, adjustmentId, adjustment.CreatedBy)
+	if err != nil {
+		return err
+	}
+
 	if status == shared.AdjustmentStatusApproved {
+		err = s.checkPostingPeriod(ctx, tx, time.Now())
+		if err != nil {
+			return err
+		}
+
 		ledger, allocations := generateLedgerEntries(ctx, addLedgerVars{
 			amount:             adjustment.Amount,
 			transactionType:    shared.ParseAdjustmentType(adjustment.AdjustmentType),
@@ -73,5 +100,17 @@ func (s *Service) UpdatePendingInvoiceAdjustment(ctx context.Context, clientId i
 		}
 	}
 
+	err = s.audit(ctx, tx, auditEntry{
+		clientId:   clientId,
+		action:     shared.AuditActionInvoiceAdjustmentDecision,
+		entityType: shared.AuditEntityInvoiceAdjustment,
+		entityId:   adjustmentId,
+		before:     map[string]string{"status": current},
+		after:      map[string]string{"status": status.Key()},
+	})
+	if err != nil {
+		return err
+	}
+
 	return tx.Commit(ctx)
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/auth"
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
@@ -176,7 +177,7 @@ func (suite *IntegrationSuite) Test_setAdjustmentDecision_LinkedToNonConfirmedLe
 			_ = store.ToInt4(&updatedBy, ctx.(auth.Context).User.ID)
 
 			adjustment, _ := tx.SetAdjustmentDecision(ctx, store.SetAdjustmentDecisionParams{
-				ID: 1, Status: "ALLOCATED", UpdatedBy: updatedBy,
+				ID: 1, ClientID: 1, Status: "ALLOCATED", UpdatedBy: updatedBy,
 			})
 
 			assert.Equal(suite.T(), int32(1), adjustment.InvoiceID)
@@ -196,7 +197,7 @@ func (suite *IntegrationSuite) Test_setAdjustmentDecision_LinkedToConfirmedLedge
 			"INSERT INTO finance_client VALUES (1, 1, '1234', 'DEMANDED', NULL);",
 			"INSERT INTO invoice VALUES (1, 1, 1, 'S2', 'reject', '2019-04-01', '2020-03-31', 12300, NULL, '2020-03-20',1, '2020-03-16', 10, NULL, NULL, '2019-06-06', NULL);",
 			"INSERT INTO ledger VALUES (NEXTVAL('ledger_id_seq'), 'fully-paid', '2022-04-11T00:00:00+00:00', '', 12300, '', 'ONLINE CARD PAYMENT', 'CONFIRMED', 1, NULL, NULL, '11/04/2022', '12/04/2022', 1254, '', '', 1, '05/05/2022', 2);",
-			"INSERT INTO invoice_adjustment VALUES (NEXTVAL('invoice_adjustment_id_seq'), 1, 1, '2024-01-01', 'CREDIT MEMO', '5000', 'reject me', 'CONFIRMED', '2022-04-11T00:00:00+00:00', 1, null, null, CURRVAL('ledger_id_seq'));",
+			"INSERT INTO invoice_adjustment VALUES (NEXTVAL('invoice_adjustment_id_seq'), 1, 1, '2024-01-01', 'CREDIT MEMO', '5000', 'reject me', 'PENDING', '2022-04-11T00:00:00+00:00', 1, null, null, CURRVAL('ledger_id_seq'));",
 			"INSERT INTO ledger_allocation VALUES (1, CURRVAL('ledger_id_seq'), 1, '2024-01-01 15:30:27', 10000, 'ALLOCATED', NULL, '', '2024-01-01', NULL)",
 		)
 
@@ -207,7 +208,7 @@ func (suite *IntegrationSuite) Test_setAdjustmentDecision_LinkedToConfirmedLedge
 			_ = store.ToInt4(&updatedBy, ctx.(auth.Context).User.ID)
 
 			adjustment, _ := tx.SetAdjustmentDecision(ctx, store.SetAdjustmentDecisionParams{
-				ID: 1, Status: "ALLOCATED", UpdatedBy: updatedBy,
+				ID: 1, ClientID: 1, Status: "ALLOCATED", UpdatedBy: updatedBy,
 			})
 
 			assert.Equal(suite.T(), int32(1), adjustment.InvoiceID)
@@ -217,3 +218,43 @@ func (suite *IntegrationSuite) Test_setAdjustmentDecision_LinkedToConfirmedLedge
 		})
 	}
 }
+
+func (suite *IntegrationSuite) TestService_UpdatePendingInvoiceAdjustment_fourEyes() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	seeder.SeedData(
+		"INSERT INTO finance_client VALUES (1, 1, '1234', 'DEMANDED', NULL);",
+		"INSERT INTO invoice VALUES (1, 1, 1, 'S2', 'S200001/19', '2019-04-01', '2020-03-31', 12300, NULL, '2020-03-20',1, '2020-03-16', 10, NULL, NULL, '2019-06-06', NULL);",
+		"INSERT INTO invoice_adjustment VALUES (1, 1, 1, '2024-01-01', 'CREDIT MEMO', '5000', 'my own credit', 'PENDING', '2024-01-01', 10)",
+	)
+
+	s := Service{store: store.New(seeder.Conn), dispatch: &mockDispatch{}, tx: seeder.Conn}
+
+	err := s.UpdatePendingInvoiceAdjustment(ctx, 1, 1, shared.AdjustmentStatusApproved)
+
+	var e *apierror.BadRequest
+	assert.ErrorAs(suite.T(), err, &e)
+	assert.Equal(suite.T(), "This adjustment cannot be approved by the user who created it", e.Reason)
+
+	var status string
+	_ = seeder.QueryRow(ctx, "SELECT status FROM invoice_adjustment WHERE id = 1").Scan(&status)
+	assert.Equal(suite.T(), "PENDING", status)
+
+	err = s.UpdatePendingInvoiceAdjustment(ctx, 1, 1, shared.AdjustmentStatusRejected)
+	assert.ErrorAs(suite.T(), err, &e)
+	assert.Equal(suite.T(), "This adjustment cannot be rejected by the user who created it", e.Reason)
+
+	_ = seeder.QueryRow(ctx, "SELECT status FROM invoice_adjustment WHERE id = 1").Scan(&status)
+	assert.Equal(suite.T(), "PENDING", status)
+
+	override := auth.Context{
+		Context: ctx.(auth.Context).Context,
+		User:    &shared.User{ID: 10, Roles: []string{shared.RoleFinanceManager, shared.RoleFinanceDecisionOverride}},
+	}
+	err = s.UpdatePendingInvoiceAdjustment(override, 1, 1, shared.AdjustmentStatusRejected)
+	assert.NoError(suite.T(), err)
+
+	_ = seeder.QueryRow(ctx, "SELECT status FROM invoice_adjustment WHERE id = 1").Scan(&status)
+	assert.Equal(suite.T(), "REJECTED", status)
+}
@@ -29,6 +29,12 @@ func (s *Service) UpdateRefundDecision(ctx context.Context, clientId int32, refu
 	_ = store.ToInt4(&refundID, refundId)
 	_ = decision.Scan(status.Key())
 
+	current, err := tx.GetRefundStatus(ctx, store.GetRefundStatusParams{ClientID: clientId, RefundID: refundId})
+	if err != nil {
+		s.Logger(ctx).Error(fmt.Sprintf("Get refund status for client %d has error %s", clientId, err.Error()))
+		return err
+	}
+
 	switch decision.String {
 	case shared.RefundStatusCancelled.Key():
 		err = tx.CancelRefund(ctx, store.CancelRefundParams{
@@ -44,7 +50,11 @@ func (s *Service) UpdateRefundDecision(ctx context.Context, clientId int32, refu
 			ClientID:   clientID,
 			RefundID:   refundID,
 		}
-		err = tx.SetRefundDecision(ctx, decisionParams)
+		var createdBy int32
+		createdBy, err = tx.SetRefundDecision(ctx, decisionParams)
+		if err == nil {
+			err = s.checkFourEyes(ctx, tx, "refund", decisionVerb(status == shared.RefundStatusApproved), clientId, shared.AuditEntityRefund, refundId, createdBy)
+		}
 	default:
 		err = errors.New("unknown decision type: " + decision.String)
 	}
@@ -60,6 +70,18 @@ func (s *Service) UpdateRefundDecision(ctx context.Context, clientId int32, refu
 		return err
 	}
 
+	err = s.audit(ctx, tx, auditEntry{
+		clientId:   clientId,
+		action:     shared.AuditActionRefundDecision,
+		entityType: shared.AuditEntityRefund,
+		entityId:   refundId,
+		before:     map[string]string{"status": current},
+		after:      map[string]string{"status": status.Key()},
+	})
+	if err != nil {
+		return err
+	}
+
 	return tx.Commit(ctx)
 }
 
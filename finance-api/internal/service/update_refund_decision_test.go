@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/auth"
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
 	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
 	"github.com/stretchr/testify/assert"
@@ -115,3 +117,51 @@ func (suite *IntegrationSuite) TestService_UpdateRefundDecision() {
 		})
 	}
 }
+
+func (suite *IntegrationSuite) TestService_UpdateRefundDecision_fourEyes() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	seeder.SeedData(
+		"INSERT INTO finance_client VALUES (2, 1, 'findme', 'DEMANDED', 1)",
+		"INSERT INTO refund VALUES (1, 2, '2019-01-27', 12300, 'PENDING', '', 10, '2025-06-04 00:00:00')",
+		"INSERT INTO bank_details VALUES (1, 1, 'Clint Client', '12345678', '11-22-33');",
+	)
+
+	s := Service{store: store.New(seeder.Conn), tx: seeder.Conn}
+
+	err := s.UpdateRefundDecision(ctx, 1, 1, shared.RefundStatusApproved)
+
+	var e *apierror.BadRequest
+	assert.ErrorAs(suite.T(), err, &e)
+
+	var decision string
+	_ = seeder.QueryRow(ctx, "SELECT decision FROM refund WHERE id = 1").Scan(&decision)
+	assert.Equal(suite.T(), "PENDING", decision)
+
+	err = s.UpdateRefundDecision(ctx, 1, 1, shared.RefundStatusRejected)
+	assert.ErrorAs(suite.T(), err, &e)
+	assert.Equal(suite.T(), "This refund cannot be rejected by the user who created it", e.Reason)
+
+	_ = seeder.QueryRow(ctx, "SELECT decision FROM refund WHERE id = 1").Scan(&decision)
+	assert.Equal(suite.T(), "PENDING", decision)
+
+	override := auth.Context{
+		Context: ctx.(auth.Context).Context,
+		User:    &shared.User{ID: 10, Roles: []string{shared.RoleFinanceManager, shared.RoleFinanceDecisionOverride}},
+	}
+	err = s.UpdateRefundDecision(override, 1, 1, shared.RefundStatusApproved)
+	assert.NoError(suite.T(), err)
+
+	_ = seeder.QueryRow(ctx, "SELECT decision FROM refund WHERE id = 1").Scan(&decision)
+	assert.Equal(suite.T(), "APPROVED", decision)
+
+	logs, err := s.GetAuditLogs(ctx, 1)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), logs, 2)
+	assert.Equal(suite.T(), shared.AuditActionRefundDecision, logs[0].Action)
+	assert.Equal(suite.T(), shared.AuditActionDecisionOverride, logs[1].Action)
+	assert.Equal(suite.T(), shared.AuditEntityRefund, logs[1].EntityType)
+	assert.Equal(suite.T(), 1, logs[1].EntityID)
+	assert.Equal(suite.T(), map[string]string{"createdBy": "10", "approvedBy": "10"}, logs[1].After)
+}
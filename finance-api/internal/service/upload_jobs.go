@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/auth"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+type UploadJobResult struct {
+	LineCount   *int
+	FailedLines map[int]string
+	Err         error
+}
+
+type NewUploadJob struct {
+	UploadType   shared.ReportUploadType
+	Filename     string
+	FileHash     string
+	UploadDate   shared.Date
+	EmailAddress string
+}
+
+// CreateUploadJob records the start of an upload, so that uploads which never complete can still be traced. Uploads that
+// post ledgers are rejected if the same file has already been uploaded, unless the previous upload failed or never
+// completed.
+func (s *Service) CreateUploadJob(ctx context.Context, job NewUploadJob) (int32, error) {
+	var (
+		uploadDate   pgtype.Date
+		emailAddress pgtype.Text
+	)
+
+	if !job.UploadDate.IsNull() {
+		_ = uploadDate.Scan(job.UploadDate.Time)
+	}
+	_ = emailAddress.Scan(job.EmailAddress)
+
+	tx, err := s.BeginStoreTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	if job.UploadType.PostsLedgers() {
+		err = s.checkDuplicateUpload(ctx, tx, job)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	id, err := tx.CreateUploadJob(ctx, store.CreateUploadJobParams{
+		UploadType:   job.UploadType.Key(),
+		Filename:     job.Filename,
+		FileHash:     job.FileHash,
+		CreatedBy:    ctx.(auth.Context).User.ID,
+		UploadDate:   uploadDate,
+		EmailAddress: emailAddress,
+		PostsLedgers: job.UploadType.PostsLedgers(),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		// the same file was uploaded concurrently and the unique index rejected this one
+		err = s.checkDuplicateUpload(ctx, tx, job)
+		if err == nil {
+			err = apierror.BadRequestError("upload", "This file is already being uploaded", nil)
+		}
+		return 0, err
+	}
+	if err != nil {
+		s.Logger(ctx).Error("unable to create upload job", "error", err)
+		return 0, err
+	}
+
+	return id, tx.Commit(ctx)
+}
+
+// checkDuplicateUpload returns a bad request if the file has already been uploaded. Uploads still processing after
+// the timeout are assumed to have been interrupted and are marked as failed, so that the file can be uploaded again.
+func (s *Service) checkDuplicateUpload(ctx context.Context, tx *store.Tx, job NewUploadJob) error {
+	err := tx.FailStaleUploadJobs(ctx, store.FailStaleUploadJobsParams{
+		FileHash:   job.FileHash,
+		UploadType: job.UploadType.Key(),
+	})
+	if err != nil {
+		s.Logger(ctx).Error("unable to fail stale upload jobs", "error", err)
+		return err
+	}
+
+	processed, err := tx.GetProcessedUploadJob(ctx, store.GetProcessedUploadJobParams{
+		FileHash:   job.FileHash,
+		UploadType: job.UploadType.Key(),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	} else if err != nil {
+		s.Logger(ctx).Error("unable to check for previous uploads", "error", err)
+		return err
+	}
+
+	return apierror.BadRequestError("upload", fmt.Sprintf(
+		"This file has already been uploaded by %s on %s",
+		uploaderName(processed),
+		processed.StartedAt.Time.Format("02/01/2006 at 15:04"),
+	), nil)
+}
+
+func uploaderName(job store.GetProcessedUploadJobRow) string {
+	if job.EmailAddress.String != "" {
+		return job.EmailAddress.String
+	}
+	return fmt.Sprintf("user %d", job.CreatedBy)
+}
+
+func (s *Service) CompleteUploadJob(ctx context.Context, id int32, result UploadJobResult) error {
+	params := store.CompleteUploadJobParams{
+		Status:          shared.UploadJobStatusCompleted,
+		FailedLineCount: pgtype.Int4{Int32: int32(len(result.FailedLines)), Valid: true},
+		ID:              id,
+	}
+
+	if result.LineCount != nil {
+		params.LineCount = pgtype.Int4{Int32: int32(*result.LineCount), Valid: true}
+	}
+
+	if len(result.FailedLines) > 0 {
+		failedLines, err := json.Marshal(result.FailedLines)
+		if err != nil {
+			return err
+		}
+		params.FailedLines = failedLines
+	}
+
+	if result.Err != nil {
+		params.Status = shared.UploadJobStatusFailed
+		_ = params.Error.Scan(result.Err.Error())
+	}
+
+	err := s.store.CompleteUploadJob(ctx, params)
+	if err != nil {
+		s.Logger(ctx).Error("unable to complete upload job", "id", id, "error", err)
+	}
+	return err
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/validation"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationSuite) Test_uploadJobs() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	s := Service{store: store.New(seeder.Conn), tx: seeder.Conn}
+
+	completedID, err := s.CreateUploadJob(ctx, NewUploadJob{UploadType: shared.ReportTypeUploadPaymentsMOTOCard, Filename: "moto.csv", FileHash: "abc", UploadDate: shared.NewDate("2025-01-02"), EmailAddress: "moto@example.com"})
+	assert.NoError(suite.T(), err)
+
+	lineCount := 3
+	err = s.CompleteUploadJob(ctx, completedID, UploadJobResult{
+		LineCount:   &lineCount,
+		FailedLines: map[int]string{2: validation.UploadErrorClientNotFound},
+	})
+	assert.NoError(suite.T(), err)
+
+	failedID, err := s.CreateUploadJob(ctx, NewUploadJob{UploadType: shared.ReportTypeUploadFulfilledRefunds, Filename: "refunds.csv", FileHash: "def"})
+	assert.NoError(suite.T(), err)
+
+	err = s.CompleteUploadJob(ctx, failedID, UploadJobResult{Err: errors.New("unable to read report")})
+	assert.NoError(suite.T(), err)
+
+	processingID, err := s.CreateUploadJob(ctx, NewUploadJob{UploadType: shared.ReportTypeUploadBouncedCheque, Filename: "cheques.csv", FileHash: "ghi"})
+	assert.NoError(suite.T(), err)
+
+	completed, err := s.GetUploadJob(ctx, completedID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), shared.ReportTypeUploadPaymentsMOTOCard, completed.UploadType)
+	assert.Equal(suite.T(), "moto.csv", completed.Filename)
+	assert.Equal(suite.T(), "abc", completed.FileHash)
+	assert.Equal(suite.T(), shared.Nillable[shared.Date]{Value: shared.NewDate("2025-01-02"), Valid: true}, completed.UploadDate)
+	assert.Equal(suite.T(), "moto@example.com", completed.EmailAddress)
+	assert.Equal(suite.T(), shared.UploadJobStatusCompleted, completed.Status)
+	assert.Equal(suite.T(), shared.Nillable[int]{Value: 3, Valid: true}, completed.LineCount)
+	assert.Equal(suite.T(), shared.Nillable[int]{Value: 1, Valid: true}, completed.FailedLineCount)
+	assert.Equal(suite.T(), map[int]string{2: validation.UploadErrorClientNotFound}, completed.FailedLines)
+	assert.True(suite.T(), completed.EndedAt.Valid)
+	assert.WithinDuration(suite.T(), time.Now(), completed.StartedAt, time.Minute)
+
+	failed, err := s.GetUploadJob(ctx, failedID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), shared.UploadJobStatusFailed, failed.Status)
+	assert.Equal(suite.T(), "unable to read report", failed.Error)
+	assert.False(suite.T(), failed.LineCount.Valid)
+
+	jobs, err := s.GetUploadJobs(ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), jobs, 3)
+	assert.Equal(suite.T(), int(processingID), jobs[0].ID)
+	assert.Equal(suite.T(), shared.UploadJobStatusProcessing, jobs[0].Status)
+	assert.False(suite.T(), jobs[0].EndedAt.Valid)
+}
+
+func (suite *IntegrationSuite) Test_createUploadJob_duplicateFile() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	seeder.SeedData(
+		"INSERT INTO upload_job VALUES (1, 'PAYMENTS_MOTO_CARD', 'moto.csv', 'abc', 'COMPLETED', 1, 0, NULL, NULL, '2025-01-02 09:30:00', '2025-01-02 09:31:00', 1, '2025-01-02', 'first@example.com');",
+		"INSERT INTO upload_job VALUES (2, 'FULFILLED_REFUNDS', 'refunds.csv', 'def', 'FAILED', NULL, 0, NULL, 'unable to read report', '2025-01-02 09:30:00', '2025-01-02 09:31:00', 1, '2025-01-02', 'first@example.com');",
+		"INSERT INTO upload_job VALUES (3, 'REMOVE_SCHEDULES', 'schedules.csv', 'ghi', 'COMPLETED', 1, 0, NULL, NULL, '2025-01-02 09:30:00', '2025-01-02 09:31:00', 1, '2025-01-02', 'first@example.com');",
+		"INSERT INTO upload_job VALUES (4, 'BOUNCED_CHEQUE', 'cheques.csv', 'jkl', 'PROCESSING', NULL, NULL, NULL, NULL, NOW() - INTERVAL '1 hour', NULL, 1, NULL, NULL);",
+		"INSERT INTO upload_job VALUES (5, 'BOUNCED_CHEQUE', 'cheques.csv', 'mno', 'PROCESSING', NULL, NULL, NULL, NULL, '2025-01-02 09:30:00', NULL, 1, NULL, NULL);",
+		"ALTER SEQUENCE upload_job_id_seq RESTART WITH 6;",
+	)
+
+	s := Service{store: store.New(seeder.Conn), tx: seeder.Conn}
+
+	tests := []struct {
+		name    string
+		job     NewUploadJob
+		wantErr string
+	}{
+		{
+			name:    "same file and date",
+			job:     NewUploadJob{UploadType: shared.ReportTypeUploadPaymentsMOTOCard, FileHash: "abc", UploadDate: shared.NewDate("2025-01-02")},
+			wantErr: "This file has already been uploaded by first@example.com on 02/01/2025 at 09:30",
+		},
+		{
+			name:    "same file on a different date",
+			job:     NewUploadJob{UploadType: shared.ReportTypeUploadPaymentsMOTOCard, FileHash: "abc", UploadDate: shared.NewDate("2025-01-03")},
+			wantErr: "This file has already been uploaded by first@example.com on 02/01/2025 at 09:30",
+		},
+		{
+			name:    "previous upload still processing",
+			job:     NewUploadJob{UploadType: shared.ReportTypeUploadBouncedCheque, FileHash: "jkl"},
+			wantErr: "This file has already been uploaded by user 1 on ",
+		},
+		{
+			name: "previous upload did not complete",
+			job:  NewUploadJob{UploadType: shared.ReportTypeUploadBouncedCheque, FileHash: "mno"},
+		},
+		{
+			name: "same file as a different upload type",
+			job:  NewUploadJob{UploadType: shared.ReportTypeUploadPaymentsOnlineCard, FileHash: "abc", UploadDate: shared.NewDate("2025-01-02")},
+		},
+		{
+			name: "previous upload failed",
+			job:  NewUploadJob{UploadType: shared.ReportTypeUploadFulfilledRefunds, FileHash: "def", UploadDate: shared.NewDate("2025-01-02")},
+		},
+		{
+			name: "does not post ledgers",
+			job:  NewUploadJob{UploadType: shared.ReportTypeUploadRemoveSchedules, FileHash: "ghi", UploadDate: shared.NewDate("2025-01-02")},
+		},
+	}
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			_, err := s.CreateUploadJob(ctx, tt.job)
+			if tt.wantErr != "" {
+				var e *apierror.BadRequest
+				assert.ErrorAs(t, err, &e)
+				assert.Contains(t, e.Reason, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	stale, err := s.GetUploadJob(ctx, 5)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), shared.UploadJobStatusFailed, stale.Status)
+	assert.Equal(suite.T(), "Upload did not complete", stale.Error)
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/auth"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/event"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+// VoidInvoice cancels an invoice raised in error. A single void ledger clears the outstanding balance and unapplies any
+// money paid towards the invoice, so that it becomes customer credit and is reapplied to other open invoices. Any
+// pending adjustments on the invoice are rejected. Pending Direct Debit collections are for the client's whole balance,
+// so they are removed and the remaining balance rescheduled.
+func (s *Service) VoidInvoice(ctx context.Context, clientId int32, invoiceId int32, void shared.VoidInvoice) error {
+	tx, err := s.BeginStoreTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = s.checkPostingPeriod(ctx, tx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	invoice, err := tx.GetInvoiceForVoid(ctx, store.GetInvoiceForVoidParams{ID: invoiceId, ClientID: clientId})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apierror.NotFoundError(err)
+	} else if err != nil {
+		return err
+	}
+
+	if invoice.Voided {
+		return apierror.BadRequestError("invoice", fmt.Sprintf("Invoice %s has already been voided", invoice.Reference), nil)
+	}
+
+	var invoiceID pgtype.Int4
+	_ = store.ToInt4(&invoiceID, invoiceId)
+
+	totals, err := tx.GetInvoiceAllocationTotals(ctx, invoiceID)
+	if err != nil {
+		return err
+	}
+
+	received, paid := getVoidAmounts(totals)
+
+	var (
+		userID       pgtype.Int4
+		voidReason   pgtype.Text
+		voidLedgerID pgtype.Int4
+	)
+	_ = store.ToInt4(&userID, ctx.(auth.Context).User.ID)
+	_ = voidReason.Scan(void.VoidReason)
+
+	allocations := voidAllocations(invoiceID, invoice.Amount-received, paid)
+	if len(allocations) > 0 {
+		var notes pgtype.Text
+		_ = notes.Scan(fmt.Sprintf("Invoice %s voided", invoice.Reference))
+
+		ledgerID, err := tx.CreateLedger(ctx, store.CreateLedgerParams{
+			ClientID:  clientId,
+			Amount:    invoice.Amount - received,
+			Notes:     notes,
+			Type:      shared.TransactionTypeInvoiceVoid.Key(),
+			Status:    "CONFIRMED",
+			CreatedBy: userID,
+		})
+		if err != nil {
+			s.Logger(ctx).Error(fmt.Sprintf("Error creating void ledger for invoice %d", invoiceId), slog.String("err", err.Error()))
+			return err
+		}
+
+		for _, allocation := range allocations {
+			allocation.LedgerID = ledgerID
+			err = tx.CreateLedgerAllocation(ctx, allocation)
+			if err != nil {
+				s.Logger(ctx).Error(fmt.Sprintf("Error creating void ledger allocation for invoice %d", invoiceId), slog.String("err", err.Error()))
+				return err
+			}
+		}
+
+		_ = store.ToInt4(&voidLedgerID, ledgerID)
+	}
+
+	err = tx.VoidInvoice(ctx, store.VoidInvoiceParams{
+		VoidedBy:     userID,
+		VoidReason:   voidReason,
+		VoidLedgerID: voidLedgerID,
+		ID:           invoiceId,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = tx.RejectPendingAdjustmentsForInvoice(ctx, store.RejectPendingAdjustmentsForInvoiceParams{
+		UpdatedBy: userID,
+		InvoiceID: invoiceId,
+	})
+	if err != nil {
+		return err
+	}
+
+	collections, err := tx.GetPendingCollections(ctx, clientId)
+	if err != nil {
+		return err
+	}
+
+	err = s.PostLedgerActions(ctx, clientId, tx)
+	if err != nil {
+		return err
+	}
+
+	// schedules are removed in Allpay asynchronously, once the void has been committed, and the client is rescheduled
+	// after the last one is removed
+	err = s.removeSchedulesForVoidedInvoice(ctx, tx, clientId, collections)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// getVoidAmounts returns the total received against an invoice, and the part of it that was money paid by the client
+// rather than a reduction such as a fee reduction or credit memo.
+func getVoidAmounts(totals []store.GetInvoiceAllocationTotalsRow) (received int32, paid int32) {
+	for _, t := range totals {
+		received += t.Amount
+		if shared.ParseTransactionType(t.Type).IsPayment() || t.Status == "UNAPPLIED" || t.Status == "REAPPLIED" {
+			paid += t.Amount
+		}
+	}
+	return received, paid
+}
+
+// voidAllocations brings the invoice's outstanding balance to zero and moves any money paid to customer credit
+func voidAllocations(invoiceID pgtype.Int4, outstanding int32, paid int32) []store.CreateLedgerAllocationParams {
+	var allocations []store.CreateLedgerAllocationParams
+
+	if outstanding+paid != 0 {
+		allocations = append(allocations, store.CreateLedgerAllocationParams{
+			InvoiceID: invoiceID,
+			Amount:    outstanding + paid,
+			Status:    "ALLOCATED",
+		})
+	}
+
+	if paid != 0 {
+		var notes pgtype.Text
+		_ = notes.Scan("Unapplied funds as a result of voiding the invoice")
+
+		allocations = append(allocations, store.CreateLedgerAllocationParams{
+			InvoiceID: invoiceID,
+			Amount:    -paid,
+			Status:    "UNAPPLIED",
+			Notes:     notes,
+		})
+	}
+
+	return allocations
+}
+
+func (s *Service) removeSchedulesForVoidedInvoice(ctx context.Context, tx *store.Tx, clientId int32, collections []store.GetPendingCollectionsRow) error {
+	if len(collections) == 0 {
+		return nil
+	}
+
+	client, err := tx.GetClientById(ctx, clientId)
+	if err != nil {
+		return err
+	}
+
+	for _, pc := range collections {
+		err = s.outbox(tx).ScheduleToRemove(ctx, event.ScheduleToRemove{
+			CourtRef:   client.CourtRef,
+			Surname:    client.Surname,
+			Amount:     pc.Amount,
+			Date:       shared.Date{Time: pc.CollectionDate.Time},
+			ClientID:   clientId,
+			Reschedule: true,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
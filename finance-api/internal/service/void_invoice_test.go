@@ -0,0 +1,130 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/event"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-api/internal/store"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationSuite) TestService_VoidInvoice() {
+	ctx := suite.ctx
+	seeder := suite.cm.Seeder(ctx, suite.T())
+
+	seeder.SeedData(
+		"INSERT INTO public.persons VALUES (11, NULL, NULL, 'Person', NULL, NULL, NULL, NULL, FALSE, FALSE, NULL, NULL, 'Client', NULL);",
+		"INSERT INTO finance_client VALUES (1, 11, '1234', 'DIRECT DEBIT', NULL, '1234567T');",
+		`INSERT INTO public.addresses VALUES (1, 11, '["1 Test Street"]', 'Testtown', NULL, 'TE1 1ST', NULL);`,
+		"INSERT INTO invoice VALUES (1, 11, 1, 'AD', 'AD11111/24', '2024-04-01', '2025-03-31', 10000, NULL, '2024-03-31', 11, '2024-03-31', NULL, NULL, NULL, '2024-03-31 00:00:00', '99');",
+		"INSERT INTO invoice VALUES (2, 11, 1, 'AD', 'AD22222/24', '2024-04-01', '2025-03-31', 10000, NULL, '2024-04-01', 11, '2024-04-01', NULL, NULL, NULL, '2024-04-01 00:00:00', '99');",
+		"INSERT INTO ledger VALUES (1, 'payment-1', '2024-05-02 15:32:10', '', 6000, 'payment 1', 'MOTO CARD PAYMENT', 'CONFIRMED', 1, NULL, NULL, NULL, '2024-05-01', NULL, NULL, NULL, NULL, '2024-05-02', 1);",
+		"INSERT INTO ledger_allocation VALUES (1, 1, 1, '2024-05-02 15:32:10', 6000, 'ALLOCATED', NULL, '', '2024-05-01', NULL);",
+		"INSERT INTO invoice_adjustment VALUES (1, 1, 1, '2024-06-01', 'CREDIT MEMO', 2000, 'pending credit', 'PENDING', '2024-06-01 00:00:00', 1);",
+		"INSERT INTO pending_collection VALUES (1, 1, '2024-07-01', 4000, 'PENDING', NULL, '2024-06-10', 1);",
+		"ALTER SEQUENCE ledger_id_seq RESTART WITH 2;",
+		"ALTER SEQUENCE ledger_allocation_id_seq RESTART WITH 2;",
+	)
+
+	dispatch := &mockDispatch{}
+	s := Service{store: store.New(seeder.Conn), dispatch: dispatch, tx: seeder.Conn}
+
+	suite.T().Run("invoice not found", func(t *testing.T) {
+		err := s.VoidInvoice(ctx, 11, 99, shared.VoidInvoice{VoidReason: "Raised in error"})
+		assert.ErrorAs(t, err, &apierror.NotFound{})
+	})
+
+	err := s.VoidInvoice(ctx, 11, 1, shared.VoidInvoice{VoidReason: "Raised in error"})
+	assert.NoError(suite.T(), err)
+
+	invoices, err := s.GetInvoices(ctx, 11)
+	assert.NoError(suite.T(), err)
+
+	for _, invoice := range invoices {
+		switch invoice.Id {
+		case 1:
+			assert.Equal(suite.T(), "Void", invoice.Status)
+			assert.Equal(suite.T(), 0, invoice.OutstandingBalance)
+		case 2:
+			// the money paid towards the voided invoice is reapplied
+			assert.Equal(suite.T(), 4000, invoice.OutstandingBalance)
+		}
+	}
+
+	var adjustmentStatus string
+	_ = seeder.QueryRow(ctx, "SELECT status FROM invoice_adjustment WHERE id = 1").Scan(&adjustmentStatus)
+	assert.Equal(suite.T(), "REJECTED", adjustmentStatus)
+
+	assert.NoError(suite.T(), s.RelayOutbox(ctx))
+	assert.Contains(suite.T(), dispatch.called, "ScheduleToRemove")
+	assert.Equal(suite.T(), event.ScheduleToRemove{
+		CourtRef:   "1234567T",
+		Surname:    "Client",
+		Amount:     4000,
+		Date:       shared.NewDate("2024-07-01"),
+		ClientID:   11,
+		Reschedule: true,
+	}, dispatch.event)
+
+	adjustments, err := s.GetPermittedAdjustments(ctx, 1)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), adjustments)
+
+	suite.T().Run("already voided", func(t *testing.T) {
+		err := s.VoidInvoice(ctx, 11, 1, shared.VoidInvoice{VoidReason: "Raised in error"})
+		var e *apierror.BadRequest
+		assert.ErrorAs(t, err, &e)
+		assert.Equal(t, "Invoice AD11111/24 has already been voided", e.Reason)
+	})
+}
+
+func Test_getVoidAmounts(t *testing.T) {
+	received, paid := getVoidAmounts([]store.GetInvoiceAllocationTotalsRow{
+		{Type: "MOTO CARD PAYMENT", Status: "ALLOCATED", Amount: 3000},
+		{Type: "CREDIT MEMO", Status: "ALLOCATED", Amount: 2000},
+		{Type: "CREDIT REAPPLY", Status: "REAPPLIED", Amount: 1000},
+		{Type: "CREDIT MEMO", Status: "UNAPPLIED", Amount: -500},
+	})
+
+	assert.Equal(t, int32(5500), received)
+	assert.Equal(t, int32(3500), paid)
+}
+
+func Test_voidAllocations(t *testing.T) {
+	invoiceID := pgtype.Int4{Int32: 1, Valid: true}
+
+	tests := []struct {
+		name        string
+		outstanding int32
+		paid        int32
+		want        []store.CreateLedgerAllocationParams
+	}{
+		{
+			name:        "unpaid",
+			outstanding: 10000,
+			want: []store.CreateLedgerAllocationParams{
+				{InvoiceID: invoiceID, Amount: 10000, Status: "ALLOCATED"},
+			},
+		},
+		{
+			name:        "part paid",
+			outstanding: 4000,
+			paid:        6000,
+			want: []store.CreateLedgerAllocationParams{
+				{InvoiceID: invoiceID, Amount: 10000, Status: "ALLOCATED"},
+				{InvoiceID: invoiceID, Amount: -6000, Status: "UNAPPLIED", Notes: pgtype.Text{String: "Unapplied funds as a result of voiding the invoice", Valid: true}},
+			},
+		},
+		{
+			name: "closed by a fee reduction",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, voidAllocations(invoiceID, tt.outstanding, tt.paid))
+		})
+	}
+}
@@ -123,6 +123,45 @@ func (q *Queries) CountOverlappingFeeReduction(ctx context.Context, arg CountOve
 	return count, err
 }
 
+const getFeeReductionCredits = `-- name: GetFeeReductionCredits :many
+SELECT fc.client_id, la.invoice_id::INT AS invoice_id, SUM(la.amount)::INT AS amount
+FROM ledger l
+         JOIN ledger_allocation la ON l.id = la.ledger_id
+         JOIN finance_client fc ON fc.id = l.finance_client_id
+WHERE l.fee_reduction_id = $1::INT
+  AND l.status = 'CONFIRMED'
+  AND la.status = 'ALLOCATED'
+GROUP BY fc.client_id, la.invoice_id
+HAVING SUM(la.amount) > 0
+ORDER BY la.invoice_id
+`
+
+type GetFeeReductionCreditsRow struct {
+	ClientID  int32
+	InvoiceID int32
+	Amount    int32
+}
+
+func (q *Queries) GetFeeReductionCredits(ctx context.Context, feeReductionID int32) ([]GetFeeReductionCreditsRow, error) {
+	rows, err := q.db.Query(ctx, getFeeReductionCredits, feeReductionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetFeeReductionCreditsRow
+	for rows.Next() {
+		var i GetFeeReductionCreditsRow
+		if err := rows.Scan(&i.ClientID, &i.InvoiceID, &i.Amount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getFeeReductionForDate = `-- name: GetFeeReductionForDate :one
 SELECT fr.id, fr.type
 FROM fee_reduction fr
@@ -204,3 +243,16 @@ func (q *Queries) GetFeeReductions(ctx context.Context, clientID int32) ([]GetFe
 	}
 	return items, nil
 }
+
+const isFeeReductionCancelled = `-- name: IsFeeReductionCancelled :one
+SELECT deleted
+FROM fee_reduction
+WHERE id = $1
+`
+
+func (q *Queries) IsFeeReductionCancelled(ctx context.Context, id int32) (bool, error) {
+	row := q.db.QueryRow(ctx, isFeeReductionCancelled, id)
+	var deleted bool
+	err := row.Scan(&deleted)
+	return deleted, err
+}
@@ -134,6 +134,33 @@ func (q *Queries) GetCreditBalanceByCourtRef(ctx context.Context, courtRef pgtyp
 	return credit, err
 }
 
+const getCourtRefByClientId = `-- name: GetCourtRefByClientId :one
+SELECT court_ref
+FROM finance_client
+WHERE client_id = $1
+`
+
+func (q *Queries) GetCourtRefByClientId(ctx context.Context, clientID int32) (pgtype.Text, error) {
+	row := q.db.QueryRow(ctx, getCourtRefByClientId, clientID)
+	var court_ref pgtype.Text
+	err := row.Scan(&court_ref)
+	return court_ref, err
+}
+
+const getCourtRefByClientIdForUpdate = `-- name: GetCourtRefByClientIdForUpdate :one
+SELECT court_ref
+FROM finance_client
+WHERE client_id = $1
+    FOR UPDATE
+`
+
+func (q *Queries) GetCourtRefByClientIdForUpdate(ctx context.Context, clientID int32) (pgtype.Text, error) {
+	row := q.db.QueryRow(ctx, getCourtRefByClientIdForUpdate, clientID)
+	var court_ref pgtype.Text
+	err := row.Scan(&court_ref)
+	return court_ref, err
+}
+
 const getPaymentMethod = `-- name: GetPaymentMethod :one
 SELECT payment_method
 FROM finance_client
@@ -181,6 +208,28 @@ func (q *Queries) GetReversibleBalanceByCourtRef(ctx context.Context, dollar_1 p
 	return balance, err
 }
 
+const provisionFinanceClient = `-- name: ProvisionFinanceClient :one
+INSERT INTO finance_client (id, client_id, sop_number, payment_method, court_ref)
+VALUES (NEXTVAL('finance_client_id_seq'), $1, $2, 'DEMANDED', $3)
+ON CONFLICT (client_id) DO UPDATE
+    SET court_ref  = COALESCE(NULLIF($3, ''), finance_client.court_ref),
+        sop_number = COALESCE(NULLIF($2, ''), finance_client.sop_number)
+RETURNING id
+`
+
+type ProvisionFinanceClientParams struct {
+	ClientID  int32
+	SopNumber string
+	CourtRef  pgtype.Text
+}
+
+func (q *Queries) ProvisionFinanceClient(ctx context.Context, arg ProvisionFinanceClientParams) (int32, error) {
+	row := q.db.QueryRow(ctx, provisionFinanceClient, arg.ClientID, arg.SopNumber, arg.CourtRef)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
 const updateClient = `-- name: UpdateClient :exec
 UPDATE finance_client
 SET court_ref = $1
@@ -105,6 +105,19 @@ func (q *Queries) CreatePendingInvoiceAdjustment(ctx context.Context, arg Create
 	return invoicereference, err
 }
 
+const getInvoiceAdjustmentStatus = `-- name: GetInvoiceAdjustmentStatus :one
+SELECT status
+FROM invoice_adjustment
+WHERE id = $1
+`
+
+func (q *Queries) GetInvoiceAdjustmentStatus(ctx context.Context, id int32) (string, error) {
+	row := q.db.QueryRow(ctx, getInvoiceAdjustmentStatus, id)
+	var status string
+	err := row.Scan(&status)
+	return status, err
+}
+
 const getInvoiceAdjustments = `-- name: GetInvoiceAdjustments :many
 SELECT ia.id,
        i.reference AS invoice_ref,
@@ -161,13 +174,102 @@ func (q *Queries) GetInvoiceAdjustments(ctx context.Context, clientID int32) ([]
 	return items, nil
 }
 
+const getPendingInvoiceAdjustments = `-- name: GetPendingInvoiceAdjustments :many
+SELECT ia.id,
+       fc.client_id,
+       fc.court_ref,
+       i.reference AS invoice_ref,
+       ia.raised_date,
+       ia.adjustment_type,
+       ia.amount,
+       ia.notes,
+       ia.created_by
+FROM invoice_adjustment ia
+         JOIN invoice i ON i.id = ia.invoice_id
+         JOIN finance_client fc ON fc.id = ia.finance_client_id
+WHERE ia.status = 'PENDING'
+  AND ($1::VARCHAR IS NULL OR ia.adjustment_type = $1::VARCHAR)
+  AND ($2::INT IS NULL OR ia.created_by = $2::INT)
+  AND ($3::DATE IS NULL OR ia.raised_date <= $3::DATE)
+ORDER BY ia.raised_date, ia.id
+`
+
+type GetPendingInvoiceAdjustmentsParams struct {
+	AdjustmentType pgtype.Text
+	CreatedBy      pgtype.Int4
+	RaisedBefore   pgtype.Date
+}
+
+type GetPendingInvoiceAdjustmentsRow struct {
+	ID             int32
+	ClientID       int32
+	CourtRef       pgtype.Text
+	InvoiceRef     string
+	RaisedDate     pgtype.Date
+	AdjustmentType string
+	Amount         int32
+	Notes          string
+	CreatedBy      int32
+}
+
+func (q *Queries) GetPendingInvoiceAdjustments(ctx context.Context, arg GetPendingInvoiceAdjustmentsParams) ([]GetPendingInvoiceAdjustmentsRow, error) {
+	rows, err := q.db.Query(ctx, getPendingInvoiceAdjustments, arg.AdjustmentType, arg.CreatedBy, arg.RaisedBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPendingInvoiceAdjustmentsRow
+	for rows.Next() {
+		var i GetPendingInvoiceAdjustmentsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ClientID,
+			&i.CourtRef,
+			&i.InvoiceRef,
+			&i.RaisedDate,
+			&i.AdjustmentType,
+			&i.Amount,
+			&i.Notes,
+			&i.CreatedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const rejectPendingAdjustmentsForInvoice = `-- name: RejectPendingAdjustmentsForInvoice :exec
+UPDATE invoice_adjustment
+SET status     = 'REJECTED',
+    updated_at = NOW(),
+    updated_by = $1
+WHERE invoice_id = $2
+  AND status = 'PENDING'
+`
+
+type RejectPendingAdjustmentsForInvoiceParams struct {
+	UpdatedBy pgtype.Int4
+	InvoiceID int32
+}
+
+func (q *Queries) RejectPendingAdjustmentsForInvoice(ctx context.Context, arg RejectPendingAdjustmentsForInvoiceParams) error {
+	_, err := q.db.Exec(ctx, rejectPendingAdjustmentsForInvoice, arg.UpdatedBy, arg.InvoiceID)
+	return err
+}
+
 const setAdjustmentDecision = `-- name: SetAdjustmentDecision :one
 UPDATE invoice_adjustment ia
-SET status     = $2,
+SET status     = $1,
     updated_at = NOW(),
-    updated_by = $3
-WHERE ia.id = $1
-RETURNING ia.amount, ia.adjustment_type, ia.finance_client_id, ia.invoice_id,
+    updated_by = $2
+WHERE ia.id = $3
+  AND ia.status = 'PENDING'
+  AND ia.finance_client_id = (SELECT id FROM finance_client WHERE client_id = $4)
+RETURNING ia.amount, ia.adjustment_type, ia.finance_client_id, ia.invoice_id, ia.created_by,
     (SELECT (i.amount - COALESCE(SUM(la.amount), 0)) outstanding
      FROM invoice i
               LEFT JOIN ledger_allocation la ON i.id = la.invoice_id
@@ -178,9 +280,10 @@ RETURNING ia.amount, ia.adjustment_type, ia.finance_client_id, ia.invoice_id,
 `
 
 type SetAdjustmentDecisionParams struct {
-	ID        int32
 	Status    string
 	UpdatedBy pgtype.Int4
+	ID        int32
+	ClientID  int32
 }
 
 type SetAdjustmentDecisionRow struct {
@@ -188,17 +291,24 @@ type SetAdjustmentDecisionRow struct {
 	AdjustmentType  string
 	FinanceClientID int32
 	InvoiceID       int32
+	CreatedBy       int32
 	Outstanding     int32
 }
 
 func (q *Queries) SetAdjustmentDecision(ctx context.Context, arg SetAdjustmentDecisionParams) (SetAdjustmentDecisionRow, error) {
-	row := q.db.QueryRow(ctx, setAdjustmentDecision, arg.ID, arg.Status, arg.UpdatedBy)
+	row := q.db.QueryRow(ctx, setAdjustmentDecision,
+		arg.Status,
+		arg.UpdatedBy,
+		arg.ID,
+		arg.ClientID,
+	)
 	var i SetAdjustmentDecisionRow
 	err := row.Scan(
 		&i.Amount,
 		&i.AdjustmentType,
 		&i.FinanceClientID,
 		&i.InvoiceID,
+		&i.CreatedBy,
 		&i.Outstanding,
 	)
 	return i, err
@@ -73,6 +73,10 @@ func (q *Queries) AddInvoice(ctx context.Context, arg AddInvoiceParams) (Invoice
 		&i.Cacheddebtamount,
 		&i.CreatedAt,
 		&i.CreatedBy,
+		&i.VoidedAt,
+		&i.VoidedBy,
+		&i.VoidReason,
+		&i.VoidLedgerID,
 	)
 	return i, err
 }
@@ -90,6 +94,41 @@ func (q *Queries) CreateInvoiceCounter(ctx context.Context, key string) (int32,
 	return counter, err
 }
 
+const getInvoiceAllocationTotals = `-- name: GetInvoiceAllocationTotals :many
+SELECT l.type, la.status, SUM(la.amount)::INT AS amount
+FROM ledger_allocation la
+         JOIN ledger l ON la.ledger_id = l.id AND l.status = 'CONFIRMED'
+WHERE la.invoice_id = $1
+  AND la.status NOT IN ('PENDING', 'UN ALLOCATED')
+GROUP BY l.type, la.status
+`
+
+type GetInvoiceAllocationTotalsRow struct {
+	Type   string
+	Status string
+	Amount int32
+}
+
+func (q *Queries) GetInvoiceAllocationTotals(ctx context.Context, invoiceID pgtype.Int4) ([]GetInvoiceAllocationTotalsRow, error) {
+	rows, err := q.db.Query(ctx, getInvoiceAllocationTotals, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetInvoiceAllocationTotalsRow
+	for rows.Next() {
+		var i GetInvoiceAllocationTotalsRow
+		if err := rows.Scan(&i.Type, &i.Status, &i.Amount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getInvoiceBalanceDetails = `-- name: GetInvoiceBalanceDetails :one
 WITH ledger_sums AS (SELECT la.invoice_id,
                             SUM(CASE
@@ -110,7 +149,8 @@ WITH ledger_sums AS (SELECT la.invoice_id,
 SELECT i.amount::INT                                                                          AS initial,
        i.amount - COALESCE(ls.received, 0)::INT                                               AS outstanding,
        i.feetype,
-       COALESCE(ls.write_off_amount, 0)::INT + COALESCE(ls.write_off_reversal_amount, 0)::INT AS write_off_amount
+       COALESCE(ls.write_off_amount, 0)::INT + COALESCE(ls.write_off_reversal_amount, 0)::INT AS write_off_amount,
+       i.voided_at IS NOT NULL                                                                AS voided
 FROM invoice i
          LEFT JOIN ledger_sums ls ON ls.invoice_id = i.id
 WHERE i.id = $1
@@ -121,6 +161,7 @@ type GetInvoiceBalanceDetailsRow struct {
 	Outstanding    int32
 	Feetype        string
 	WriteOffAmount int32
+	Voided         bool
 }
 
 func (q *Queries) GetInvoiceBalanceDetails(ctx context.Context, invoiceID int32) (GetInvoiceBalanceDetailsRow, error) {
@@ -131,6 +172,54 @@ func (q *Queries) GetInvoiceBalanceDetails(ctx context.Context, invoiceID int32)
 		&i.Outstanding,
 		&i.Feetype,
 		&i.WriteOffAmount,
+		&i.Voided,
+	)
+	return i, err
+}
+
+const getInvoiceBalanceForFeeReduction = `-- name: GetInvoiceBalanceForFeeReduction :one
+SELECT i.amount,
+       COALESCE(general_fee.amount, 0)::INT               general_supervision_fee,
+       i.amount - COALESCE(transactions.received, 0)::INT outstanding,
+       i.feetype,
+       i.raiseddate,
+       COALESCE(transactions.fee_reduced, FALSE)::BOOLEAN fee_reduced
+FROM invoice i
+         LEFT JOIN LATERAL (
+    SELECT SUM(la.amount) AS received, BOOL_OR(l.fee_reduction_id IS NOT NULL) AS fee_reduced
+    FROM ledger_allocation la
+             JOIN ledger l ON la.ledger_id = l.id AND l.status = 'CONFIRMED'
+    WHERE la.status NOT IN ('PENDING', 'UN ALLOCATED')
+      AND la.invoice_id = i.id
+    ) transactions ON TRUE
+         LEFT JOIN LATERAL (
+    SELECT SUM(ifr.amount) AS amount
+    FROM invoice_fee_range ifr
+    WHERE ifr.invoice_id = i.id
+      AND ifr.supervisionlevel = 'GENERAL'
+    ) general_fee ON TRUE
+WHERE i.id = $1
+`
+
+type GetInvoiceBalanceForFeeReductionRow struct {
+	Amount                int32
+	GeneralSupervisionFee int32
+	Outstanding           int32
+	Feetype               string
+	Raiseddate            pgtype.Date
+	FeeReduced            bool
+}
+
+func (q *Queries) GetInvoiceBalanceForFeeReduction(ctx context.Context, id int32) (GetInvoiceBalanceForFeeReductionRow, error) {
+	row := q.db.QueryRow(ctx, getInvoiceBalanceForFeeReduction, id)
+	var i GetInvoiceBalanceForFeeReductionRow
+	err := row.Scan(
+		&i.Amount,
+		&i.GeneralSupervisionFee,
+		&i.Outstanding,
+		&i.Feetype,
+		&i.Raiseddate,
+		&i.FeeReduced,
 	)
 	return i, err
 }
@@ -158,6 +247,7 @@ FROM invoice i
     ) general_fee ON TRUE
 WHERE i.raiseddate >= (fr.datereceived - INTERVAL '6 months')
   AND i.raiseddate BETWEEN fr.startdate AND fr.enddate
+  AND i.voided_at IS NULL
   AND fr.id = $1
 `
 
@@ -221,13 +311,47 @@ func (q *Queries) GetInvoiceFeeReductionReversalDetails(ctx context.Context, inv
 	return i, err
 }
 
+const getInvoiceForVoid = `-- name: GetInvoiceForVoid :one
+SELECT i.id, i.reference, i.amount, i.voided_at IS NOT NULL AS voided
+FROM invoice i
+         JOIN finance_client fc ON fc.id = i.finance_client_id
+WHERE i.id = $1
+  AND fc.client_id = $2
+    FOR UPDATE OF i
+`
+
+type GetInvoiceForVoidParams struct {
+	ID       int32
+	ClientID int32
+}
+
+type GetInvoiceForVoidRow struct {
+	ID        int32
+	Reference string
+	Amount    int32
+	Voided    bool
+}
+
+func (q *Queries) GetInvoiceForVoid(ctx context.Context, arg GetInvoiceForVoidParams) (GetInvoiceForVoidRow, error) {
+	row := q.db.QueryRow(ctx, getInvoiceForVoid, arg.ID, arg.ClientID)
+	var i GetInvoiceForVoidRow
+	err := row.Scan(
+		&i.ID,
+		&i.Reference,
+		&i.Amount,
+		&i.Voided,
+	)
+	return i, err
+}
+
 const getInvoices = `-- name: GetInvoices :many
 SELECT i.id,
        i.raiseddate,
        i.reference,
        i.amount,
        COALESCE(transactions.received, 0)::INT                AS received,
-       COALESCE(transactions.fee_reduction_type, '')::VARCHAR AS fee_reduction_type
+       COALESCE(transactions.fee_reduction_type, '')::VARCHAR AS fee_reduction_type,
+       i.voided_at IS NOT NULL                                AS voided
 FROM invoice i
          JOIN finance_client fc ON fc.id = i.finance_client_id
          LEFT JOIN LATERAL (
@@ -249,6 +373,7 @@ type GetInvoicesRow struct {
 	Amount           int32
 	Received         int32
 	FeeReductionType string
+	Voided           bool
 }
 
 func (q *Queries) GetInvoices(ctx context.Context, clientID int32) ([]GetInvoicesRow, error) {
@@ -267,6 +392,7 @@ func (q *Queries) GetInvoices(ctx context.Context, clientID int32) ([]GetInvoice
 			&i.Amount,
 			&i.Received,
 			&i.FeeReductionType,
+			&i.Voided,
 		); err != nil {
 			return nil, err
 		}
@@ -430,7 +556,7 @@ func (q *Queries) GetSupervisionLevels(ctx context.Context, dollar_1 []int32) ([
 }
 
 const getUnpaidInvoicesByCourtRef = `-- name: GetUnpaidInvoicesByCourtRef :many
-SELECT i.id, (i.amount - COALESCE(transactions.received, 0)::INT) AS outstanding
+SELECT i.id, i.reference, (i.amount - COALESCE(transactions.received, 0)::INT) AS outstanding
 FROM invoice i
          JOIN finance_client fc ON fc.id = i.finance_client_id
          LEFT JOIN LATERAL (
@@ -441,6 +567,7 @@ FROM invoice i
       AND la.invoice_id = i.id
     ) transactions ON TRUE
 WHERE fc.court_ref = $1
+  AND i.voided_at IS NULL
 GROUP BY i.id, i.amount, transactions.received, i.raiseddate
 HAVING (i.amount - COALESCE(SUM(transactions.received), 0)::INT) > 0
 ORDER BY i.raiseddate
@@ -448,6 +575,7 @@ ORDER BY i.raiseddate
 
 type GetUnpaidInvoicesByCourtRefRow struct {
 	ID          int32
+	Reference   string
 	Outstanding int32
 }
 
@@ -460,7 +588,7 @@ func (q *Queries) GetUnpaidInvoicesByCourtRef(ctx context.Context, courtRef pgty
 	var items []GetUnpaidInvoicesByCourtRefRow
 	for rows.Next() {
 		var i GetUnpaidInvoicesByCourtRefRow
-		if err := rows.Scan(&i.ID, &i.Outstanding); err != nil {
+		if err := rows.Scan(&i.ID, &i.Reference, &i.Outstanding); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -498,3 +626,29 @@ func (q *Queries) LockInvoiceCounter(ctx context.Context, key string) (int32, er
 	err := row.Scan(&counter)
 	return counter, err
 }
+
+const voidInvoice = `-- name: VoidInvoice :exec
+UPDATE invoice
+SET voided_at      = NOW(),
+    voided_by      = $1,
+    void_reason    = $2,
+    void_ledger_id = $3
+WHERE id = $4
+`
+
+type VoidInvoiceParams struct {
+	VoidedBy     pgtype.Int4
+	VoidReason   pgtype.Text
+	VoidLedgerID pgtype.Int4
+	ID           int32
+}
+
+func (q *Queries) VoidInvoice(ctx context.Context, arg VoidInvoiceParams) error {
+	_, err := q.db.Exec(ctx, voidInvoice,
+		arg.VoidedBy,
+		arg.VoidReason,
+		arg.VoidLedgerID,
+		arg.ID,
+	)
+	return err
+}
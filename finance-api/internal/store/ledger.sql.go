@@ -96,7 +96,7 @@ func (q *Queries) CreateLedger(ctx context.Context, arg CreateLedgerParams) (int
 
 const createLedgerForCourtRef = `-- name: CreateLedgerForCourtRef :one
 INSERT INTO ledger (id, datetime, bankdate, finance_client_id, amount, notes, type, status, created_at, created_by,
-                    reference, method, pis_number)
+                    reference, method, pis_number, allocation_strategy)
 SELECT NEXTVAL('ledger_id_seq'),
        $1,
        $2,
@@ -109,22 +109,24 @@ SELECT NEXTVAL('ledger_id_seq'),
        $7,
        gen_random_uuid(),
        '',
-       $8
+       $8,
+       $9
 FROM finance_client fc
-WHERE court_ref = $9
+WHERE court_ref = $10
 RETURNING id
 `
 
 type CreateLedgerForCourtRefParams struct {
-	ReceivedDate pgtype.Timestamp
-	BankDate     pgtype.Date
-	Amount       int32
-	Notes        pgtype.Text
-	Type         string
-	Status       string
-	CreatedBy    pgtype.Int4
-	PisNumber    pgtype.Int4
-	CourtRef     pgtype.Text
+	ReceivedDate       pgtype.Timestamp
+	BankDate           pgtype.Date
+	Amount             int32
+	Notes              pgtype.Text
+	Type               string
+	Status             string
+	CreatedBy          pgtype.Int4
+	PisNumber          pgtype.Int4
+	AllocationStrategy pgtype.Text
+	CourtRef           pgtype.Text
 }
 
 func (q *Queries) CreateLedgerForCourtRef(ctx context.Context, arg CreateLedgerForCourtRefParams) (int32, error) {
@@ -137,6 +139,7 @@ func (q *Queries) CreateLedgerForCourtRef(ctx context.Context, arg CreateLedgerF
 		arg.Status,
 		arg.CreatedBy,
 		arg.PisNumber,
+		arg.AllocationStrategy,
 		arg.CourtRef,
 	)
 	var id int32
@@ -144,6 +147,54 @@ func (q *Queries) CreateLedgerForCourtRef(ctx context.Context, arg CreateLedgerF
 	return id, err
 }
 
+const getLedgerAllocationsForPreview = `-- name: GetLedgerAllocationsForPreview :many
+SELECT fc.client_id, fc.court_ref, l.type, l.amount AS ledger_amount, la.amount, la.status, i.reference AS invoice_reference
+FROM ledger l
+         JOIN finance_client fc ON fc.id = l.finance_client_id
+         JOIN ledger_allocation la ON l.id = la.ledger_id
+         LEFT JOIN invoice i ON i.id = la.invoice_id
+WHERE l.id = $1
+ORDER BY la.id
+`
+
+type GetLedgerAllocationsForPreviewRow struct {
+	ClientID         int32
+	CourtRef         pgtype.Text
+	Type             string
+	LedgerAmount     int32
+	Amount           int32
+	Status           string
+	InvoiceReference pgtype.Text
+}
+
+func (q *Queries) GetLedgerAllocationsForPreview(ctx context.Context, id int32) ([]GetLedgerAllocationsForPreviewRow, error) {
+	rows, err := q.db.Query(ctx, getLedgerAllocationsForPreview, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetLedgerAllocationsForPreviewRow
+	for rows.Next() {
+		var i GetLedgerAllocationsForPreviewRow
+		if err := rows.Scan(
+			&i.ClientID,
+			&i.CourtRef,
+			&i.Type,
+			&i.LedgerAmount,
+			&i.Amount,
+			&i.Status,
+			&i.InvoiceReference,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getLedgerForPayment = `-- name: GetLedgerForPayment :one
 SELECT l.id
 FROM ledger l
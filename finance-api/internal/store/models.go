@@ -14,6 +14,13 @@ type Account struct {
 	CostCentre             pgtype.Int4
 }
 
+type AccountingPeriod struct {
+	ID          int32
+	PeriodStart pgtype.Date
+	ClosedAt    pgtype.Timestamp
+	ClosedBy    int32
+}
+
 type Address struct {
 	ID                int32
 	PersonID          pgtype.Int4
@@ -30,6 +37,18 @@ type Assignee struct {
 	Surname pgtype.Text
 }
 
+type AuditLog struct {
+	ID              int32
+	FinanceClientID pgtype.Int4
+	Action          string
+	EntityType      string
+	EntityID        int32
+	Before          []byte
+	After           []byte
+	CreatedAt       pgtype.Timestamp
+	CreatedBy       int32
+}
+
 type BankDetail struct {
 	ID       int32
 	RefundID int32
@@ -67,6 +86,21 @@ type Counter struct {
 	Counter int32
 }
 
+type CreditTransfer struct {
+	ID                  int32
+	FromFinanceClientID int32
+	ToFinanceClientID   int32
+	Amount              int32
+	Notes               string
+	Status              string
+	CreatedAt           pgtype.Timestamp
+	CreatedBy           int32
+	DecisionAt          pgtype.Timestamp
+	DecisionBy          pgtype.Int4
+	FromLedgerID        pgtype.Int4
+	ToLedgerID          pgtype.Int4
+}
+
 type FeeReduction struct {
 	ID              int32
 	FinanceClientID pgtype.Int4
@@ -117,6 +151,10 @@ type Invoice struct {
 	Cacheddebtamount pgtype.Int4
 	CreatedAt        pgtype.Timestamp
 	CreatedBy        pgtype.Int4
+	VoidedAt         pgtype.Timestamp
+	VoidedBy         pgtype.Int4
+	VoidReason       pgtype.Text
+	VoidLedgerID     pgtype.Int4
 }
 
 type InvoiceAdjustment struct {
@@ -175,12 +213,13 @@ type Ledger struct {
 	Bankdate        pgtype.Date
 	Batchnumber     pgtype.Int4
 	// (DC2Type:refdata)
-	Bankaccount pgtype.Text
-	Source      pgtype.Text
-	Line        pgtype.Int4
-	CreatedAt   pgtype.Timestamp
-	CreatedBy   pgtype.Int4
-	PisNumber   pgtype.Int4
+	Bankaccount        pgtype.Text
+	Source             pgtype.Text
+	Line               pgtype.Int4
+	CreatedAt          pgtype.Timestamp
+	CreatedBy          pgtype.Int4
+	PisNumber          pgtype.Int4
+	AllocationStrategy pgtype.Text
 }
 
 type LedgerAllocation struct {
@@ -199,6 +238,19 @@ type LedgerAllocation struct {
 	Source        pgtype.Text
 }
 
+type OutboxEvent struct {
+	ID             int32
+	OrderingKey    string
+	EventType      string
+	Detail         []byte
+	CreatedAt      pgtype.Timestamp
+	Attempts       int32
+	NextAttemptAt  pgtype.Timestamp
+	LastError      pgtype.Text
+	PublishedAt    pgtype.Timestamp
+	DeadLetteredAt pgtype.Timestamp
+}
+
 type PaymentMethod struct {
 	ID              int32
 	FinanceClientID int32
@@ -235,6 +287,20 @@ type Person struct {
 	Clientstatus                                pgtype.Text
 }
 
+type ProcessedEvent struct {
+	ID          int32
+	Source      string
+	DetailType  string
+	EventKey    string
+	Payload     []byte
+	Status      string
+	Attempts    int32
+	Error       pgtype.Text
+	ReceivedAt  pgtype.Timestamp
+	ProcessedAt pgtype.Timestamp
+	StartedAt   pgtype.Timestamp
+}
+
 type Property struct {
 	ID    int32
 	Key   string
@@ -267,6 +333,53 @@ type Refund struct {
 	CancelledBy     pgtype.Int4
 }
 
+type ReportRequest struct {
+	ID            int32
+	ReportRequest []byte
+	Status        string
+	RequestedAt   pgtype.Timestamp
+	RequestedBy   int32
+	CompletedAt   pgtype.Timestamp
+	RowCount      pgtype.Int4
+	S3Key         pgtype.Text
+	S3VersionID   pgtype.Text
+	Error         pgtype.Text
+}
+
+type ReportSubscription struct {
+	ID              int32
+	Name            string
+	ReportRequest   []byte
+	Recipients      []string
+	Cadence         string
+	TransactionDate pgtype.Text
+	FromDate        pgtype.Text
+	ToDate          pgtype.Text
+	LastRunOn       pgtype.Date
+	CreatedAt       pgtype.Timestamp
+	CreatedBy       int32
+	CancelledAt     pgtype.Timestamp
+	CancelledBy     pgtype.Int4
+}
+
+type Suspense struct {
+	ID                int32
+	CourtRef          pgtype.Text
+	Amount            int32
+	Type              string
+	BankDate          pgtype.Date
+	ReceivedDate      pgtype.Timestamp
+	PisNumber         pgtype.Int4
+	UploadType        string
+	Line              []byte
+	CreatedAt         pgtype.Timestamp
+	CreatedBy         int32
+	AllocatedCourtRef pgtype.Text
+	LedgerID          pgtype.Int4
+	AllocatedAt       pgtype.Timestamp
+	AllocatedBy       pgtype.Int4
+}
+
 type SupervisionDeputyImportantInformation struct {
 	ID       int32
 	DeputyID pgtype.Int4
@@ -310,3 +423,22 @@ type TransactionTypeUpdate struct {
 	LineDescriptionUpdate pgtype.Text
 	IsReceiptUpdate       pgtype.Bool
 }
+
+type UploadJob struct {
+	ID                 int32
+	UploadType         string
+	Filename           string
+	FileHash           string
+	Status             string
+	LineCount          pgtype.Int4
+	FailedLineCount    pgtype.Int4
+	FailedLines        []byte
+	Error              pgtype.Text
+	StartedAt          pgtype.Timestamp
+	EndedAt            pgtype.Timestamp
+	CreatedBy          int32
+	UploadDate         pgtype.Date
+	EmailAddress       pgtype.Text
+	ProcessedLineCount pgtype.Int4
+	PostsLedgers       bool
+}
@@ -0,0 +1,21 @@
+-- name: CloseAccountingPeriod :execrows
+INSERT INTO accounting_period (id, period_start, closed_at, closed_by)
+VALUES (NEXTVAL('accounting_period_id_seq'), DATE_TRUNC('month', @period_start::DATE), NOW(), @closed_by)
+ON CONFLICT (period_start) DO NOTHING;
+
+-- name: GetClosedAccountingPeriods :many
+SELECT period_start, closed_at, closed_by
+FROM accounting_period
+WHERE period_start >= @from_date::DATE
+ORDER BY period_start DESC;
+
+-- name: IsAccountingPeriodClosed :one
+SELECT EXISTS (SELECT 1
+               FROM accounting_period
+               WHERE period_start = DATE_TRUNC('month', @date::DATE));
+
+-- name: ReopenAccountingPeriod :one
+DELETE
+FROM accounting_period
+WHERE period_start = DATE_TRUNC('month', @period_start::DATE)
+RETURNING id, closed_at, closed_by;
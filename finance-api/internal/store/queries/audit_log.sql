@@ -0,0 +1,23 @@
+-- name: CreateAuditLog :one
+INSERT INTO audit_log (id, finance_client_id, action, entity_type, entity_id, before, after, created_at, created_by)
+SELECT NEXTVAL('audit_log_id_seq'), fc.id, @action, @entity_type, @entity_id, @before, @after, NOW(), @created_by
+FROM finance_client fc
+WHERE fc.client_id = @client_id
+RETURNING id;
+
+-- name: CreateSystemAuditLog :one
+INSERT INTO audit_log (id, finance_client_id, action, entity_type, entity_id, before, after, created_at, created_by)
+VALUES (NEXTVAL('audit_log_id_seq'), NULL, @action, @entity_type, @entity_id, @before, @after, NOW(), @created_by)
+RETURNING id;
+
+-- name: GetAuditLogs :many
+SELECT al.id, al.action, al.entity_type, al.entity_id, al.before, al.after, al.created_at, al.created_by
+FROM audit_log al
+         JOIN finance_client fc ON fc.id = al.finance_client_id
+WHERE fc.client_id = $1
+ORDER BY al.created_at DESC, al.id DESC;
+
+-- name: GetClientIdByFinanceClientId :one
+SELECT client_id
+FROM finance_client
+WHERE id = $1;
@@ -0,0 +1,4 @@
+-- name: CreateBillingPeriod :exec
+INSERT INTO billing_period (id, finance_client_id, order_id, start_date)
+VALUES (NEXTVAL('billing_period_id_seq'), @finance_client_id, @order_id, @start_date)
+ON CONFLICT (finance_client_id, order_id) DO NOTHING;
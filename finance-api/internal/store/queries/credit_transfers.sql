@@ -0,0 +1,43 @@
+-- name: CreateCreditTransfer :one
+INSERT INTO credit_transfer (id, from_finance_client_id, to_finance_client_id, amount, notes, status, created_at,
+                             created_by)
+SELECT NEXTVAL('credit_transfer_id_seq'), f.id, t.id, @amount, @notes, 'PENDING', NOW(), @created_by
+FROM finance_client f,
+     finance_client t
+WHERE f.client_id = @from_client_id
+  AND t.court_ref = @to_court_ref
+RETURNING id;
+
+-- name: GetCreditTransferForDecision :one
+SELECT ct.id, f.client_id AS from_client_id, t.client_id AS to_client_id, ct.amount, ct.created_by
+FROM credit_transfer ct
+         JOIN finance_client f ON f.id = ct.from_finance_client_id
+         JOIN finance_client t ON t.id = ct.to_finance_client_id
+WHERE ct.id = $1
+  AND ct.status = 'PENDING'
+    FOR UPDATE OF ct;
+
+-- name: GetPendingCreditTransfers :many
+SELECT ct.id,
+       f.client_id AS from_client_id,
+       f.court_ref AS from_court_ref,
+       t.client_id AS to_client_id,
+       t.court_ref AS to_court_ref,
+       ct.amount,
+       ct.notes,
+       ct.created_at,
+       ct.created_by
+FROM credit_transfer ct
+         JOIN finance_client f ON f.id = ct.from_finance_client_id
+         JOIN finance_client t ON t.id = ct.to_finance_client_id
+WHERE ct.status = 'PENDING'
+ORDER BY ct.created_at, ct.id;
+
+-- name: SetCreditTransferDecision :exec
+UPDATE credit_transfer
+SET status         = @status,
+    decision_at    = NOW(),
+    decision_by    = @decision_by,
+    from_ledger_id = @from_ledger_id,
+    to_ledger_id   = @to_ledger_id
+WHERE id = @id;
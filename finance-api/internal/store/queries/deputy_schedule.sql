@@ -0,0 +1,26 @@
+-- name: AddDoNotInvoiceWarning :exec
+INSERT INTO supervision.warnings (id, warningtype, isactive, client_id)
+VALUES (NEXTVAL('supervision.warnings_id_seq'), 'Do not invoice', TRUE, $1);
+
+-- name: GetDeputyScheduleClient :one
+SELECT c.id                                        AS client_id,
+       EXISTS (SELECT 1
+               FROM public.cases o
+                        JOIN supervision.order_deputy od ON o.id = od.order_id
+                        JOIN public.persons d ON od.deputy_id = d.id
+               WHERE o.client_id = c.id
+                 AND d.deputynumber = @deputy_number) AS deputy_found,
+       EXISTS (SELECT 1
+               FROM supervision.warnings w
+               WHERE w.client_id = c.id
+                 AND w.warningtype = 'Do not invoice'
+                 AND w.isactive = TRUE)            AS do_not_invoice
+FROM public.persons c
+WHERE c.caserecnumber = @court_ref;
+
+-- name: RemoveDoNotInvoiceWarning :exec
+UPDATE supervision.warnings
+SET isactive = FALSE
+WHERE client_id = $1
+  AND warningtype = 'Do not invoice'
+  AND isactive = TRUE;
@@ -48,3 +48,20 @@ WHERE @date_received::DATE >= (fr.datereceived - INTERVAL '6 months')
   AND @date_received::DATE BETWEEN fr.startdate::DATE AND fr.enddate::DATE
   AND fr.deleted = FALSE
   AND fc.client_id = @client_id;
+
+-- name: GetFeeReductionCredits :many
+SELECT fc.client_id, la.invoice_id::INT AS invoice_id, SUM(la.amount)::INT AS amount
+FROM ledger l
+         JOIN ledger_allocation la ON l.id = la.ledger_id
+         JOIN finance_client fc ON fc.id = l.finance_client_id
+WHERE l.fee_reduction_id = @fee_reduction_id::INT
+  AND l.status = 'CONFIRMED'
+  AND la.status = 'ALLOCATED'
+GROUP BY fc.client_id, la.invoice_id
+HAVING SUM(la.amount) > 0
+ORDER BY la.invoice_id;
+
+-- name: IsFeeReductionCancelled :one
+SELECT deleted
+FROM fee_reduction
+WHERE id = $1;
@@ -86,7 +86,26 @@ JOIN public.persons c ON fc.client_id = c.id
 JOIN public.addresses a ON c.id = a.person_id
 WHERE fc.client_id = @client_id;
 
+-- name: GetCourtRefByClientId :one
+SELECT court_ref
+FROM finance_client
+WHERE client_id = $1;
+
+-- name: GetCourtRefByClientIdForUpdate :one
+SELECT court_ref
+FROM finance_client
+WHERE client_id = $1
+    FOR UPDATE;
+
 -- name: GetPaymentMethod :one
 SELECT payment_method
 FROM finance_client
 WHERE client_id = $1;
+
+-- name: ProvisionFinanceClient :one
+INSERT INTO finance_client (id, client_id, sop_number, payment_method, court_ref)
+VALUES (NEXTVAL('finance_client_id_seq'), @client_id, @sop_number, 'DEMANDED', @court_ref)
+ON CONFLICT (client_id) DO UPDATE
+    SET court_ref  = COALESCE(NULLIF(@court_ref, ''), finance_client.court_ref),
+        sop_number = COALESCE(NULLIF(@sop_number, ''), finance_client.sop_number)
+RETURNING id;
@@ -30,13 +30,34 @@ FROM finance_client fc
 WHERE fc.client_id = $1
 RETURNING (SELECT reference invoicereference FROM invoice WHERE id = invoice_id);
 
+-- name: GetPendingInvoiceAdjustments :many
+SELECT ia.id,
+       fc.client_id,
+       fc.court_ref,
+       i.reference AS invoice_ref,
+       ia.raised_date,
+       ia.adjustment_type,
+       ia.amount,
+       ia.notes,
+       ia.created_by
+FROM invoice_adjustment ia
+         JOIN invoice i ON i.id = ia.invoice_id
+         JOIN finance_client fc ON fc.id = ia.finance_client_id
+WHERE ia.status = 'PENDING'
+  AND (sqlc.narg('adjustment_type')::VARCHAR IS NULL OR ia.adjustment_type = sqlc.narg('adjustment_type')::VARCHAR)
+  AND (sqlc.narg('created_by')::INT IS NULL OR ia.created_by = sqlc.narg('created_by')::INT)
+  AND (sqlc.narg('raised_before')::DATE IS NULL OR ia.raised_date <= sqlc.narg('raised_before')::DATE)
+ORDER BY ia.raised_date, ia.id;
+
 -- name: SetAdjustmentDecision :one
 UPDATE invoice_adjustment ia
-SET status     = $2,
+SET status     = @status,
     updated_at = NOW(),
-    updated_by = $3
-WHERE ia.id = $1
-RETURNING ia.amount, ia.adjustment_type, ia.finance_client_id, ia.invoice_id,
+    updated_by = @updated_by
+WHERE ia.id = @id
+  AND ia.status = 'PENDING'
+  AND ia.finance_client_id = (SELECT id FROM finance_client WHERE client_id = @client_id)
+RETURNING ia.amount, ia.adjustment_type, ia.finance_client_id, ia.invoice_id, ia.created_by,
     (SELECT (i.amount - COALESCE(SUM(la.amount), 0)) outstanding
      FROM invoice i
               LEFT JOIN ledger_allocation la ON i.id = la.invoice_id
@@ -45,6 +66,11 @@ RETURNING ia.amount, ia.adjustment_type, ia.finance_client_id, ia.invoice_id,
      WHERE i.id = ia.invoice_id
      GROUP BY i.amount)::INT AS outstanding;
 
+-- name: GetInvoiceAdjustmentStatus :one
+SELECT status
+FROM invoice_adjustment
+WHERE id = $1;
+
 -- name: CreateLedgerForAdjustment :one
 WITH created AS (
     INSERT INTO ledger (id, datetime, finance_client_id, amount, notes, type, status, fee_reduction_id, created_at,
@@ -69,3 +95,11 @@ SET ledger_id = created.id
 FROM created
 WHERE ia.id = $8
 RETURNING created.id;
+
+-- name: RejectPendingAdjustmentsForInvoice :exec
+UPDATE invoice_adjustment
+SET status     = 'REJECTED',
+    updated_at = NOW(),
+    updated_by = $1
+WHERE invoice_id = $2
+  AND status = 'PENDING';
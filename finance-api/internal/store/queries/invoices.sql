@@ -6,7 +6,8 @@ SELECT i.id,
        i.reference,
        i.amount,
        COALESCE(transactions.received, 0)::INT                AS received,
-       COALESCE(transactions.fee_reduction_type, '')::VARCHAR AS fee_reduction_type
+       COALESCE(transactions.fee_reduction_type, '')::VARCHAR AS fee_reduction_type,
+       i.voided_at IS NOT NULL                                AS voided
 FROM invoice i
          JOIN finance_client fc ON fc.id = i.finance_client_id
          LEFT JOIN LATERAL (
@@ -21,7 +22,7 @@ WHERE fc.client_id = $1
 ORDER BY i.raiseddate DESC;
 
 -- name: GetUnpaidInvoicesByCourtRef :many
-SELECT i.id, (i.amount - COALESCE(transactions.received, 0)::INT) AS outstanding
+SELECT i.id, i.reference, (i.amount - COALESCE(transactions.received, 0)::INT) AS outstanding
 FROM invoice i
          JOIN finance_client fc ON fc.id = i.finance_client_id
          LEFT JOIN LATERAL (
@@ -32,6 +33,7 @@ FROM invoice i
       AND la.invoice_id = i.id
     ) transactions ON TRUE
 WHERE fc.court_ref = $1
+  AND i.voided_at IS NULL
 GROUP BY i.id, i.amount, transactions.received, i.raiseddate
 HAVING (i.amount - COALESCE(SUM(transactions.received), 0)::INT) > 0
 ORDER BY i.raiseddate;
@@ -106,7 +108,8 @@ WITH ledger_sums AS (SELECT la.invoice_id,
 SELECT i.amount::INT                                                                          AS initial,
        i.amount - COALESCE(ls.received, 0)::INT                                               AS outstanding,
        i.feetype,
-       COALESCE(ls.write_off_amount, 0)::INT + COALESCE(ls.write_off_reversal_amount, 0)::INT AS write_off_amount
+       COALESCE(ls.write_off_amount, 0)::INT + COALESCE(ls.write_off_reversal_amount, 0)::INT AS write_off_amount,
+       i.voided_at IS NOT NULL                                                                AS voided
 FROM invoice i
          LEFT JOIN ledger_sums ls ON ls.invoice_id = i.id
 WHERE i.id = @invoice_id;
@@ -147,8 +150,32 @@ FROM invoice i
     ) general_fee ON TRUE
 WHERE i.raiseddate >= (fr.datereceived - INTERVAL '6 months')
   AND i.raiseddate BETWEEN fr.startdate AND fr.enddate
+  AND i.voided_at IS NULL
   AND fr.id = $1;
 
+-- name: GetInvoiceBalanceForFeeReduction :one
+SELECT i.amount,
+       COALESCE(general_fee.amount, 0)::INT               general_supervision_fee,
+       i.amount - COALESCE(transactions.received, 0)::INT outstanding,
+       i.feetype,
+       i.raiseddate,
+       COALESCE(transactions.fee_reduced, FALSE)::BOOLEAN fee_reduced
+FROM invoice i
+         LEFT JOIN LATERAL (
+    SELECT SUM(la.amount) AS received, BOOL_OR(l.fee_reduction_id IS NOT NULL) AS fee_reduced
+    FROM ledger_allocation la
+             JOIN ledger l ON la.ledger_id = l.id AND l.status = 'CONFIRMED'
+    WHERE la.status NOT IN ('PENDING', 'UN ALLOCATED')
+      AND la.invoice_id = i.id
+    ) transactions ON TRUE
+         LEFT JOIN LATERAL (
+    SELECT SUM(ifr.amount) AS amount
+    FROM invoice_fee_range ifr
+    WHERE ifr.invoice_id = i.id
+      AND ifr.supervisionlevel = 'GENERAL'
+    ) general_fee ON TRUE
+WHERE i.id = $1;
+
 -- name: AddInvoice :one
 INSERT INTO invoice (id, person_id, finance_client_id, feetype, reference, startdate, enddate, amount, confirmeddate,
                      raiseddate, source, created_at, created_by)
@@ -183,3 +210,27 @@ UPDATE counter
 SET counter = counter + 1
 WHERE key = $1
 RETURNING counter;
+
+-- name: GetInvoiceForVoid :one
+SELECT i.id, i.reference, i.amount, i.voided_at IS NOT NULL AS voided
+FROM invoice i
+         JOIN finance_client fc ON fc.id = i.finance_client_id
+WHERE i.id = $1
+  AND fc.client_id = $2
+    FOR UPDATE OF i;
+
+-- name: GetInvoiceAllocationTotals :many
+SELECT l.type, la.status, SUM(la.amount)::INT AS amount
+FROM ledger_allocation la
+         JOIN ledger l ON la.ledger_id = l.id AND l.status = 'CONFIRMED'
+WHERE la.invoice_id = $1
+  AND la.status NOT IN ('PENDING', 'UN ALLOCATED')
+GROUP BY l.type, la.status;
+
+-- name: VoidInvoice :exec
+UPDATE invoice
+SET voided_at      = NOW(),
+    voided_by      = $1,
+    void_reason    = $2,
+    void_ledger_id = $3
+WHERE id = $4;
@@ -19,7 +19,7 @@ RETURNING id;
 
 -- name: CreateLedgerForCourtRef :one
 INSERT INTO ledger (id, datetime, bankdate, finance_client_id, amount, notes, type, status, created_at, created_by,
-                    reference, method, pis_number)
+                    reference, method, pis_number, allocation_strategy)
 SELECT NEXTVAL('ledger_id_seq'),
        @received_date,
        @bank_date,
@@ -32,7 +32,8 @@ SELECT NEXTVAL('ledger_id_seq'),
        @created_by,
        gen_random_uuid(),
        '',
-       @pis_number
+       @pis_number,
+       @allocation_strategy
 FROM finance_client fc
 WHERE court_ref = @court_ref
 RETURNING id;
@@ -59,3 +60,12 @@ WHERE l.amount = @amount
  AND l.type = @type
  AND fc.court_ref = @court_ref
  AND COALESCE(l.pis_number, 0) = COALESCE(sqlc.narg('pis_number'), 0);
+
+-- name: GetLedgerAllocationsForPreview :many
+SELECT fc.client_id, fc.court_ref, l.type, l.amount AS ledger_amount, la.amount, la.status, i.reference AS invoice_reference
+FROM ledger l
+         JOIN finance_client fc ON fc.id = l.finance_client_id
+         JOIN ledger_allocation la ON l.id = la.ledger_id
+         LEFT JOIN invoice i ON i.id = la.invoice_id
+WHERE l.id = $1
+ORDER BY la.id;
@@ -0,0 +1,47 @@
+-- name: CreateOutboxEvent :exec
+INSERT INTO outbox_event (id, ordering_key, event_type, detail, created_at, next_attempt_at)
+VALUES (NEXTVAL('outbox_event_id_seq'), @ordering_key, @event_type, @detail, NOW(), NOW());
+
+-- name: LockOutboxRelay :one
+SELECT PG_TRY_ADVISORY_XACT_LOCK(HASHTEXT('outbox_event'))::BOOLEAN AS locked;
+
+-- name: GetDeadLetteredOutboxEvents :many
+SELECT id, ordering_key, event_type, attempts, last_error, created_at, dead_lettered_at
+FROM outbox_event
+WHERE dead_lettered_at IS NOT NULL
+  AND published_at IS NULL
+ORDER BY id;
+
+-- name: GetUnpublishedOutboxEvents :many
+SELECT id, ordering_key, event_type, detail, attempts, (next_attempt_at <= NOW())::BOOLEAN AS due
+FROM outbox_event
+WHERE published_at IS NULL
+  AND dead_lettered_at IS NULL
+ORDER BY id
+LIMIT $1;
+
+-- name: LeaseOutboxEvents :exec
+UPDATE outbox_event
+SET next_attempt_at = NOW() + INTERVAL '5 minutes'
+WHERE id = ANY (@ids::INT[]);
+
+-- name: ReleaseOutboxEvents :exec
+UPDATE outbox_event
+SET next_attempt_at = NOW()
+WHERE id = ANY (@ids::INT[]);
+
+-- name: MarkOutboxEventPublished :exec
+UPDATE outbox_event
+SET published_at = NOW(),
+    attempts     = attempts + 1,
+    last_error   = NULL
+WHERE id = $1;
+
+-- name: MarkOutboxEventFailed :one
+UPDATE outbox_event
+SET attempts         = attempts + 1,
+    last_error       = @last_error,
+    next_attempt_at  = NOW() + LEAST(POWER(2, attempts), 3600) * INTERVAL '1 second',
+    dead_lettered_at = CASE WHEN attempts + 1 >= @max_attempts::INT THEN NOW() END
+WHERE id = @id
+RETURNING (dead_lettered_at IS NOT NULL)::BOOLEAN AS dead_lettered;
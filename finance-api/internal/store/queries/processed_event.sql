@@ -0,0 +1,35 @@
+-- name: StartProcessedEvent :one
+INSERT INTO processed_event (id, source, detail_type, event_key, payload, status, received_at, started_at)
+VALUES (NEXTVAL('processed_event_id_seq'), @source, @detail_type, @event_key, @payload, 'PROCESSING', NOW(), NOW())
+ON CONFLICT (source, detail_type, event_key) DO UPDATE
+    SET status     = 'PROCESSING',
+        attempts   = processed_event.attempts + 1,
+        error      = NULL,
+        started_at = NOW()
+WHERE processed_event.status = 'FAILED'
+   OR (processed_event.status = 'PROCESSING' AND processed_event.started_at < NOW() - INTERVAL '1 hour')
+RETURNING id;
+
+-- name: ReplayProcessedEvent :one
+UPDATE processed_event
+SET status     = 'PROCESSING',
+    attempts   = attempts + 1,
+    error      = NULL,
+    started_at = NOW()
+WHERE id = $1
+  AND (status = 'FAILED' OR (status = 'PROCESSING' AND started_at < NOW() - INTERVAL '1 hour'))
+RETURNING payload;
+
+-- name: CompleteProcessedEvent :exec
+UPDATE processed_event
+SET status       = @status,
+    error        = @error,
+    processed_at = NOW()
+WHERE id = @id;
+
+-- name: GetFailedProcessedEvents :many
+SELECT id, source, detail_type, event_key, attempts, error, received_at, processed_at
+FROM processed_event
+WHERE status = 'FAILED'
+   OR (status = 'PROCESSING' AND started_at < NOW() - INTERVAL '1 hour')
+ORDER BY id;
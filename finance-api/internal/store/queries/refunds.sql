@@ -20,6 +20,38 @@ FROM refund r
 WHERE fc.client_id = $1
 ORDER BY r.raised_date DESC, r.created_at DESC;
 
+-- name: GetPendingRefunds :many
+SELECT r.id,
+       fc.client_id,
+       fc.court_ref,
+       r.raised_date,
+       r.amount,
+       r.decision AS status,
+       r.notes,
+       r.created_by,
+       (CASE
+            WHEN r.decision = 'APPROVED' THEN r.decision_at::DATE
+            ELSE r.created_at::DATE
+           END + 14 - CURRENT_DATE)::INT AS days_until_expiry
+FROM refund r
+         JOIN finance_client fc ON fc.id = r.finance_client_id
+WHERE r.decision IN ('PENDING', 'APPROVED')
+  AND r.processed_at IS NULL
+  AND r.cancelled_at IS NULL
+ORDER BY days_until_expiry, r.id;
+
+-- name: GetRefundStatus :one
+SELECT CASE
+           WHEN r.fulfilled_at IS NOT NULL THEN 'FULFILLED'
+           WHEN r.cancelled_at IS NOT NULL THEN 'CANCELLED'
+           WHEN r.processed_at IS NOT NULL THEN 'PROCESSING'
+           ELSE r.decision
+           END::VARCHAR AS status
+FROM refund r
+         JOIN finance_client fc ON fc.id = r.finance_client_id
+WHERE fc.client_id = @client_id
+  AND r.id = @refund_id;
+
 -- name: GetRefundAmount :one
 SELECT ABS(COALESCE(SUM(
                             CASE
@@ -51,13 +83,14 @@ WITH r AS (
 SELECT id
 FROM r;
 
--- name: SetRefundDecision :exec
+-- name: SetRefundDecision :one
 UPDATE refund
 SET decision    = @decision,
     decision_at = NOW(),
     decision_by = @decision_by
 WHERE finance_client_id = (SELECT id FROM finance_client WHERE client_id = @client_id)
-  AND id = @refund_id;
+  AND id = @refund_id
+RETURNING created_by;
 
 -- name: RemoveBankDetails :exec
 DELETE
@@ -0,0 +1,41 @@
+-- name: CreateReportRequest :one
+INSERT INTO report_request (id, report_request, status, requested_at, requested_by)
+VALUES (NEXTVAL('report_request_id_seq'), @report_request, 'RUNNING', NOW(), @requested_by)
+RETURNING id;
+
+-- name: CompleteReportRequest :exec
+UPDATE report_request
+SET status        = @status,
+    completed_at  = NOW(),
+    row_count     = @row_count,
+    s3_key        = @s3_key,
+    s3_version_id = @s3_version_id,
+    error         = @error
+WHERE id = @id
+  AND status = 'RUNNING';
+
+-- name: InterruptReportRequests :execrows
+UPDATE report_request
+SET status       = 'INTERRUPTED',
+    completed_at = NOW()
+WHERE id = ANY (@ids::INT[])
+  AND status = 'RUNNING';
+
+-- name: InterruptStaleReportRequests :execrows
+UPDATE report_request
+SET status       = 'INTERRUPTED',
+    completed_at = NOW()
+WHERE status = 'RUNNING'
+  AND requested_at < NOW() - INTERVAL '6 hours';
+
+-- name: GetReportRequest :one
+SELECT id, report_request, status, requested_at, requested_by, completed_at, row_count, s3_key, s3_version_id, error
+FROM report_request
+WHERE id = @id;
+
+-- name: GetReportRequestsByUser :many
+SELECT id, report_request, status, requested_at, requested_by, completed_at, row_count, s3_key, s3_version_id, error
+FROM report_request
+WHERE requested_by = @requested_by
+ORDER BY requested_at DESC, id DESC
+LIMIT 50;
@@ -0,0 +1,42 @@
+-- name: CreateReportSubscription :one
+INSERT INTO report_subscription (id, name, report_request, recipients, cadence, transaction_date, from_date, to_date,
+                                 created_at, created_by)
+VALUES (NEXTVAL('report_subscription_id_seq'), @name, @report_request, @recipients, @cadence, @transaction_date,
+        @from_date, @to_date, NOW(), @created_by)
+RETURNING id;
+
+-- name: GetReportSubscriptions :many
+SELECT id,
+       name,
+       report_request,
+       recipients,
+       cadence,
+       transaction_date,
+       from_date,
+       to_date,
+       last_run_on,
+       created_at,
+       created_by
+FROM report_subscription
+WHERE cancelled_at IS NULL
+ORDER BY id;
+
+-- name: CancelReportSubscription :execrows
+UPDATE report_subscription
+SET cancelled_at = NOW(),
+    cancelled_by = @cancelled_by
+WHERE id = @id
+  AND cancelled_at IS NULL;
+
+-- name: GetReportSubscriptionsToRun :many
+SELECT id, name, report_request, recipients, cadence, transaction_date, from_date, to_date
+FROM report_subscription
+WHERE cancelled_at IS NULL
+  AND (last_run_on IS NULL OR last_run_on < @run_date)
+ORDER BY id;
+
+-- name: MarkReportSubscriptionRun :execrows
+UPDATE report_subscription
+SET last_run_on = @run_date
+WHERE id = @id
+  AND (last_run_on IS NULL OR last_run_on < @run_date);
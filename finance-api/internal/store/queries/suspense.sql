@@ -0,0 +1,36 @@
+-- name: AllocateSuspense :exec
+UPDATE suspense
+SET allocated_court_ref = @allocated_court_ref,
+    ledger_id           = @ledger_id,
+    allocated_at        = NOW(),
+    allocated_by        = @allocated_by
+WHERE id = @id;
+
+-- name: CountDuplicateSuspense :one
+SELECT COUNT(*)
+FROM suspense
+WHERE amount = @amount
+  AND bank_date = @bank_date
+  AND received_date::DATE = (@received_date::TIMESTAMP)::DATE
+  AND type = @type
+  AND court_ref = @court_ref
+  AND COALESCE(pis_number, 0) = COALESCE(@pis_number, 0);
+
+-- name: CreateSuspense :exec
+INSERT INTO suspense (id, court_ref, amount, type, bank_date, received_date, pis_number, upload_type, line, created_at,
+                      created_by)
+VALUES (NEXTVAL('suspense_id_seq'), @court_ref, @amount, @type, @bank_date, @received_date, @pis_number, @upload_type,
+        @line, NOW(), @created_by);
+
+-- name: GetSuspenseForAllocation :one
+SELECT id, court_ref, amount, type, bank_date, received_date, pis_number
+FROM suspense
+WHERE id = $1
+  AND ledger_id IS NULL
+    FOR UPDATE;
+
+-- name: GetUnallocatedSuspense :many
+SELECT id, court_ref, amount, type, bank_date, received_date, pis_number, upload_type, line, created_at, created_by
+FROM suspense
+WHERE ledger_id IS NULL
+ORDER BY created_at, id;
@@ -0,0 +1,82 @@
+-- name: CreateUploadJob :one
+INSERT INTO upload_job (id, upload_type, filename, file_hash, status, started_at, created_by, upload_date, email_address,
+                        posts_ledgers)
+VALUES (NEXTVAL('upload_job_id_seq'), @upload_type, @filename, @file_hash, 'PROCESSING', NOW(), @created_by, @upload_date,
+        @email_address, @posts_ledgers)
+ON CONFLICT DO NOTHING
+RETURNING id;
+
+-- name: CompleteUploadJob :exec
+UPDATE upload_job
+SET status            = @status,
+    line_count        = @line_count,
+    failed_line_count = @failed_line_count,
+    failed_lines      = @failed_lines,
+    error             = @error,
+    ended_at          = NOW()
+WHERE id = @id;
+
+-- name: GetUploadJobs :many
+SELECT id,
+       upload_type,
+       filename,
+       file_hash,
+       status,
+       line_count,
+       failed_line_count,
+       failed_lines,
+       error,
+       started_at,
+       ended_at,
+       created_by,
+       upload_date,
+       email_address,
+       processed_line_count,
+       posts_ledgers
+FROM upload_job
+ORDER BY started_at DESC, id DESC
+LIMIT 100;
+
+-- name: GetUploadJob :one
+SELECT id,
+       upload_type,
+       filename,
+       file_hash,
+       status,
+       line_count,
+       failed_line_count,
+       failed_lines,
+       error,
+       started_at,
+       ended_at,
+       created_by,
+       upload_date,
+       email_address,
+       processed_line_count,
+       posts_ledgers
+FROM upload_job
+WHERE id = $1;
+
+-- name: GetProcessedUploadJob :one
+SELECT started_at, email_address, created_by
+FROM upload_job
+WHERE file_hash = @file_hash
+  AND upload_type = @upload_type
+  AND status != 'FAILED'
+ORDER BY started_at
+LIMIT 1;
+
+-- name: FailStaleUploadJobs :exec
+UPDATE upload_job
+SET status   = 'FAILED',
+    error    = 'Upload did not complete',
+    ended_at = NOW()
+WHERE file_hash = @file_hash
+  AND upload_type = @upload_type
+  AND status = 'PROCESSING'
+  AND started_at < NOW() - INTERVAL '2 hours';
+
+-- name: UpdateUploadJobProgress :exec
+UPDATE upload_job
+SET processed_line_count = @processed_line_count
+WHERE id = @id;
@@ -30,6 +30,7 @@ WITH total_credit AS (SELECT fc.id                                 AS finance_cl
                        WHERE i.finance_client_id = (SELECT fc.id
                                                     FROM finance_client fc
                                                     WHERE fc.client_id = $1)
+                         AND i.voided_at IS NULL
                        GROUP BY i.id, i.raiseddate, i.amount
                        HAVING (i.amount - COALESCE(SUM(la.amount), 0)) > 0 -- Only unpaid invoices
                        ORDER BY i.raiseddate
@@ -158,6 +158,69 @@ func (q *Queries) ExpireProcessingRefunds(ctx context.Context, cancelledBy pgtyp
 	return count, err
 }
 
+const getPendingRefunds = `-- name: GetPendingRefunds :many
+SELECT r.id,
+       fc.client_id,
+       fc.court_ref,
+       r.raised_date,
+       r.amount,
+       r.decision AS status,
+       r.notes,
+       r.created_by,
+       (CASE
+            WHEN r.decision = 'APPROVED' THEN r.decision_at::DATE
+            ELSE r.created_at::DATE
+           END + 14 - CURRENT_DATE)::INT AS days_until_expiry
+FROM refund r
+         JOIN finance_client fc ON fc.id = r.finance_client_id
+WHERE r.decision IN ('PENDING', 'APPROVED')
+  AND r.processed_at IS NULL
+  AND r.cancelled_at IS NULL
+ORDER BY days_until_expiry, r.id
+`
+
+type GetPendingRefundsRow struct {
+	ID              int32
+	ClientID        int32
+	CourtRef        pgtype.Text
+	RaisedDate      pgtype.Date
+	Amount          int32
+	Status          string
+	Notes           string
+	CreatedBy       int32
+	DaysUntilExpiry int32
+}
+
+func (q *Queries) GetPendingRefunds(ctx context.Context) ([]GetPendingRefundsRow, error) {
+	rows, err := q.db.Query(ctx, getPendingRefunds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPendingRefundsRow
+	for rows.Next() {
+		var i GetPendingRefundsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ClientID,
+			&i.CourtRef,
+			&i.RaisedDate,
+			&i.Amount,
+			&i.Status,
+			&i.Notes,
+			&i.CreatedBy,
+			&i.DaysUntilExpiry,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getProcessingRefund = `-- name: GetProcessingRefund :one
 SELECT r.id
 FROM refund r
@@ -214,6 +277,31 @@ func (q *Queries) GetRefundAmount(ctx context.Context, clientID int32) (int32, e
 	return credit, err
 }
 
+const getRefundStatus = `-- name: GetRefundStatus :one
+SELECT CASE
+           WHEN r.fulfilled_at IS NOT NULL THEN 'FULFILLED'
+           WHEN r.cancelled_at IS NOT NULL THEN 'CANCELLED'
+           WHEN r.processed_at IS NOT NULL THEN 'PROCESSING'
+           ELSE r.decision
+           END::VARCHAR AS status
+FROM refund r
+         JOIN finance_client fc ON fc.id = r.finance_client_id
+WHERE fc.client_id = $1
+  AND r.id = $2
+`
+
+type GetRefundStatusParams struct {
+	ClientID int32
+	RefundID int32
+}
+
+func (q *Queries) GetRefundStatus(ctx context.Context, arg GetRefundStatusParams) (string, error) {
+	row := q.db.QueryRow(ctx, getRefundStatus, arg.ClientID, arg.RefundID)
+	var status string
+	err := row.Scan(&status)
+	return status, err
+}
+
 const getRefunds = `-- name: GetRefunds :many
 SELECT r.id,
        r.raised_date,
@@ -362,13 +450,14 @@ func (q *Queries) ResetApprovedRefunds(ctx context.Context, clientID int32) ([]i
 	return items, nil
 }
 
-const setRefundDecision = `-- name: SetRefundDecision :exec
+const setRefundDecision = `-- name: SetRefundDecision :one
 UPDATE refund
 SET decision    = $1,
     decision_at = NOW(),
     decision_by = $2
 WHERE finance_client_id = (SELECT id FROM finance_client WHERE client_id = $3)
   AND id = $4
+RETURNING created_by
 `
 
 type SetRefundDecisionParams struct {
@@ -378,12 +467,14 @@ type SetRefundDecisionParams struct {
 	RefundID   pgtype.Int4
 }
 
-func (q *Queries) SetRefundDecision(ctx context.Context, arg SetRefundDecisionParams) error {
-	_, err := q.db.Exec(ctx, setRefundDecision,
+func (q *Queries) SetRefundDecision(ctx context.Context, arg SetRefundDecisionParams) (int32, error) {
+	row := q.db.QueryRow(ctx, setRefundDecision,
 		arg.Decision,
 		arg.DecisionBy,
 		arg.ClientID,
 		arg.RefundID,
 	)
-	return err
+	var created_by int32
+	err := row.Scan(&created_by)
+	return created_by, err
 }
@@ -142,7 +142,7 @@ func (s *Seeder) CreateAdjustment(ctx context.Context, clientID int32, invoiceId
 	err = s.Conn.QueryRow(ctx, "SELECT id FROM supervision_finance.invoice_adjustment ORDER BY id DESC LIMIT 1").Scan(&id)
 	assert.NoError(s.t, err, "failed find created adjustment: %v", err)
 
-	err = s.Service.UpdatePendingInvoiceAdjustment(ctx, clientID, id, shared.AdjustmentStatusApproved)
+	err = s.Service.UpdatePendingInvoiceAdjustment(withDecisionOverride(ctx), clientID, id, shared.AdjustmentStatusApproved)
 	assert.NoError(s.t, err, "failed to approve adjustment: %v", err)
 
 	if approvedDate == nil {
@@ -293,7 +293,7 @@ func (s *Seeder) CreateRefund(ctx context.Context, clientId int32, accountName s
 }
 
 func (s *Seeder) SetRefundDecision(ctx context.Context, clientId int32, refundId int32, decision shared.RefundStatus, decisionDate time.Time) {
-	err := s.Service.UpdateRefundDecision(ctx, clientId, refundId, decision)
+	err := s.Service.UpdateRefundDecision(withDecisionOverride(ctx), clientId, refundId, decision)
 	assert.NoError(s.t, err, "failed to update refund decision: %v", err)
 
 	if decision == shared.RefundStatusCancelled {
@@ -354,7 +354,7 @@ func (s *Seeder) FulfillRefund(ctx context.Context, refundId int32, amount int32
 
 	latestLedgerId := s.GetLatestLedgerID(ctx)
 
-	_ = s.Service.ProcessFulfilledRefundsLine(ctx, tx, refundId, refund)
+	_, _ = s.Service.ProcessFulfilledRefundsLine(ctx, tx, refundId, refund)
 	assert.NoError(s.t, err, "refund not processed: %v", err)
 
 	err = tx.Commit(ctx)
@@ -406,3 +406,12 @@ func (s *Seeder) updateLedgerDates(ctx context.Context, latestLedgerID int, date
 	_, err = s.Conn.Exec(ctx, "UPDATE supervision_finance.ledger_allocation SET datetime = $1 WHERE ledger_id > $2", date, latestLedgerID)
 	assert.NoError(s.t, err, "failed to update ledger allocation dates for refund: %v", err)
 }
+
+// withDecisionOverride grants the seeding user the decision override role, as seeded records are created and approved
+// by the same user
+func withDecisionOverride(ctx context.Context) context.Context {
+	authCtx := ctx.(auth.Context)
+	user := *authCtx.User
+	user.Roles = append(append([]string{}, user.Roles...), shared.RoleFinanceDecisionOverride)
+	return auth.Context{Context: authCtx.Context, User: &user}
+}
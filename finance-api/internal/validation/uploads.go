@@ -14,4 +14,10 @@ const (
 	UploadErrorRefundForReversalNotFound = "REFUND_NOT_FOUND_FOR_REVERSAL"
 	UploadErrorMaximumDebt               = "MAXIMUM_DEBT"
 	UploadErrorDuplicatePayment          = "DUPLICATE_PAYMENT"
+	UploadErrorDeputyNotFound            = "DEPUTY_NOT_FOUND"
+	UploadErrorDeputyNumberParse         = "DEPUTY_NUMBER_PARSE_ERROR"
+	UploadErrorDoNotInvoiceParse         = "DO_NOT_INVOICE_PARSE_ERROR"
+	UploadErrorBalanceMismatch           = "BALANCE_MISMATCH"
+	UploadErrorHeldInSuspense            = "HELD_IN_SUSPENSE"
+	UploadErrorAccountingPeriodClosed    = "ACCOUNTING_PERIOD_CLOSED"
 )
@@ -59,6 +59,7 @@ type Envs struct {
 	allpaySchemeCode   string
 	holidayAPIURL      string
 	allpayEnabled      bool
+	uploadBatchSize    int
 }
 
 func parseEnvs() (*Envs, error) {
@@ -94,6 +95,14 @@ func parseEnvs() (*Envs, error) {
 		missing = append(missing, errors.New("OPG_SUPERVISION_SYSTEM_USER_ID must be an integer"))
 	}
 
+	uploadBatchSize := 500
+	if os.Getenv("UPLOAD_BATCH_SIZE") != "" {
+		uploadBatchSize, err = strconv.Atoi(os.Getenv("UPLOAD_BATCH_SIZE"))
+		if err != nil || uploadBatchSize < 0 {
+			missing = append(missing, errors.New("UPLOAD_BATCH_SIZE must be zero or a positive integer"))
+		}
+	}
+
 	if len(missing) > 0 {
 		return nil, errors.Join(missing...)
 	}
@@ -131,6 +140,7 @@ func parseEnvs() (*Envs, error) {
 		allpayEnabled:      os.Getenv("ALLPAY_ENABLED") == "1",
 		allpaySchemeCode:   "OPGB",
 		holidayAPIURL:      os.Getenv("HOLIDAY_API_URL"),
+		uploadBatchSize:    uploadBatchSize,
 	}, nil
 }
 
@@ -186,6 +196,13 @@ func run(ctx context.Context, logger *slog.Logger) error {
 		AllpayEnabled: envs.allpayEnabled,
 	})
 
+	// reports lost when a previous server stopped without shutting down are no longer shown as running
+	_ = Service.InterruptStaleReportRequests(telemetry.ContextWithLogger(ctx, logger))
+
+	relayCtx, stopRelay := context.WithCancel(telemetry.ContextWithLogger(ctx, logger))
+	defer stopRelay()
+	go Service.StartOutboxRelay(relayCtx, 5*time.Second)
+
 	validator, err := validation.New()
 	if err != nil {
 		return err
@@ -215,9 +232,11 @@ func run(ctx context.Context, logger *slog.Logger) error {
 		Secret: envs.jwtSecret,
 	}, validator, &api.Envs{
 		ReportsBucket:     envs.reportsBucket,
+		AsyncBucket:       envs.asyncBucket,
 		GoLiveDate:        goLiveDate,
 		SystemUserID:      envs.systemUserID,
 		EventBridgeAPIKey: envs.eventBridgeAPIKey,
+		UploadBatchSize:   envs.uploadBatchSize,
 	})
 
 	s := &http.Server{
@@ -243,7 +262,18 @@ func run(ctx context.Context, logger *slog.Logger) error {
 	tc, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	return s.Shutdown(tc)
+	err = s.Shutdown(tc)
+
+	// reports are generated in the background, so any still running are lost when the process exits. The shutdown may
+	// have used up the timeout, so they are recorded with a context of their own.
+	ic, cancelInterrupt := context.WithTimeout(ctx, 5*time.Second)
+	defer cancelInterrupt()
+
+	if interruptErr := server.InterruptRunningReports(telemetry.ContextWithLogger(ic, logger)); interruptErr != nil {
+		logger.Error("unable to interrupt running reports", slog.Any("err", interruptErr.Error()))
+	}
+
+	return err
 }
 
 func setupDbPool(ctx context.Context, logger *slog.Logger, searchPath string, envs *Envs, readOnly bool) (*pgxpool.Pool, error) {
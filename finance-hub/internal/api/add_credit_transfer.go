@@ -0,0 +1,65 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+func (c *Client) AddCreditTransfer(ctx context.Context, clientId int, toCourtRef string, amount string, notes string) error {
+	var (
+		body              bytes.Buffer
+		amountTransformed int32
+	)
+
+	if amount != "" {
+		amountTransformed = shared.DecimalStringToInt(amount)
+	}
+
+	err := json.NewEncoder(&body).Encode(shared.AddCreditTransfer{
+		ToCourtRef:    strings.TrimSpace(toCourtRef),
+		Amount:        amountTransformed,
+		TransferNotes: notes,
+	})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("/clients/%d/credit-transfers", clientId)
+	req, err := c.newBackendRequest(ctx, http.MethodPost, url, &body)
+
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer unchecked(resp.Body.Close)
+
+	if resp.StatusCode == http.StatusCreated {
+		return nil
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return ErrUnauthorized
+	}
+
+	if resp.StatusCode == http.StatusUnprocessableEntity {
+		var v apierror.ValidationError
+		if err := json.NewDecoder(resp.Body).Decode(&v); err == nil && len(v.Errors) > 0 {
+			return apierror.ValidationError{Errors: v.Errors}
+		}
+	}
+
+	return newStatusError(resp)
+}
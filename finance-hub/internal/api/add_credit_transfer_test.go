@@ -0,0 +1,83 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddCreditTransfer(t *testing.T) {
+	mockClient := SetUpTest()
+	mockJWT := mockJWTClient{}
+	client := NewClient(mockClient, &mockJWT, Envs{"http://localhost:3000", ""})
+
+	var sent shared.AddCreditTransfer
+	GetDoFunc = func(r *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &sent)
+		return &http.Response{
+			StatusCode: 201,
+			Body:       http.NoBody,
+		}, nil
+	}
+
+	err := client.AddCreditTransfer(testContext(), 1, " 12345678 ", "25.50", "wrong client")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, shared.AddCreditTransfer{ToCourtRef: "12345678", Amount: 2550, TransferNotes: "wrong client"}, sent)
+}
+
+func TestAddCreditTransferUnauthorised(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	err := client.AddCreditTransfer(testContext(), 1, "12345678", "25.50", "")
+
+	assert.Equal(t, ErrUnauthorized.Error(), err.Error())
+}
+
+func TestAddCreditTransferReturns500Error(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	err := client.AddCreditTransfer(testContext(), 1, "12345678", "25.50", "")
+	assert.Equal(t, StatusError{
+		Code:   http.StatusInternalServerError,
+		URL:    svr.URL + "/clients/1/credit-transfers",
+		Method: http.MethodPost,
+	}, err)
+}
+
+func TestAddCreditTransferReturnsValidationError(t *testing.T) {
+	validationErrors := apierror.ValidationError{
+		Errors: map[string]map[string]string{
+			"Amount": {
+				"credit-available": "The amount is more than the credit available to transfer",
+			},
+		},
+	}
+	responseBody, _ := json.Marshal(validationErrors)
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write(responseBody)
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	err := client.AddCreditTransfer(testContext(), 1, "12345678", "9999.99", "")
+	assert.Equal(t, validationErrors, err.(apierror.ValidationError))
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+func (c *Client) AddManualPayment(ctx context.Context, clientId int, paymentType string, amount string, bankDate string, receivedDate string, pisNumber string, invoiceReference string) error {
+	var (
+		body                    bytes.Buffer
+		bankDateTransformed     *shared.Date
+		receivedDateTransformed *shared.Date
+		amountTransformed       int32
+	)
+
+	if bankDate != "" {
+		bankDateToTime, _ := time.Parse("2006-01-02", bankDate)
+		bankDateTransformed = &shared.Date{Time: bankDateToTime}
+	}
+
+	if receivedDate != "" {
+		receivedDateToTime, _ := time.Parse("2006-01-02", receivedDate)
+		receivedDateTransformed = &shared.Date{Time: receivedDateToTime}
+	}
+
+	if amount != "" {
+		amountTransformed = shared.DecimalStringToInt(amount)
+	}
+
+	pisNumberTransformed, _ := strconv.Atoi(pisNumber)
+
+	err := json.NewEncoder(&body).Encode(shared.AddManualPayment{
+		PaymentType:      shared.ParseTransactionType(paymentType),
+		Amount:           amountTransformed,
+		BankDate:         bankDateTransformed,
+		ReceivedDate:     receivedDateTransformed,
+		PisNumber:        pisNumberTransformed,
+		InvoiceReference: strings.TrimSpace(invoiceReference),
+	})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("/clients/%d/payments", clientId)
+	req, err := c.newBackendRequest(ctx, http.MethodPost, url, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer unchecked(resp.Body.Close)
+
+	if resp.StatusCode == http.StatusCreated {
+		return nil
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return ErrUnauthorized
+	}
+
+	if resp.StatusCode == http.StatusUnprocessableEntity {
+		var v apierror.ValidationError
+		if err := json.NewDecoder(resp.Body).Decode(&v); err == nil && len(v.Errors) > 0 {
+			return apierror.ValidationError{Errors: v.Errors}
+		}
+	}
+
+	return newStatusError(resp)
+}
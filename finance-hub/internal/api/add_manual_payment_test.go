@@ -0,0 +1,92 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddManualPayment(t *testing.T) {
+	mockClient := SetUpTest()
+	mockJWT := mockJWTClient{}
+	client := NewClient(mockClient, &mockJWT, Envs{"http://localhost:3000", ""})
+
+	var sent shared.AddManualPayment
+
+	GetDoFunc = func(r *http.Request) (*http.Response, error) {
+		_ = json.NewDecoder(r.Body).Decode(&sent)
+		return &http.Response{
+			StatusCode: 201,
+			Body:       http.NoBody,
+		}, nil
+	}
+
+	err := client.AddManualPayment(testContext(), 1, "SUPERVISION CHEQUE PAYMENT", "123.45", "2024-04-12", "2024-04-11", "100023", "AD11223/19")
+	assert.Equal(t, nil, err)
+
+	bankDate := shared.NewDate("2024-04-12")
+	receivedDate := shared.NewDate("2024-04-11")
+	assert.Equal(t, shared.AddManualPayment{
+		PaymentType:      shared.TransactionTypeSupervisionChequePayment,
+		Amount:           12345,
+		BankDate:         &bankDate,
+		ReceivedDate:     &receivedDate,
+		PisNumber:        100023,
+		InvoiceReference: "AD11223/19",
+	}, sent)
+}
+
+func TestAddManualPaymentUnauthorised(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	err := client.AddManualPayment(testContext(), 1, "MOTO CARD PAYMENT", "10", "2024-04-12", "2024-04-11", "", "")
+
+	assert.Equal(t, ErrUnauthorized.Error(), err.Error())
+}
+
+func TestAddManualPaymentReturns500Error(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	err := client.AddManualPayment(testContext(), 1, "MOTO CARD PAYMENT", "10", "2024-04-12", "2024-04-11", "", "")
+	assert.Equal(t, StatusError{
+		Code:   http.StatusInternalServerError,
+		URL:    svr.URL + "/clients/1/payments",
+		Method: http.MethodPost,
+	}, err)
+}
+
+func TestAddManualPaymentReturnsValidationError(t *testing.T) {
+	validationErrors := apierror.ValidationError{
+		Errors: map[string]map[string]string{
+			"Duplicate": {
+				"duplicate": "A payment with these details has already been added",
+			},
+		},
+	}
+	responseBody, _ := json.Marshal(validationErrors)
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write(responseBody)
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	err := client.AddManualPayment(testContext(), 1, "MOTO CARD PAYMENT", "10", "2024-04-12", "2024-04-11", "", "")
+	assert.Equal(t, validationErrors, err.(apierror.ValidationError))
+}
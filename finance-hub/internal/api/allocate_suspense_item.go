@@ -0,0 +1,58 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+func (c *Client) AllocateSuspenseItem(ctx context.Context, suspenseId int, courtRef string) error {
+	var body bytes.Buffer
+
+	err := json.NewEncoder(&body).Encode(shared.AllocateSuspense{
+		CourtRef: courtRef,
+	})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("/suspense/%d/allocate", suspenseId)
+	req, err := c.newBackendRequest(ctx, http.MethodPost, url, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer unchecked(resp.Body.Close)
+
+	if resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		return ErrUnauthorized
+	}
+	if resp.StatusCode == http.StatusUnprocessableEntity {
+		var v apierror.ValidationError
+		if err = json.NewDecoder(resp.Body).Decode(&v); err == nil && len(v.Errors) > 0 {
+			return apierror.ValidationError{Errors: v.Errors}
+		}
+	}
+	if resp.StatusCode == http.StatusBadRequest {
+		var be apierror.BadRequest
+		if err = json.NewDecoder(resp.Body).Decode(&be); err == nil {
+			return be
+		}
+	}
+
+	return newStatusError(resp)
+}
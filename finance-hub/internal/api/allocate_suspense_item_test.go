@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllocateSuspenseItem(t *testing.T) {
+	mockClient := SetUpTest()
+	mockJWT := mockJWTClient{}
+	client := NewClient(mockClient, &mockJWT, Envs{"http://localhost:3000", ""})
+
+	GetDoFunc = func(*http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: 204,
+			Body:       http.NoBody,
+		}, nil
+	}
+
+	err := client.AllocateSuspenseItem(testContext(), 3, "12345678")
+	assert.Equal(t, nil, err)
+}
+
+func TestAllocateSuspenseItemUnauthorised(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	err := client.AllocateSuspenseItem(testContext(), 3, "12345678")
+
+	assert.Equal(t, ErrUnauthorized.Error(), err.Error())
+}
+
+func TestAllocateSuspenseItemReturns500Error(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	err := client.AllocateSuspenseItem(testContext(), 3, "12345678")
+	assert.Equal(t, StatusError{
+		Code:   http.StatusInternalServerError,
+		URL:    svr.URL + "/suspense/3/allocate",
+		Method: http.MethodPost,
+	}, err)
+}
+
+func TestAllocateSuspenseItemReturnsValidationError(t *testing.T) {
+	validationErrors := apierror.ValidationError{
+		Errors: map[string]map[string]string{
+			"CourtRef": {
+				"required": "This field CourtRef needs to be looked at required",
+			},
+		},
+	}
+	responseBody, _ := json.Marshal(validationErrors)
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write(responseBody)
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	err := client.AllocateSuspenseItem(testContext(), 3, "")
+	assert.Equal(t, validationErrors, err.(apierror.ValidationError))
+}
+
+func TestAllocateSuspenseItemReturnsBadRequest(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"field":"courtRef","reason":"Could not find a client with this court reference"}`))
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	err := client.AllocateSuspenseItem(testContext(), 3, "99999999")
+	assert.Equal(t, apierror.BadRequest{Field: "courtRef", Reason: "Could not find a client with this court reference"}, err)
+}
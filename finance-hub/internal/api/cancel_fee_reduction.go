@@ -10,11 +10,12 @@ import (
 	"net/http"
 )
 
-func (c *Client) CancelFeeReduction(ctx context.Context, clientId int, feeReductionId int, cancellationReason string) error {
+func (c *Client) CancelFeeReduction(ctx context.Context, clientId int, feeReductionId int, cancellationReason string, reverseCredits bool) error {
 	var body bytes.Buffer
 
 	err := json.NewEncoder(&body).Encode(shared.CancelFeeReduction{
 		CancellationReason: cancellationReason,
+		ReverseCredits:     reverseCredits,
 	})
 	if err != nil {
 		return err
@@ -30,7 +30,7 @@ func TestCancelFeeReduction(t *testing.T) {
 		}, nil
 	}
 
-	err := client.CancelFeeReduction(testContext(), 1, 1, "Fee remission note for one award")
+	err := client.CancelFeeReduction(testContext(), 1, 1, "Fee remission note for one award", false)
 	assert.Equal(t, nil, err)
 }
 
@@ -42,7 +42,7 @@ func TestCancelFeeReductionUnauthorised(t *testing.T) {
 
 	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
 
-	err := client.CancelFeeReduction(testContext(), 1, 1, "Fee remission note for one award")
+	err := client.CancelFeeReduction(testContext(), 1, 1, "Fee remission note for one award", false)
 
 	assert.Equal(t, ErrUnauthorized.Error(), err.Error())
 }
@@ -55,7 +55,7 @@ func TestCancelFeeReductionReturns500Error(t *testing.T) {
 
 	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
 
-	err := client.CancelFeeReduction(testContext(), 1, 1, "Fee remission note for one award")
+	err := client.CancelFeeReduction(testContext(), 1, 1, "Fee remission note for one award", false)
 	assert.Equal(t, StatusError{
 		Code:   http.StatusInternalServerError,
 		URL:    svr.URL + "/clients/1/fee-reductions/1/cancel",
@@ -80,7 +80,7 @@ func TestCancelFeeReductionReturnsValidationError(t *testing.T) {
 
 	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
 
-	err := client.CancelFeeReduction(testContext(), 0, 0, "")
+	err := client.CancelFeeReduction(testContext(), 0, 0, "", false)
 	expectedError := apierror.ValidationError{Errors: apierror.ValidationErrors{"CancelFeeReductionNotes": map[string]string{"required": "This field CancelFeeReductionNotes needs to be looked at required"}}}
 	assert.Equal(t, expectedError, err.(apierror.ValidationError))
 }
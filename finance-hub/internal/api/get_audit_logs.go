@@ -0,0 +1,39 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+func (c *Client) GetAuditLogs(ctx context.Context, clientId int) (shared.AuditLogs, error) {
+	var auditLogs shared.AuditLogs
+
+	url := fmt.Sprintf("/clients/%d/audit", clientId)
+
+	req, err := c.newBackendRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return auditLogs, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return auditLogs, err
+	}
+
+	defer unchecked(resp.Body.Close)
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return auditLogs, ErrUnauthorized
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return auditLogs, newStatusError(resp)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&auditLogs)
+	return auditLogs, err
+}
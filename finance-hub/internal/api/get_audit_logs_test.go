@@ -0,0 +1,88 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAuditLogsCanReturn200(t *testing.T) {
+	mockClient := SetUpTest()
+	mockJWT := mockJWTClient{}
+	client := NewClient(mockClient, &mockJWT, Envs{"http://localhost:3000", ""})
+
+	json := `[
+		{
+			"id": 1,
+			"action": "REFUND DECISION",
+			"entityType": "REFUND",
+			"entityId": 2,
+			"before": {"status": "PENDING"},
+			"after": {"status": "APPROVED"},
+			"createdAt": "2025-06-04T09:30:00Z",
+			"createdBy": 10
+		}
+	]`
+
+	r := io.NopCloser(bytes.NewReader([]byte(json)))
+
+	GetDoFunc = func(rq *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: 200,
+			Body:       r,
+		}, nil
+	}
+
+	expectedResponse := shared.AuditLogs{
+		{
+			ID:         1,
+			Action:     shared.AuditActionRefundDecision,
+			EntityType: shared.AuditEntityRefund,
+			EntityID:   2,
+			Before:     map[string]string{"status": "PENDING"},
+			After:      map[string]string{"status": "APPROVED"},
+			CreatedAt:  time.Date(2025, 6, 4, 9, 30, 0, 0, time.UTC),
+			CreatedBy:  10,
+		},
+	}
+
+	resp, err := client.GetAuditLogs(testContext(), 1)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, expectedResponse, resp)
+}
+
+func TestGetAuditLogsCanThrow500Error(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	_, err := client.GetAuditLogs(testContext(), 1)
+
+	assert.Equal(t, StatusError{
+		Code:   http.StatusInternalServerError,
+		URL:    svr.URL + "/clients/1/audit",
+		Method: http.MethodGet,
+	}, err)
+}
+
+func TestGetAuditLogsUnauthorised(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	_, err := client.GetAuditLogs(testContext(), 1)
+
+	assert.Equal(t, ErrUnauthorized, err)
+}
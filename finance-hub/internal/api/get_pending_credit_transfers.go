@@ -0,0 +1,36 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+func (c *Client) GetPendingCreditTransfers(ctx context.Context) (shared.CreditTransfers, error) {
+	var transfers shared.CreditTransfers
+
+	req, err := c.newBackendRequest(ctx, http.MethodGet, "/credit-transfers", nil)
+	if err != nil {
+		return transfers, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return transfers, err
+	}
+
+	defer unchecked(resp.Body.Close)
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return transfers, ErrUnauthorized
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return transfers, newStatusError(resp)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&transfers)
+	return transfers, err
+}
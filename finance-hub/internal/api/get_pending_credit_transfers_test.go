@@ -0,0 +1,79 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPendingCreditTransfers(t *testing.T) {
+	mockClient := SetUpTest()
+	mockJWT := mockJWTClient{}
+	client := NewClient(mockClient, &mockJWT, Envs{"http://localhost:3000", ""})
+
+	json := `
+	[
+	  {
+		 "id":4,
+		 "fromClientId":1,
+		 "fromCourtRef":"11111111",
+		 "toClientId":2,
+		 "toCourtRef":"22222222",
+		 "amount":2550,
+		 "notes":"wrong client",
+		 "createdDate":"02/04/2222",
+		 "createdBy":1
+	  }
+	]
+	`
+
+	r := io.NopCloser(bytes.NewReader([]byte(json)))
+
+	GetDoFunc = func(*http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: 200,
+			Body:       r,
+		}, nil
+	}
+
+	expectedResponse := shared.CreditTransfers{
+		{
+			ID:           4,
+			FromClientId: 1,
+			FromCourtRef: "11111111",
+			ToClientId:   2,
+			ToCourtRef:   "22222222",
+			Amount:       2550,
+			Notes:        "wrong client",
+			CreatedDate:  shared.NewDate("02/04/2222"),
+			CreatedBy:    1,
+		},
+	}
+
+	resp, err := client.GetPendingCreditTransfers(testContext())
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, expectedResponse, resp)
+}
+
+func TestGetPendingCreditTransfersCanThrow500Error(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	_, err := client.GetPendingCreditTransfers(testContext())
+
+	assert.Equal(t, StatusError{
+		Code:   http.StatusInternalServerError,
+		URL:    svr.URL + "/credit-transfers",
+		Method: http.MethodGet,
+	}, err)
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+func (c *Client) GetPendingInvoiceAdjustments(ctx context.Context, adjustmentType string, createdBy int, olderThanDays int) (shared.PendingInvoiceAdjustments, error) {
+	var adjustments shared.PendingInvoiceAdjustments
+
+	query := url.Values{}
+	if adjustmentType != "" {
+		query.Set("adjustmentType", adjustmentType)
+	}
+	if createdBy != 0 {
+		query.Set("createdBy", strconv.Itoa(createdBy))
+	}
+	if olderThanDays != 0 {
+		query.Set("olderThanDays", strconv.Itoa(olderThanDays))
+	}
+
+	path := "/invoice-adjustments"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	req, err := c.newBackendRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return adjustments, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return adjustments, err
+	}
+
+	defer unchecked(resp.Body.Close)
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return adjustments, ErrUnauthorized
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return adjustments, newStatusError(resp)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&adjustments)
+	return adjustments, err
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPendingInvoiceAdjustments(t *testing.T) {
+	mockClient := SetUpTest()
+	mockJWT := mockJWTClient{}
+	client := NewClient(mockClient, &mockJWT, Envs{"http://localhost:3000", ""})
+
+	json := `
+	[
+	  {
+		 "id":3,
+		 "clientId":2,
+		 "courtRef":"12345678",
+		 "invoiceRef":"N2000001/20",
+		 "raisedDate":"01/04/2222",
+		 "adjustmentType":"CREDIT WRITE OFF",
+		 "amount":232,
+		 "notes":"Some notes here",
+		 "createdBy":1
+	  }
+	]
+	`
+
+	r := io.NopCloser(bytes.NewReader([]byte(json)))
+
+	var requestURL string
+	GetDoFunc = func(rq *http.Request) (*http.Response, error) {
+		requestURL = rq.URL.String()
+		return &http.Response{
+			StatusCode: 200,
+			Body:       r,
+		}, nil
+	}
+
+	expectedResponse := shared.PendingInvoiceAdjustments{
+		{
+			Id:             3,
+			ClientId:       2,
+			CourtRef:       "12345678",
+			InvoiceRef:     "N2000001/20",
+			RaisedDate:     shared.NewDate("01/04/2222"),
+			AdjustmentType: shared.AdjustmentTypeWriteOff,
+			Amount:         232,
+			Notes:          "Some notes here",
+			CreatedBy:      1,
+		},
+	}
+
+	resp, err := client.GetPendingInvoiceAdjustments(testContext(), "CREDIT WRITE OFF", 1, 30)
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, expectedResponse, resp)
+	assert.Equal(t, "http://localhost:3000/invoice-adjustments?adjustmentType=CREDIT+WRITE+OFF&createdBy=1&olderThanDays=30", requestURL)
+}
+
+func TestGetPendingInvoiceAdjustmentsCanThrow500Error(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	_, err := client.GetPendingInvoiceAdjustments(testContext(), "", 0, 0)
+
+	assert.Equal(t, StatusError{
+		Code:   http.StatusInternalServerError,
+		URL:    svr.URL + "/invoice-adjustments",
+		Method: http.MethodGet,
+	}, err)
+}
+
+func TestGetPendingInvoiceAdjustmentsUnauthorised(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	_, err := client.GetPendingInvoiceAdjustments(testContext(), "", 0, 0)
+
+	assert.Equal(t, ErrUnauthorized.Error(), err.Error())
+}
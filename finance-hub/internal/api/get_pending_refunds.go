@@ -0,0 +1,36 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+func (c *Client) GetPendingRefunds(ctx context.Context) (shared.PendingRefunds, error) {
+	var refunds shared.PendingRefunds
+
+	req, err := c.newBackendRequest(ctx, http.MethodGet, "/refunds", nil)
+	if err != nil {
+		return refunds, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return refunds, err
+	}
+
+	defer unchecked(resp.Body.Close)
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return refunds, ErrUnauthorized
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return refunds, newStatusError(resp)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&refunds)
+	return refunds, err
+}
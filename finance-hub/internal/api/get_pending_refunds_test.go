@@ -0,0 +1,92 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPendingRefunds(t *testing.T) {
+	mockClient := SetUpTest()
+	mockJWT := mockJWTClient{}
+	client := NewClient(mockClient, &mockJWT, Envs{"http://localhost:3000", ""})
+
+	json := `
+	[
+	  {
+		 "id":3,
+		 "clientId":2,
+		 "courtRef":"12345678",
+		 "raisedDate":"01/04/2222",
+		 "amount":232,
+		 "status":"PENDING",
+		 "notes":"Some notes here",
+		 "createdBy":1,
+		 "daysUntilExpiry":6
+	  }
+	]
+	`
+
+	r := io.NopCloser(bytes.NewReader([]byte(json)))
+
+	GetDoFunc = func(*http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: 200,
+			Body:       r,
+		}, nil
+	}
+
+	expectedResponse := shared.PendingRefunds{
+		{
+			ID:              3,
+			ClientId:        2,
+			CourtRef:        "12345678",
+			RaisedDate:      shared.NewDate("01/04/2222"),
+			Amount:          232,
+			Status:          shared.RefundStatusPending,
+			Notes:           "Some notes here",
+			CreatedBy:       1,
+			DaysUntilExpiry: 6,
+		},
+	}
+
+	resp, err := client.GetPendingRefunds(testContext())
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, expectedResponse, resp)
+}
+
+func TestGetPendingRefundsCanThrow500Error(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	_, err := client.GetPendingRefunds(testContext())
+
+	assert.Equal(t, StatusError{
+		Code:   http.StatusInternalServerError,
+		URL:    svr.URL + "/refunds",
+		Method: http.MethodGet,
+	}, err)
+}
+
+func TestGetPendingRefundsUnauthorised(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	_, err := client.GetPendingRefunds(testContext())
+
+	assert.Equal(t, ErrUnauthorized.Error(), err.Error())
+}
@@ -0,0 +1,36 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+func (c *Client) GetSuspenseItems(ctx context.Context) (shared.SuspenseItems, error) {
+	var items shared.SuspenseItems
+
+	req, err := c.newBackendRequest(ctx, http.MethodGet, "/suspense", nil)
+	if err != nil {
+		return items, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return items, err
+	}
+
+	defer unchecked(resp.Body.Close)
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return items, ErrUnauthorized
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return items, newStatusError(resp)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&items)
+	return items, err
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSuspenseItems(t *testing.T) {
+	mockClient := SetUpTest()
+	mockJWT := mockJWTClient{}
+	client := NewClient(mockClient, &mockJWT, Envs{"http://localhost:3000", ""})
+
+	json := `
+	[
+	  {
+		 "id":3,
+		 "courtRef":"99999999",
+		 "amount":12345,
+		 "ledgerType":"MOTO CARD PAYMENT",
+		 "bankDate":"01/04/2222",
+		 "receivedDate":"31/03/2222",
+		 "uploadType":"PAYMENTS_MOTO_CARD",
+		 "line":["99999999","31/03/2222","123.45"],
+		 "createdDate":"02/04/2222",
+		 "createdBy":1
+	  }
+	]
+	`
+
+	r := io.NopCloser(bytes.NewReader([]byte(json)))
+
+	GetDoFunc = func(*http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: 200,
+			Body:       r,
+		}, nil
+	}
+
+	expectedResponse := shared.SuspenseItems{
+		{
+			ID:           3,
+			CourtRef:     "99999999",
+			Amount:       12345,
+			LedgerType:   shared.TransactionTypeMotoCardPayment,
+			BankDate:     shared.NewDate("01/04/2222"),
+			ReceivedDate: shared.NewDate("31/03/2222"),
+			UploadType:   shared.ReportTypeUploadPaymentsMOTOCard,
+			Line:         []string{"99999999", "31/03/2222", "123.45"},
+			CreatedDate:  shared.NewDate("02/04/2222"),
+			CreatedBy:    1,
+		},
+	}
+
+	resp, err := client.GetSuspenseItems(testContext())
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, expectedResponse, resp)
+}
+
+func TestGetSuspenseItemsCanThrow500Error(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	_, err := client.GetSuspenseItems(testContext())
+
+	assert.Equal(t, StatusError{
+		Code:   http.StatusInternalServerError,
+		URL:    svr.URL + "/suspense",
+		Method: http.MethodGet,
+	}, err)
+}
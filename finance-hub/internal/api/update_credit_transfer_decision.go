@@ -0,0 +1,54 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+func (c *Client) UpdateCreditTransferDecision(ctx context.Context, creditTransferId int, status string) error {
+	var body bytes.Buffer
+
+	err := json.NewEncoder(&body).Encode(shared.UpdateCreditTransfer{
+		Status: shared.ParseAdjustmentStatus(status),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("/credit-transfers/%d", creditTransferId)
+	req, err := c.newBackendRequest(ctx, http.MethodPut, url, &body)
+
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer unchecked(resp.Body.Close)
+
+	if resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		return ErrUnauthorized
+	}
+	if resp.StatusCode == http.StatusBadRequest {
+		var be apierror.BadRequest
+		if err = json.NewDecoder(resp.Body).Decode(&be); err == nil {
+			return be
+		}
+	}
+
+	return newStatusError(resp)
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateCreditTransferDecision(t *testing.T) {
+	mockClient := SetUpTest()
+	mockJWT := mockJWTClient{}
+	client := NewClient(mockClient, &mockJWT, Envs{"http://localhost:3000", ""})
+
+	GetDoFunc = func(*http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: 204,
+			Body:       http.NoBody,
+		}, nil
+	}
+
+	err := client.UpdateCreditTransferDecision(testContext(), 4, "APPROVED")
+	assert.Equal(t, nil, err)
+}
+
+func TestUpdateCreditTransferDecisionUnauthorised(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	err := client.UpdateCreditTransferDecision(testContext(), 4, "APPROVED")
+
+	assert.Equal(t, ErrUnauthorized.Error(), err.Error())
+}
+
+func TestUpdateCreditTransferDecisionReturns500Error(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	err := client.UpdateCreditTransferDecision(testContext(), 4, "REJECTED")
+	assert.Equal(t, StatusError{
+		Code:   http.StatusInternalServerError,
+		URL:    svr.URL + "/credit-transfers/4",
+		Method: http.MethodPut,
+	}, err)
+}
+
+func TestUpdateCreditTransferDecisionReturnsBadRequest(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"field":"createdBy","reason":"This credit transfer cannot be approved by the user who created it"}`))
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	err := client.UpdateCreditTransferDecision(testContext(), 4, "APPROVED")
+	assert.Equal(t, apierror.BadRequest{Field: "createdBy", Reason: "This credit transfer cannot be approved by the user who created it"}, err)
+}
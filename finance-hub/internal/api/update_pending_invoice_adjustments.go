@@ -0,0 +1,49 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+func (c *Client) UpdatePendingInvoiceAdjustments(ctx context.Context, adjustments []shared.ClientInvoiceAdjustment, status string) (shared.InvoiceAdjustmentDecisions, error) {
+	var (
+		body      bytes.Buffer
+		decisions shared.InvoiceAdjustmentDecisions
+	)
+
+	err := json.NewEncoder(&body).Encode(shared.UpdateInvoiceAdjustments{
+		Status:      shared.ParseAdjustmentStatus(status),
+		Adjustments: adjustments,
+	})
+	if err != nil {
+		return decisions, err
+	}
+
+	req, err := c.newBackendRequest(ctx, http.MethodPut, "/invoice-adjustments", &body)
+	if err != nil {
+		return decisions, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return decisions, err
+	}
+
+	defer unchecked(resp.Body.Close)
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return decisions, ErrUnauthorized
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return decisions, newStatusError(resp)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&decisions)
+	return decisions, err
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdatePendingInvoiceAdjustments(t *testing.T) {
+	mockClient := SetUpTest()
+	mockJWT := mockJWTClient{}
+	client := NewClient(mockClient, &mockJWT, Envs{"http://localhost:3000", ""})
+
+	json := `[{"clientId":1,"adjustmentId":2},{"clientId":3,"adjustmentId":4,"error":"Unable to update the adjustment"}]`
+
+	r := io.NopCloser(bytes.NewReader([]byte(json)))
+
+	GetDoFunc = func(*http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: 200,
+			Body:       r,
+		}, nil
+	}
+
+	adjustments := []shared.ClientInvoiceAdjustment{{ClientId: 1, AdjustmentId: 2}, {ClientId: 3, AdjustmentId: 4}}
+	resp, err := client.UpdatePendingInvoiceAdjustments(testContext(), adjustments, "APPROVED")
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, shared.InvoiceAdjustmentDecisions{
+		{ClientInvoiceAdjustment: adjustments[0]},
+		{ClientInvoiceAdjustment: adjustments[1], Error: "Unable to update the adjustment"},
+	}, resp)
+}
+
+func TestUpdatePendingInvoiceAdjustmentsUnauthorised(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	_, err := client.UpdatePendingInvoiceAdjustments(testContext(), nil, "APPROVED")
+
+	assert.Equal(t, ErrUnauthorized.Error(), err.Error())
+}
+
+func TestUpdatePendingInvoiceAdjustmentsReturns500Error(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	_, err := client.UpdatePendingInvoiceAdjustments(testContext(), nil, "APPROVED")
+
+	assert.Equal(t, StatusError{
+		Code:   http.StatusInternalServerError,
+		URL:    svr.URL + "/invoice-adjustments",
+		Method: http.MethodPut,
+	}, err)
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateRefundDecisions(t *testing.T) {
+	mockClient := SetUpTest()
+	mockJWT := mockJWTClient{}
+	client := NewClient(mockClient, &mockJWT, Envs{"http://localhost:3000", ""})
+
+	json := `[{"clientId":1,"refundId":2},{"clientId":3,"refundId":4,"error":"Refund is no longer pending"}]`
+
+	r := io.NopCloser(bytes.NewReader([]byte(json)))
+
+	GetDoFunc = func(*http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: 200,
+			Body:       r,
+		}, nil
+	}
+
+	refunds := []shared.ClientRefund{{ClientId: 1, RefundId: 2}, {ClientId: 3, RefundId: 4}}
+	resp, err := client.UpdateRefundDecisions(testContext(), refunds, "APPROVED")
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, shared.RefundDecisions{
+		{ClientRefund: refunds[0]},
+		{ClientRefund: refunds[1], Error: "Refund is no longer pending"},
+	}, resp)
+}
+
+func TestUpdateRefundDecisionsUnauthorised(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	_, err := client.UpdateRefundDecisions(testContext(), nil, "APPROVED")
+
+	assert.Equal(t, ErrUnauthorized.Error(), err.Error())
+}
+
+func TestUpdateRefundDecisionsReturns500Error(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	_, err := client.UpdateRefundDecisions(testContext(), nil, "APPROVED")
+
+	assert.Equal(t, StatusError{
+		Code:   http.StatusInternalServerError,
+		URL:    svr.URL + "/refunds",
+		Method: http.MethodPut,
+	}, err)
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+func (c *Client) VoidInvoice(ctx context.Context, clientId int, invoiceId int, voidReason string) error {
+	var body bytes.Buffer
+
+	err := json.NewEncoder(&body).Encode(shared.VoidInvoice{
+		VoidReason: voidReason,
+	})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("/clients/%d/invoices/%d/void", clientId, invoiceId)
+	req, err := c.newBackendRequest(ctx, http.MethodPost, url, &body)
+
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer unchecked(resp.Body.Close)
+
+	if resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return ErrUnauthorized
+	}
+
+	if resp.StatusCode == http.StatusUnprocessableEntity {
+		var v apierror.ValidationError
+		if err := json.NewDecoder(resp.Body).Decode(&v); err == nil && len(v.Errors) > 0 {
+			return apierror.ValidationError{Errors: v.Errors}
+		}
+	}
+
+	if resp.StatusCode == http.StatusBadRequest {
+		var be apierror.BadRequest
+		if err = json.NewDecoder(resp.Body).Decode(&be); err == nil {
+			return be
+		}
+	}
+
+	return newStatusError(resp)
+}
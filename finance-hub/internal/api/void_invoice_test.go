@@ -0,0 +1,96 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVoidInvoice(t *testing.T) {
+	mockClient := SetUpTest()
+	mockJWT := mockJWTClient{}
+	client := NewClient(mockClient, &mockJWT, Envs{"http://localhost:3000", ""})
+
+	var sent shared.VoidInvoice
+	GetDoFunc = func(r *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &sent)
+		return &http.Response{
+			StatusCode: 204,
+			Body:       http.NoBody,
+		}, nil
+	}
+
+	err := client.VoidInvoice(testContext(), 1, 2, "Raised in error")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, shared.VoidInvoice{VoidReason: "Raised in error"}, sent)
+}
+
+func TestVoidInvoiceUnauthorised(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	err := client.VoidInvoice(testContext(), 1, 2, "Raised in error")
+
+	assert.Equal(t, ErrUnauthorized.Error(), err.Error())
+}
+
+func TestVoidInvoiceReturns500Error(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	err := client.VoidInvoice(testContext(), 1, 2, "Raised in error")
+	assert.Equal(t, StatusError{
+		Code:   http.StatusInternalServerError,
+		URL:    svr.URL + "/clients/1/invoices/2/void",
+		Method: http.MethodPost,
+	}, err)
+}
+
+func TestVoidInvoiceReturnsValidationError(t *testing.T) {
+	validationErrors := apierror.ValidationError{
+		Errors: map[string]map[string]string{
+			"VoidReason": {
+				"required": "This field VoidReason needs to be looked at required",
+			},
+		},
+	}
+	responseBody, _ := json.Marshal(validationErrors)
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write(responseBody)
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	err := client.VoidInvoice(testContext(), 1, 2, "")
+	assert.Equal(t, validationErrors, err.(apierror.ValidationError))
+}
+
+func TestVoidInvoiceReturnsBadRequest(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"field":"invoice","reason":"Invoice AD11111/24 has already been voided"}`))
+	}))
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient, &mockJWTClient{}, Envs{svr.URL, svr.URL})
+
+	err := client.VoidInvoice(testContext(), 1, 2, "Raised in error")
+	assert.Equal(t, apierror.BadRequest{Field: "invoice", Reason: "Invoice AD11111/24 has already been voided"}, err)
+}
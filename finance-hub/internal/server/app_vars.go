@@ -61,6 +61,12 @@ func NewAppVars(r *http.Request, envVars Envs) AppVars {
 			BasePath: "/clients/" + clientId + "/billing-history",
 			Show:     true,
 		},
+		{
+			Id:       "audit",
+			Title:    "Audit",
+			BasePath: "/clients/" + clientId + "/audit",
+			Show:     true,
+		},
 	}
 
 	vars := AppVars{
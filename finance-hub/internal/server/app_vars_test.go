@@ -50,6 +50,12 @@ func TestNewAppVars(t *testing.T) {
 				Id:       "billing-history",
 				Show:     true,
 			},
+			{
+				Title:    "Audit",
+				BasePath: "/clients/1/audit",
+				Id:       "audit",
+				Show:     true,
+			},
 		},
 	}, vars)
 }
@@ -0,0 +1,23 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+)
+
+type AddCreditTransferForm struct {
+	ClientId string
+	AppVars
+}
+
+type AddCreditTransferHandler struct {
+	router
+}
+
+func (h *AddCreditTransferHandler) render(v AppVars, w http.ResponseWriter, r *http.Request) error {
+	clientID := getClientID(r)
+
+	data := AddCreditTransferForm{ClientId: strconv.Itoa(clientID), AppVars: v}
+
+	return h.execute(w, r, data)
+}
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddCreditTransfer(t *testing.T) {
+	client := mockApiClient{}
+	ro := &mockRoute{client: client}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodGet, "", nil)
+	r.SetPathValue("clientId", "1")
+
+	appVars := AppVars{Path: "/path/"}
+
+	sut := AddCreditTransferHandler{ro}
+	err := sut.render(appVars, w, r)
+
+	assert.Nil(t, err)
+	assert.True(t, ro.executed)
+
+	expected := AddCreditTransferForm{
+		"1",
+		appVars,
+	}
+	assert.Equal(t, expected, ro.data)
+}
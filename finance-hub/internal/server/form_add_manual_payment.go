@@ -0,0 +1,26 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+type AddManualPaymentForm struct {
+	ClientId     string
+	PaymentTypes []shared.TransactionType
+	AppVars
+}
+
+type AddManualPaymentHandler struct {
+	router
+}
+
+func (h *AddManualPaymentHandler) render(v AppVars, w http.ResponseWriter, r *http.Request) error {
+	clientID := getClientID(r)
+
+	data := AddManualPaymentForm{ClientId: strconv.Itoa(clientID), PaymentTypes: shared.ManualPaymentTypes, AppVars: v}
+
+	return h.execute(w, r, data)
+}
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddManualPayment(t *testing.T) {
+	client := mockApiClient{}
+	ro := &mockRoute{client: client}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodGet, "", nil)
+	r.SetPathValue("clientId", "1")
+
+	appVars := AppVars{Path: "/path/"}
+
+	sut := AddManualPaymentHandler{ro}
+	err := sut.render(appVars, w, r)
+
+	assert.Nil(t, err)
+	assert.True(t, ro.executed)
+
+	expected := AddManualPaymentForm{
+		"1",
+		shared.ManualPaymentTypes,
+		appVars,
+	}
+	assert.Equal(t, expected, ro.data)
+}
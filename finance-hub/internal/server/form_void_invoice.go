@@ -0,0 +1,24 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+)
+
+type VoidInvoiceForm struct {
+	ClientId  string
+	InvoiceId string
+	AppVars
+}
+
+type VoidInvoiceFormHandler struct {
+	router
+}
+
+func (h *VoidInvoiceFormHandler) render(v AppVars, w http.ResponseWriter, r *http.Request) error {
+	clientID := getClientID(r)
+
+	data := VoidInvoiceForm{ClientId: strconv.Itoa(clientID), InvoiceId: r.PathValue("invoiceId"), AppVars: v}
+
+	return h.execute(w, r, data)
+}
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVoidInvoiceForm(t *testing.T) {
+	client := mockApiClient{}
+	ro := &mockRoute{client: client}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodGet, "", nil)
+	r.SetPathValue("clientId", "1")
+	r.SetPathValue("invoiceId", "9")
+
+	appVars := AppVars{Path: "/path/"}
+
+	sut := VoidInvoiceFormHandler{ro}
+	err := sut.render(appVars, w, r)
+
+	assert.Nil(t, err)
+	assert.True(t, ro.executed)
+
+	expected := VoidInvoiceForm{
+		"1",
+		"9",
+		appVars,
+	}
+	assert.Equal(t, expected, ro.data)
+}
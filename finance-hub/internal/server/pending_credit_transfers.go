@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+type PendingCreditTransferRows []PendingCreditTransferRow
+
+type PendingCreditTransferRow struct {
+	ID            int
+	FromClientId  int
+	FromCourtRef  string
+	ToClientId    int
+	ToCourtRef    string
+	Amount        int
+	Notes         string
+	CreatedDate   shared.Date
+	CreatedByName string
+	Error         string
+}
+
+type CreditTransferDecision struct {
+	ID     int
+	Status string
+}
+
+type PendingCreditTransfersPage struct {
+	Transfers PendingCreditTransferRows
+	Decided   *CreditTransferDecision
+	AppVars
+}
+
+type PendingCreditTransfersHandler struct {
+	router
+}
+
+func (h *PendingCreditTransfersHandler) render(v AppVars, w http.ResponseWriter, r *http.Request) error {
+	data, err := newPendingCreditTransfersPage(r.Context(), h.Client(), v)
+	if err != nil {
+		return err
+	}
+
+	return h.execute(w, r, data)
+}
+
+func newPendingCreditTransfersPage(ctx context.Context, client ApiClient, v AppVars) (*PendingCreditTransfersPage, error) {
+	transfers, err := client.GetPendingCreditTransfers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	creators := map[int]shared.User{}
+
+	var rows PendingCreditTransferRows
+	for _, transfer := range transfers {
+		creator, ok := creators[transfer.CreatedBy]
+		if !ok {
+			creator, err = client.GetUser(ctx, transfer.CreatedBy)
+			if err != nil {
+				return nil, err
+			}
+			creators[transfer.CreatedBy] = creator
+		}
+
+		rows = append(rows, PendingCreditTransferRow{
+			ID:            transfer.ID,
+			FromClientId:  transfer.FromClientId,
+			FromCourtRef:  transfer.FromCourtRef,
+			ToClientId:    transfer.ToClientId,
+			ToCourtRef:    transfer.ToCourtRef,
+			Amount:        transfer.Amount,
+			Notes:         transfer.Notes,
+			CreatedDate:   transfer.CreatedDate,
+			CreatedByName: creator.DisplayName,
+		})
+	}
+
+	return &PendingCreditTransfersPage{Transfers: rows, AppVars: v}, nil
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPendingCreditTransfers(t *testing.T) {
+	data := shared.CreditTransfers{
+		{
+			ID:           4,
+			FromClientId: 1,
+			FromCourtRef: "11111111",
+			ToClientId:   2,
+			ToCourtRef:   "22222222",
+			Amount:       2550,
+			Notes:        "Paid to the wrong client",
+			CreatedDate:  shared.NewDate("02/04/2222"),
+			CreatedBy:    99,
+		},
+	}
+
+	client := mockApiClient{creditTransfers: data, User: shared.User{ID: 99, DisplayName: "Colin Creator"}}
+	ro := &mockRoute{client: client}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodGet, "/credit-transfers", nil)
+
+	appVars := AppVars{Path: "/path/"}
+
+	sut := PendingCreditTransfersHandler{ro}
+	err := sut.render(appVars, w, r)
+
+	assert.Nil(t, err)
+	assert.True(t, ro.executed)
+
+	expected := &PendingCreditTransfersPage{
+		Transfers: PendingCreditTransferRows{
+			{
+				ID:            4,
+				FromClientId:  1,
+				FromCourtRef:  "11111111",
+				ToClientId:    2,
+				ToCourtRef:    "22222222",
+				Amount:        2550,
+				Notes:         "Paid to the wrong client",
+				CreatedDate:   shared.NewDate("02/04/2222"),
+				CreatedByName: "Colin Creator",
+			},
+		},
+		AppVars: appVars,
+	}
+
+	assert.Equal(t, expected, ro.data)
+}
+
+func TestPendingCreditTransfers_error(t *testing.T) {
+	client := mockApiClient{error: errors.New("this has failed")}
+	ro := &mockRoute{client: client}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodGet, "/credit-transfers", nil)
+
+	sut := PendingCreditTransfersHandler{ro}
+	err := sut.render(AppVars{}, w, r)
+
+	assert.Equal(t, "this has failed", err.Error())
+	assert.False(t, ro.executed)
+}
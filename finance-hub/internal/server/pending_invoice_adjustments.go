@@ -0,0 +1,136 @@
+package server
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+type QueuedInvoiceAdjustments []QueuedInvoiceAdjustment
+
+type QueuedInvoiceAdjustment struct {
+	Id               int
+	ClientId         int
+	CourtRef         string
+	Invoice          string
+	DateRaised       shared.Date
+	AdjustmentType   string
+	AdjustmentAmount int
+	Notes            string
+	CreatedBy        int
+	CreatedByName    string
+	Error            string
+}
+
+type PendingInvoiceAdjustmentsFilter struct {
+	AdjustmentType string
+	CreatedBy      int
+	OlderThanDays  int
+}
+
+type AdjustmentDecisionOutcome struct {
+	Status    string
+	Succeeded int
+	Failed    shared.InvoiceAdjustmentDecisions
+}
+
+type PendingInvoiceAdjustmentsPage struct {
+	Adjustments     QueuedInvoiceAdjustments
+	Filter          PendingInvoiceAdjustmentsFilter
+	AdjustmentTypes []shared.AdjustmentType
+	Creators        []shared.User
+	AgeOptions      []int
+	Outcome         *AdjustmentDecisionOutcome
+	AppVars
+}
+
+type PendingInvoiceAdjustmentsHandler struct {
+	router
+}
+
+func (h *PendingInvoiceAdjustmentsHandler) render(v AppVars, w http.ResponseWriter, r *http.Request) error {
+	data, err := newPendingInvoiceAdjustmentsPage(r.Context(), h.Client(), parsePendingInvoiceAdjustmentsFilter(r.URL.Query()), v)
+	if err != nil {
+		return err
+	}
+
+	return h.execute(w, r, data)
+}
+
+func parsePendingInvoiceAdjustmentsFilter(values url.Values) PendingInvoiceAdjustmentsFilter {
+	createdBy, _ := strconv.Atoi(values.Get("creator"))
+	olderThanDays, _ := strconv.Atoi(values.Get("age"))
+
+	return PendingInvoiceAdjustmentsFilter{
+		AdjustmentType: values.Get("type"),
+		CreatedBy:      createdBy,
+		OlderThanDays:  olderThanDays,
+	}
+}
+
+// newPendingInvoiceAdjustmentsPage fetches the pending adjustments matching the filter and resolves the name of each
+// creator. The creator filter options are taken from the results, along with the selected creator so that it can be
+// cleared when it no longer matches anything.
+func newPendingInvoiceAdjustmentsPage(ctx context.Context, client ApiClient, filter PendingInvoiceAdjustmentsFilter, v AppVars) (*PendingInvoiceAdjustmentsPage, error) {
+	pending, err := client.GetPendingInvoiceAdjustments(ctx, filter.AdjustmentType, filter.CreatedBy, filter.OlderThanDays)
+	if err != nil {
+		return nil, err
+	}
+
+	creators := map[int]shared.User{}
+	creatorIds := []int{}
+	if filter.CreatedBy != 0 {
+		creatorIds = append(creatorIds, filter.CreatedBy)
+	}
+	for _, adjustment := range pending {
+		creatorIds = append(creatorIds, adjustment.CreatedBy)
+	}
+
+	var options []shared.User
+	for _, id := range creatorIds {
+		if _, ok := creators[id]; ok {
+			continue
+		}
+		user, err := client.GetUser(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		creators[id] = user
+		options = append(options, user)
+	}
+
+	var adjustments QueuedInvoiceAdjustments
+	for _, adjustment := range pending {
+		adjustments = append(adjustments, QueuedInvoiceAdjustment{
+			Id:               adjustment.Id,
+			ClientId:         adjustment.ClientId,
+			CourtRef:         adjustment.CourtRef,
+			Invoice:          adjustment.InvoiceRef,
+			DateRaised:       adjustment.RaisedDate,
+			AdjustmentType:   adjustment.AdjustmentType.String(),
+			AdjustmentAmount: int(math.Abs(float64(adjustment.Amount))),
+			Notes:            adjustment.Notes,
+			CreatedBy:        adjustment.CreatedBy,
+			CreatedByName:    creators[adjustment.CreatedBy].DisplayName,
+		})
+	}
+
+	return &PendingInvoiceAdjustmentsPage{
+		Adjustments: adjustments,
+		Filter:      filter,
+		AdjustmentTypes: []shared.AdjustmentType{
+			shared.AdjustmentTypeCreditMemo,
+			shared.AdjustmentTypeDebitMemo,
+			shared.AdjustmentTypeWriteOff,
+			shared.AdjustmentTypeWriteOffReversal,
+			shared.AdjustmentTypeFeeReductionReversal,
+		},
+		Creators:   options,
+		AgeOptions: []int{7, 30, 90},
+		AppVars:    v,
+	}, nil
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPendingInvoiceAdjustmentsQueue(t *testing.T) {
+	data := shared.PendingInvoiceAdjustments{
+		{
+			Id:             4,
+			ClientId:       1,
+			CourtRef:       "12345678",
+			InvoiceRef:     "S203531/19",
+			RaisedDate:     shared.NewDate("01/04/2222"),
+			AdjustmentType: shared.AdjustmentTypeWriteOff,
+			Amount:         -12300,
+			Notes:          "Write off",
+			CreatedBy:      99,
+		},
+	}
+
+	client := mockApiClient{pendingAdjustments: data, User: shared.User{ID: 99, DisplayName: "Colette Creator"}}
+	ro := &mockRoute{client: client}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodGet, "/invoice-adjustments?type=CREDIT+WRITE+OFF&creator=99&age=30", nil)
+
+	appVars := AppVars{Path: "/path/"}
+
+	sut := PendingInvoiceAdjustmentsHandler{ro}
+	err := sut.render(appVars, w, r)
+
+	assert.Nil(t, err)
+	assert.True(t, ro.executed)
+
+	expected := &PendingInvoiceAdjustmentsPage{
+		Adjustments: QueuedInvoiceAdjustments{
+			{
+				Id:               4,
+				ClientId:         1,
+				CourtRef:         "12345678",
+				Invoice:          "S203531/19",
+				DateRaised:       shared.NewDate("01/04/2222"),
+				AdjustmentType:   "Write off",
+				AdjustmentAmount: 12300,
+				Notes:            "Write off",
+				CreatedBy:        99,
+				CreatedByName:    "Colette Creator",
+			},
+		},
+		Filter: PendingInvoiceAdjustmentsFilter{
+			AdjustmentType: "CREDIT WRITE OFF",
+			CreatedBy:      99,
+			OlderThanDays:  30,
+		},
+		AdjustmentTypes: []shared.AdjustmentType{
+			shared.AdjustmentTypeCreditMemo,
+			shared.AdjustmentTypeDebitMemo,
+			shared.AdjustmentTypeWriteOff,
+			shared.AdjustmentTypeWriteOffReversal,
+			shared.AdjustmentTypeFeeReductionReversal,
+		},
+		Creators:   []shared.User{{ID: 99, DisplayName: "Colette Creator"}},
+		AgeOptions: []int{7, 30, 90},
+		AppVars:    appVars,
+	}
+
+	assert.Equal(t, expected, ro.data)
+}
+
+func TestPendingInvoiceAdjustmentsQueue_error(t *testing.T) {
+	client := mockApiClient{error: errors.New("this has failed")}
+	ro := &mockRoute{client: client}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodGet, "/invoice-adjustments", nil)
+
+	sut := PendingInvoiceAdjustmentsHandler{ro}
+	err := sut.render(AppVars{}, w, r)
+
+	assert.Equal(t, "this has failed", err.Error())
+	assert.False(t, ro.executed)
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+type QueuedRefunds []QueuedRefund
+
+type QueuedRefund struct {
+	ID              int
+	ClientId        int
+	CourtRef        string
+	DateRaised      shared.Date
+	Amount          int
+	Status          string
+	Notes           string
+	CreatedBy       int
+	CreatedByName   string
+	DaysUntilExpiry int
+	Error           string
+}
+
+type RefundDecisionOutcome struct {
+	Status    string
+	Succeeded int
+	Failed    shared.RefundDecisions
+}
+
+type PendingRefundsPage struct {
+	Refunds QueuedRefunds
+	Outcome *RefundDecisionOutcome
+	AppVars
+}
+
+type PendingRefundsHandler struct {
+	router
+}
+
+func (h *PendingRefundsHandler) render(v AppVars, w http.ResponseWriter, r *http.Request) error {
+	data, err := newPendingRefundsPage(r.Context(), h.Client(), v)
+	if err != nil {
+		return err
+	}
+
+	return h.execute(w, r, data)
+}
+
+func newPendingRefundsPage(ctx context.Context, client ApiClient, v AppVars) (*PendingRefundsPage, error) {
+	pending, err := client.GetPendingRefunds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var refunds QueuedRefunds
+	for _, refund := range pending {
+		creator, err := client.GetUser(ctx, refund.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+
+		refunds = append(refunds, QueuedRefund{
+			ID:              refund.ID,
+			ClientId:        refund.ClientId,
+			CourtRef:        refund.CourtRef,
+			DateRaised:      refund.RaisedDate,
+			Amount:          refund.Amount,
+			Status:          refund.Status.String(),
+			Notes:           refund.Notes,
+			CreatedBy:       refund.CreatedBy,
+			CreatedByName:   creator.DisplayName,
+			DaysUntilExpiry: refund.DaysUntilExpiry,
+		})
+	}
+
+	return &PendingRefundsPage{Refunds: refunds, AppVars: v}, nil
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPendingRefundsQueue(t *testing.T) {
+	data := shared.PendingRefunds{
+		{
+			ID:              3,
+			ClientId:        1,
+			CourtRef:        "12345678",
+			RaisedDate:      shared.NewDate("01/04/2222"),
+			Amount:          232,
+			Status:          shared.RefundStatusApproved,
+			Notes:           "Some notes here",
+			CreatedBy:       99,
+			DaysUntilExpiry: 4,
+		},
+	}
+
+	client := mockApiClient{pendingRefunds: data, User: shared.User{ID: 99, DisplayName: "Colette Creator"}}
+	ro := &mockRoute{client: client}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodGet, "/refunds", nil)
+
+	appVars := AppVars{Path: "/path/"}
+
+	sut := PendingRefundsHandler{ro}
+	err := sut.render(appVars, w, r)
+
+	assert.Nil(t, err)
+	assert.True(t, ro.executed)
+
+	expected := &PendingRefundsPage{
+		Refunds: QueuedRefunds{
+			{
+				ID:              3,
+				ClientId:        1,
+				CourtRef:        "12345678",
+				DateRaised:      shared.NewDate("01/04/2222"),
+				Amount:          232,
+				Status:          "Approved",
+				Notes:           "Some notes here",
+				CreatedBy:       99,
+				CreatedByName:   "Colette Creator",
+				DaysUntilExpiry: 4,
+			},
+		},
+		AppVars: appVars,
+	}
+
+	assert.Equal(t, expected, ro.data)
+}
+
+func TestPendingRefundsQueue_error(t *testing.T) {
+	client := mockApiClient{error: errors.New("this has failed")}
+	ro := &mockRoute{client: client}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodGet, "/refunds", nil)
+
+	sut := PendingRefundsHandler{ro}
+	err := sut.render(AppVars{}, w, r)
+
+	assert.Equal(t, "this has failed", err.Error())
+	assert.False(t, ro.executed)
+}
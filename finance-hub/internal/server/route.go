@@ -62,32 +62,36 @@ func (r route) execute(w http.ResponseWriter, req *http.Request, data any) error
 
 		data.User = ctx.User
 
-		clientID := getClientID(req)
-		var person shared.Person
-		var accountInfo shared.AccountInformation
-
-		group.Go(func() error {
-			p, err := r.client.GetPersonDetails(ctx, clientID)
-			if err != nil {
-				return err
-			}
-			person = p
-			return nil
-		})
-		group.Go(func() error {
-			ai, err := r.client.GetAccountInformation(ctx, clientID)
-			if err != nil {
+		// pages that are not about a single client, such as the pending adjustments queue, have no client header
+		if req.PathValue("clientId") != "" {
+			clientID := getClientID(req)
+			var person shared.Person
+			var accountInfo shared.AccountInformation
+
+			group.Go(func() error {
+				p, err := r.client.GetPersonDetails(ctx, clientID)
+				if err != nil {
+					return err
+				}
+				person = p
+				return nil
+			})
+			group.Go(func() error {
+				ai, err := r.client.GetAccountInformation(ctx, clientID)
+				if err != nil {
+					return err
+				}
+				accountInfo = ai
+				return nil
+			})
+
+			if err := group.Wait(); err != nil {
 				return err
 			}
-			accountInfo = ai
-			return nil
-		})
 
-		if err := group.Wait(); err != nil {
-			return err
+			data.FinanceClient = r.transformFinanceClient(person, accountInfo)
 		}
 
-		data.FinanceClient = r.transformFinanceClient(person, accountInfo)
 		data.SuccessMessage = r.getSuccess(req)
 
 		return r.tmpl.Execute(w, data)
@@ -158,8 +162,14 @@ func (r route) getSuccess(req *http.Request) string {
 		return "The GT invoice has been successfully created"
 	case "payment-method":
 		return "Payment method has been successfully changed"
+	case "invoice-void":
+		return "The invoice has been voided"
+	case "manual-payment":
+		return "The payment has been successfully added"
 	case "refund-added":
 		return "The refund has been successfully added"
+	case "credit-transfer":
+		return "The credit transfer has been requested and is awaiting approval"
 	case "refunds[APPROVED]":
 		return "You have approved the refund"
 	case "refunds[REJECTED]":
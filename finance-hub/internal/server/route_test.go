@@ -127,3 +127,30 @@ func TestRoute_error(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Equal(t, "it broke", err.Error())
 }
+
+func TestRoute_fullPageWithoutClient(t *testing.T) {
+	client := mockApiClient{}
+	client.error = errors.New("should not be called")
+	template := &mockTemplate{}
+
+	w := httptest.NewRecorder()
+	user := &shared.User{ID: 123}
+	ctx := auth.Context{
+		User:    user,
+		Context: context.Background(),
+	}
+	r, _ := http.NewRequestWithContext(ctx, http.MethodGet, "", nil)
+
+	data := mockRouteData{
+		stuff:   "abc",
+		AppVars: AppVars{Path: "/path/"},
+	}
+
+	sut := route{client: client, tmpl: template, partial: "test"}
+
+	err := sut.execute(w, r, data)
+
+	assert.Nil(t, err)
+	assert.True(t, template.executed)
+	assert.Equal(t, PageData{Data: data, HeaderData: HeaderData{User: user}}, template.lastVars)
+}
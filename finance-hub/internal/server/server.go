@@ -16,25 +16,37 @@ import (
 )
 
 type ApiClient interface {
+	AddCreditTransfer(context.Context, int, string, string, string) error
 	AddFeeReduction(context.Context, int, string, string, string, string, string) error
 	AddInvoiceAdjustment(context.Context, int, int, int, string, string, string, bool) error
 	AddManualInvoice(context.Context, int, string, *string, *string, *string, *string, *string, *string) error
+	AddManualPayment(context.Context, int, string, string, string, string, string, string) error
 	AddRefund(context.Context, int, string, string, string, string) error
-	CancelFeeReduction(context.Context, int, int, string) error
+	AllocateSuspenseItem(context.Context, int, string) error
+	CancelFeeReduction(context.Context, int, int, string, bool) error
 	CancelDirectDebitMandate(context.Context, int) error
 	CreateDirectDebitMandate(context.Context, int, api.AccountDetails) error
 	GetAccountInformation(context.Context, int) (shared.AccountInformation, error)
+	GetAuditLogs(context.Context, int) (shared.AuditLogs, error)
 	GetBillingHistory(context.Context, int) ([]shared.BillingHistory, error)
 	GetFeeReductions(context.Context, int) (shared.FeeReductions, error)
 	GetInvoices(context.Context, int) (shared.Invoices, error)
 	GetInvoiceAdjustments(context.Context, int) (shared.InvoiceAdjustments, error)
+	GetPendingCreditTransfers(context.Context) (shared.CreditTransfers, error)
+	GetPendingInvoiceAdjustments(context.Context, string, int, int) (shared.PendingInvoiceAdjustments, error)
 	GetPersonDetails(context.Context, int) (shared.Person, error)
+	GetPendingRefunds(context.Context) (shared.PendingRefunds, error)
 	GetPermittedAdjustments(context.Context, int, int) ([]shared.AdjustmentType, error)
 	GetRefunds(context.Context, int) (shared.Refunds, error)
+	GetSuspenseItems(context.Context) (shared.SuspenseItems, error)
 	GetUser(context.Context, int) (shared.User, error)
+	UpdateCreditTransferDecision(context.Context, int, string) error
 	UpdatePaymentMethod(context.Context, int, string) error
 	UpdatePendingInvoiceAdjustment(context.Context, int, int, string) error
+	UpdatePendingInvoiceAdjustments(context.Context, []shared.ClientInvoiceAdjustment, string) (shared.InvoiceAdjustmentDecisions, error)
 	UpdateRefundDecision(context.Context, int, int, string) error
+	UpdateRefundDecisions(context.Context, []shared.ClientRefund, string) (shared.RefundDecisions, error)
+	VoidInvoice(context.Context, int, int, string) error
 }
 
 type router interface {
@@ -79,31 +91,48 @@ func New(logger *slog.Logger, client *api.Client, templates map[string]*template
 		mux.Handle(pattern, authenticator.Authenticate(auth.XsrfCheck(errors(h))))
 	}
 
+	handleMux("GET /clients/{clientId}/audit", &AuditHandler{&route{client: client, tmpl: templates["audit.gotmpl"], partial: "audit"}})
 	handleMux("GET /clients/{clientId}/billing-history", &BillingHistoryHandler{&route{client: client, tmpl: templates["billing-history.gotmpl"], partial: "billing-history"}})
 	handleMux("GET /clients/{clientId}/direct-debit/setup", &DirectDebitMandateHandler{&route{client: client, tmpl: templates["setup-direct-debit.gotmpl"], partial: "setup-direct-debit"}})
 	handleMux("GET /clients/{clientId}/direct-debit/cancel", &DirectDebitMandateHandler{&route{client: client, tmpl: templates["cancel-direct-debit.gotmpl"], partial: "cancel-direct-debit"}})
+	handleMux("GET /clients/{clientId}/credit-transfers/add", &AddCreditTransferHandler{&route{client: client, tmpl: templates["add-credit-transfer.gotmpl"], partial: "add-credit-transfer"}})
 	handleMux("GET /clients/{clientId}/fee-reductions", &FeeReductionsHandler{&route{client: client, tmpl: templates["fee-reductions.gotmpl"], partial: "fee-reductions"}})
 	handleMux("GET /clients/{clientId}/fee-reductions/add", &AddFeeReductionHandler{&route{client: client, tmpl: templates["add-fee-reduction.gotmpl"], partial: "add-fee-reduction"}})
 	handleMux("GET /clients/{clientId}/fee-reductions/{feeReductionId}/cancel", &CancelFeeReductionHandler{&route{client: client, tmpl: templates["cancel-fee-reduction.gotmpl"], partial: "cancel-fee-reduction"}})
 	handleMux("GET /clients/{clientId}/invoices", &InvoicesHandler{&route{client: client, tmpl: templates["invoices.gotmpl"], partial: "invoices"}})
 	handleMux("GET /clients/{clientId}/invoices/add", &AddManualInvoiceHandler{&route{client: client, tmpl: templates["add-manual-invoice.gotmpl"], partial: "add-manual-invoice"}})
 	handleMux("GET /clients/{clientId}/invoices/{invoiceId}/adjustments", &AddInvoiceAdjustmentFormHandler{&route{client: client, tmpl: templates["adjust-invoice.gotmpl"], partial: "adjust-invoice"}})
+	handleMux("GET /clients/{clientId}/invoices/{invoiceId}/void", &VoidInvoiceFormHandler{&route{client: client, tmpl: templates["void-invoice.gotmpl"], partial: "void-invoice"}})
 	handleMux("GET /clients/{clientId}/invoice-adjustments", &InvoiceAdjustmentsHandler{&route{client: client, tmpl: templates["invoice-adjustments.gotmpl"], partial: "invoice-adjustments"}})
+	handleMux("GET /clients/{clientId}/payments/add", &AddManualPaymentHandler{&route{client: client, tmpl: templates["add-manual-payment.gotmpl"], partial: "add-manual-payment"}})
 	handleMux("GET /clients/{clientId}/refunds", &RefundsHandler{&route{client: client, tmpl: templates["refunds.gotmpl"], partial: "refunds"}})
 	handleMux("GET /clients/{clientId}/refunds/add", &AddRefundHandler{&route{client: client, tmpl: templates["add-refund.gotmpl"], partial: "add-refund"}})
 	handleMux("GET /clients/{clientId}/payment-method/add", &PaymentMethodHandler{&route{client: client, tmpl: templates["set-up-payment-method.gotmpl"], partial: "set-up-payment-method"}})
 
+	handleMux("GET /credit-transfers", &PendingCreditTransfersHandler{&route{client: client, tmpl: templates["pending-credit-transfers.gotmpl"], partial: "pending-credit-transfers"}})
+	handleMux("GET /invoice-adjustments", &PendingInvoiceAdjustmentsHandler{&route{client: client, tmpl: templates["pending-invoice-adjustments.gotmpl"], partial: "pending-invoice-adjustments"}})
+	handleMux("GET /refunds", &PendingRefundsHandler{&route{client: client, tmpl: templates["pending-refunds.gotmpl"], partial: "pending-refunds"}})
+	handleMux("GET /suspense", &SuspenseHandler{&route{client: client, tmpl: templates["suspense.gotmpl"], partial: "suspense"}})
+
+	handleMux("POST /clients/{clientId}/credit-transfers", &SubmitCreditTransferHandler{&route{client: client, tmpl: templates["add-credit-transfer.gotmpl"], partial: "error-summary"}})
 	handleMux("POST /clients/{clientId}/direct-debit/setup", &SetupDirectDebitHandler{&route{client: client, tmpl: templates["setup-direct-debit.gotmpl"], partial: "error-summary"}})
 	handleMux("POST /clients/{clientId}/direct-debit/cancel", &SubmitCancelDirectDebitHandler{&route{client: client, tmpl: templates["cancel-direct-debit.gotmpl"], partial: "error-summary"}})
 	handleMux("POST /clients/{clientId}/fee-reductions/add", &SubmitFeeReductionsHandler{&route{client: client, tmpl: templates["add-fee-reduction.gotmpl"], partial: "error-summary"}})
 	handleMux("POST /clients/{clientId}/fee-reductions/{feeReductionId}/cancel", &SubmitCancelFeeReductionsHandler{&route{client: client, tmpl: templates["cancel-fee-reduction.gotmpl"], partial: "error-summary"}})
 	handleMux("POST /clients/{clientId}/invoices", &SubmitManualInvoiceHandler{&route{client: client, tmpl: templates["add-manual-invoice.gotmpl"], partial: "error-summary"}})
 	handleMux("POST /clients/{clientId}/invoices/{invoiceId}/adjustments", &SubmitInvoiceAdjustmentHandler{&route{client: client, tmpl: templates["adjust-invoice.gotmpl"], partial: "error-summary"}})
+	handleMux("POST /clients/{clientId}/invoices/{invoiceId}/void", &SubmitVoidInvoiceHandler{&route{client: client, tmpl: templates["void-invoice.gotmpl"], partial: "error-summary"}})
 	handleMux("POST /clients/{clientId}/invoice-adjustments/{adjustmentId}/{adjustmentType}/{status}", &SubmitUpdatePendingInvoiceAdjustmentHandler{&route{client: client, tmpl: templates["invoice-adjustments.gotmpl"], partial: "invoice-adjustments"}})
+	handleMux("POST /clients/{clientId}/payments", &SubmitManualPaymentHandler{&route{client: client, tmpl: templates["add-manual-payment.gotmpl"], partial: "error-summary"}})
 	handleMux("POST /clients/{clientId}/payment-method/add", &SubmitPaymentMethodHandler{&route{client: client, tmpl: templates["setup-direct-debit.gotmpl"], partial: "error-summary"}})
 	handleMux("POST /clients/{clientId}/refunds", &SubmitRefundHandler{&route{client: client, tmpl: templates["add-refund.gotmpl"], partial: "error-summary"}})
 	handleMux("POST /clients/{clientId}/refunds/{refundId}", &SubmitRefundDecisionHandler{&route{client: client, tmpl: templates["refunds.gotmpl"], partial: "refunds"}})
 
+	handleMux("POST /credit-transfers/{creditTransferId}/{status}", &SubmitCreditTransferDecisionHandler{&route{client: client, tmpl: templates["pending-credit-transfers.gotmpl"], partial: "pending-credit-transfers"}})
+	handleMux("POST /invoice-adjustments", &SubmitPendingInvoiceAdjustmentsHandler{&route{client: client, tmpl: templates["pending-invoice-adjustments.gotmpl"], partial: "pending-invoice-adjustments"}})
+	handleMux("POST /refunds", &SubmitPendingRefundsHandler{&route{client: client, tmpl: templates["pending-refunds.gotmpl"], partial: "pending-refunds"}})
+	handleMux("POST /suspense/{suspenseId}/allocate", &SubmitSuspenseAllocationHandler{&route{client: client, tmpl: templates["suspense.gotmpl"], partial: "suspense"}})
+
 	mux.Handle("/health-check", healthCheck())
 
 	static := http.FileServer(http.Dir(envs.WebDir + "/static"))
@@ -67,8 +67,17 @@ type mockApiClient struct {
 	invoiceAdjustments shared.InvoiceAdjustments
 	refunds            shared.Refunds
 	BillingHistory     []shared.BillingHistory
+	auditLogs          shared.AuditLogs
 	adjustmentTypes    []shared.AdjustmentType
 	User               shared.User
+	pendingAdjustments shared.PendingInvoiceAdjustments
+	decisions          shared.InvoiceAdjustmentDecisions
+	pendingRefunds     shared.PendingRefunds
+	refundDecisions    shared.RefundDecisions
+	suspenseItems      shared.SuspenseItems
+	allocationError    error
+	creditTransfers    shared.CreditTransfers
+	decisionError      error
 }
 
 func (m mockApiClient) CreateDirectDebitMandate(context context.Context, clientId int, details api.AccountDetails) error {
@@ -91,6 +100,10 @@ func (m mockApiClient) GetUser(context context.Context, i int) (shared.User, err
 	return m.User, m.error
 }
 
+func (m mockApiClient) GetAuditLogs(context context.Context, i int) (shared.AuditLogs, error) {
+	return m.auditLogs, m.error
+}
+
 func (m mockApiClient) GetBillingHistory(context context.Context, i int) ([]shared.BillingHistory, error) {
 	return m.BillingHistory, m.error
 }
@@ -99,6 +112,10 @@ func (m mockApiClient) AddManualInvoice(context context.Context, i int, s string
 	return m.error
 }
 
+func (m mockApiClient) AddManualPayment(context context.Context, i int, s string, s2 string, s3 string, s4 string, s5 string, s6 string) error {
+	return m.error
+}
+
 func (m mockApiClient) GetPermittedAdjustments(context.Context, int, int) ([]shared.AdjustmentType, error) {
 	return m.adjustmentTypes, nil
 }
@@ -107,11 +124,51 @@ func (m mockApiClient) UpdatePendingInvoiceAdjustment(context context.Context, i
 	return m.error
 }
 
+func (m mockApiClient) GetPendingInvoiceAdjustments(context.Context, string, int, int) (shared.PendingInvoiceAdjustments, error) {
+	return m.pendingAdjustments, m.error
+}
+
+func (m mockApiClient) UpdatePendingInvoiceAdjustments(context.Context, []shared.ClientInvoiceAdjustment, string) (shared.InvoiceAdjustmentDecisions, error) {
+	return m.decisions, m.error
+}
+
+func (m mockApiClient) GetPendingRefunds(context.Context) (shared.PendingRefunds, error) {
+	return m.pendingRefunds, m.error
+}
+
+func (m mockApiClient) UpdateRefundDecisions(context.Context, []shared.ClientRefund, string) (shared.RefundDecisions, error) {
+	return m.refundDecisions, m.error
+}
+
+func (m mockApiClient) GetSuspenseItems(context.Context) (shared.SuspenseItems, error) {
+	return m.suspenseItems, m.error
+}
+
+func (m mockApiClient) AllocateSuspenseItem(context.Context, int, string) error {
+	return m.allocationError
+}
+
+func (m mockApiClient) AddCreditTransfer(context.Context, int, string, string, string) error {
+	return m.error
+}
+
+func (m mockApiClient) GetPendingCreditTransfers(context.Context) (shared.CreditTransfers, error) {
+	return m.creditTransfers, m.error
+}
+
+func (m mockApiClient) UpdateCreditTransferDecision(context.Context, int, string) error {
+	return m.decisionError
+}
+
+func (m mockApiClient) VoidInvoice(context.Context, int, int, string) error {
+	return m.error
+}
+
 func (m mockApiClient) AddInvoiceAdjustment(context.Context, int, int, int, string, string, string, bool) error {
 	return m.error
 }
 
-func (m mockApiClient) CancelFeeReduction(context context.Context, i int, i2 int, s string) error {
+func (m mockApiClient) CancelFeeReduction(context context.Context, i int, i2 int, s string, b bool) error {
 	return m.error
 }
 
@@ -24,9 +24,10 @@ func (h *SubmitCancelFeeReductionsHandler) render(v AppVars, w http.ResponseWrit
 
 	var (
 		notes             = r.PostFormValue("cancellation-reason")
+		reverseCredits    = r.PostFormValue("reverse-credits")
 		feeReductionId, _ = strconv.Atoi(r.PathValue("feeReductionId"))
 	)
-	err := h.Client().CancelFeeReduction(ctx, clientID, feeReductionId, notes)
+	err := h.Client().CancelFeeReduction(ctx, clientID, feeReductionId, notes, reverseCredits != "")
 
 	if err == nil {
 		w.Header().Add("HX-Redirect", fmt.Sprintf("%s/clients/%d/fee-reductions?success=fee-reduction[CANCELLED]", v.EnvironmentVars.Prefix, clientID))
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-hub/internal/api"
+)
+
+type SubmitCreditTransferDecisionHandler struct {
+	router
+}
+
+func (h *SubmitCreditTransferDecisionHandler) render(v AppVars, w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	var (
+		creditTransferId, _ = strconv.Atoi(r.PathValue("creditTransferId"))
+		status              = strings.ToUpper(r.PathValue("status"))
+		failure             string
+		decided             *CreditTransferDecision
+	)
+
+	err := h.Client().UpdateCreditTransferDecision(ctx, creditTransferId, status)
+	if err == nil {
+		decided = &CreditTransferDecision{ID: creditTransferId, Status: strings.ToLower(status)}
+	} else {
+		var (
+			br    apierror.BadRequest
+			stErr api.StatusError
+		)
+		switch {
+		case errors.As(err, &br):
+			failure = br.Reason
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		case errors.As(err, &stErr):
+			v.Error = stErr.Error()
+			v.Code = stErr.Code
+			w.WriteHeader(stErr.Code)
+		default:
+			return err
+		}
+	}
+
+	data, err := newPendingCreditTransfersPage(ctx, h.Client(), v)
+	if err != nil {
+		return err
+	}
+
+	data.Decided = decided
+	for i, transfer := range data.Transfers {
+		if transfer.ID == creditTransferId {
+			data.Transfers[i].Error = failure
+		}
+	}
+
+	return h.execute(w, r, data)
+}
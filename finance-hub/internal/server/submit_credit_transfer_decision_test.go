@@ -0,0 +1,79 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-hub/internal/api"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubmitCreditTransferDecision(t *testing.T) {
+	client := mockApiClient{}
+	ro := &mockRoute{client: client}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodPost, "/credit-transfers/4/approved", nil)
+	r.SetPathValue("creditTransferId", "4")
+	r.SetPathValue("status", "approved")
+
+	sut := SubmitCreditTransferDecisionHandler{ro}
+	err := sut.render(AppVars{}, w, r)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	data := ro.data.(*PendingCreditTransfersPage)
+	assert.Equal(t, &CreditTransferDecision{ID: 4, Status: "approved"}, data.Decided)
+}
+
+func TestSubmitCreditTransferDecision_failures(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		expectedCode  int
+		expectedError string
+		expectedApp   AppVars
+	}{
+		{
+			name:          "approved by creator",
+			err:           apierror.BadRequest{Field: "createdBy", Reason: "This credit transfer cannot be approved by the user who created it"},
+			expectedCode:  http.StatusUnprocessableEntity,
+			expectedError: "This credit transfer cannot be approved by the user who created it",
+		},
+		{
+			name:         "already decided",
+			err:          api.StatusError{Code: http.StatusNotFound, URL: "/credit-transfers/4", Method: http.MethodPut},
+			expectedCode: http.StatusNotFound,
+			expectedApp:  AppVars{Error: "PUT /credit-transfers/4 returned 404", Code: http.StatusNotFound},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := mockApiClient{
+				decisionError:   tt.err,
+				creditTransfers: shared.CreditTransfers{{ID: 4, FromCourtRef: "11111111", ToCourtRef: "22222222"}},
+			}
+			ro := &mockRoute{client: client}
+
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest(http.MethodPost, "/credit-transfers/4/approved", nil)
+			r.SetPathValue("creditTransferId", "4")
+			r.SetPathValue("status", "approved")
+
+			sut := SubmitCreditTransferDecisionHandler{ro}
+			err := sut.render(AppVars{}, w, r)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expectedCode, w.Code)
+
+			data := ro.data.(*PendingCreditTransfersPage)
+			assert.Nil(t, data.Decided)
+			assert.Equal(t, tt.expectedError, data.Transfers[0].Error)
+			assert.Equal(t, tt.expectedApp, data.AppVars)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-hub/internal/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubmitCreditTransferSuccess(t *testing.T) {
+	form := url.Values{
+		"toCourtRef": {"12345678"},
+		"amount":     {"25.50"},
+		"notes":      {"Paid to the wrong client"},
+	}
+
+	client := mockApiClient{}
+	ro := &mockRoute{client: client}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodPost, "/credit-transfers", strings.NewReader(form.Encode()))
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	r.SetPathValue("clientId", "1")
+
+	appVars := AppVars{
+		Path: "/credit-transfers",
+	}
+
+	appVars.EnvironmentVars.Prefix = "prefix"
+
+	sut := SubmitCreditTransferHandler{ro}
+
+	err := sut.render(appVars, w, r)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "prefix/clients/1/refunds?success=credit-transfer", w.Header().Get("HX-Redirect"))
+}
+
+func TestSubmitCreditTransferValidationErrors(t *testing.T) {
+	client := &mockApiClient{}
+	ro := &mockRoute{client: client}
+
+	client.error = apierror.ValidationError{
+		Errors: apierror.ValidationErrors{
+			"ToCourtRef": {
+				"not-found": "Could not find a client with this court reference",
+			},
+		},
+	}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodPost, "/credit-transfers", strings.NewReader(""))
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	r.SetPathValue("clientId", "1")
+
+	sut := SubmitCreditTransferHandler{ro}
+	err := sut.render(AppVars{Path: "/credit-transfers"}, w, r)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, AppVars{Errors: map[string]map[string]string{
+		"ToCourtRef": {"not-found": "Could not find a client with this court reference"},
+	}}, ro.data)
+}
+
+func TestSubmitCreditTransferStatusError(t *testing.T) {
+	client := &mockApiClient{}
+	ro := &mockRoute{client: client}
+
+	client.error = api.StatusError{Code: http.StatusNotFound, URL: "/clients/1/credit-transfers", Method: http.MethodPost}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodPost, "/credit-transfers", strings.NewReader(""))
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	r.SetPathValue("clientId", "1")
+
+	sut := SubmitCreditTransferHandler{ro}
+	err := sut.render(AppVars{}, w, r)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, AppVars{Error: "POST /clients/1/credit-transfers returned 404", Code: http.StatusNotFound}, ro.data)
+}
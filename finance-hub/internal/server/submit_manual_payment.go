@@ -0,0 +1,51 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-hub/internal/api"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-hub/internal/util"
+)
+
+type SubmitManualPaymentHandler struct {
+	router
+}
+
+func (h *SubmitManualPaymentHandler) render(v AppVars, w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	clientID := getClientID(r)
+
+	err := h.Client().AddManualPayment(
+		ctx,
+		clientID,
+		r.PostFormValue("paymentType"),
+		r.PostFormValue("amount"),
+		r.PostFormValue("bankDate"),
+		r.PostFormValue("receivedDate"),
+		r.PostFormValue("pisNumber"),
+		r.PostFormValue("invoiceReference"),
+	)
+
+	if err == nil {
+		w.Header().Add("HX-Redirect", fmt.Sprintf("%s/clients/%d/invoices?success=manual-payment", v.EnvironmentVars.Prefix, clientID))
+	} else {
+		var (
+			valErr apierror.ValidationError
+			stErr  api.StatusError
+		)
+		if errors.As(err, &valErr) {
+			data := AppVars{Errors: util.RenameErrors(valErr.Errors)}
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			err = h.execute(w, r, data)
+		} else if errors.As(err, &stErr) {
+			data := AppVars{Error: stErr.Error(), Code: stErr.Code}
+			w.WriteHeader(stErr.Code)
+			err = h.execute(w, r, data)
+		}
+	}
+
+	return err
+}
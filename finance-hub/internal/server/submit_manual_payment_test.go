@@ -0,0 +1,84 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-hub/internal/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubmitManualPaymentSuccess(t *testing.T) {
+	form := url.Values{
+		"paymentType":      {"SUPERVISION CHEQUE PAYMENT"},
+		"amount":           {"150.00"},
+		"bankDate":         {"2024-01-02"},
+		"receivedDate":     {"2024-01-01"},
+		"pisNumber":        {"100023"},
+		"invoiceReference": {"AD11223/19"},
+	}
+
+	client := mockApiClient{}
+	ro := &mockRoute{client: client}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodPost, "/payments", strings.NewReader(form.Encode()))
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	r.SetPathValue("clientId", "1")
+
+	appVars := AppVars{Path: "/payments"}
+	appVars.EnvironmentVars.Prefix = "prefix"
+
+	sut := SubmitManualPaymentHandler{ro}
+	err := sut.render(appVars, w, r)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "prefix/clients/1/invoices?success=manual-payment", w.Header().Get("HX-Redirect"))
+}
+
+func TestSubmitManualPaymentValidationErrors(t *testing.T) {
+	client := &mockApiClient{}
+	ro := &mockRoute{client: client}
+
+	client.error = apierror.ValidationError{
+		Errors: apierror.ValidationErrors{
+			"BankDate": {"required": "This field BankDate needs to be looked at required"},
+		},
+	}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodPost, "/payments", strings.NewReader(""))
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	r.SetPathValue("clientId", "1")
+
+	sut := SubmitManualPaymentHandler{ro}
+	err := sut.render(AppVars{Path: "/payments"}, w, r)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Result().StatusCode)
+	assert.Equal(t, AppVars{Errors: apierror.ValidationErrors{
+		"BankDate": {"required": "Enter the bank date"},
+	}}, ro.data)
+}
+
+func TestSubmitManualPaymentStatusError(t *testing.T) {
+	client := &mockApiClient{}
+	ro := &mockRoute{client: client}
+
+	client.error = api.StatusError{Code: http.StatusForbidden, URL: "/clients/1/payments", Method: http.MethodPost}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodPost, "/payments", strings.NewReader(""))
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	r.SetPathValue("clientId", "1")
+
+	sut := SubmitManualPaymentHandler{ro}
+	err := sut.render(AppVars{Path: "/payments"}, w, r)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusForbidden, w.Result().StatusCode)
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-hub/internal/api"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+type SubmitPendingInvoiceAdjustmentsHandler struct {
+	router
+}
+
+func (h *SubmitPendingInvoiceAdjustmentsHandler) render(v AppVars, w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	// Limit request body size to 10MB to prevent memory exhaustion
+	r.Body = http.MaxBytesReader(w, r.Body, 10<<20)
+
+	if err := r.ParseForm(); err != nil {
+		return err
+	}
+
+	var (
+		status      = strings.ToUpper(r.PostFormValue("status"))
+		filter      = parsePendingInvoiceAdjustmentsFilter(r.PostForm)
+		adjustments = parseSelectedAdjustments(r.PostForm["adjustment"])
+		outcome     *AdjustmentDecisionOutcome
+	)
+
+	if len(adjustments) == 0 {
+		v.Errors = apierror.ValidationErrors{"adjustments": {"required": "Select at least one adjustment"}}
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	} else {
+		decisions, err := h.Client().UpdatePendingInvoiceAdjustments(ctx, adjustments, status)
+		if err != nil {
+			var stErr api.StatusError
+			if !errors.As(err, &stErr) {
+				return err
+			}
+			v.Error = stErr.Error()
+			v.Code = stErr.Code
+			w.WriteHeader(stErr.Code)
+		} else {
+			outcome = &AdjustmentDecisionOutcome{Status: strings.ToLower(status)}
+			for _, decision := range decisions {
+				if decision.Error == "" {
+					outcome.Succeeded++
+				} else {
+					outcome.Failed = append(outcome.Failed, decision)
+				}
+			}
+		}
+	}
+
+	data, err := newPendingInvoiceAdjustmentsPage(ctx, h.Client(), filter, v)
+	if err != nil {
+		return err
+	}
+
+	if outcome != nil {
+		data.Outcome = outcome
+		for i, adjustment := range data.Adjustments {
+			for _, failed := range outcome.Failed {
+				if failed.ClientId == adjustment.ClientId && failed.AdjustmentId == adjustment.Id {
+					data.Adjustments[i].Error = failed.Error
+				}
+			}
+		}
+	}
+
+	return h.execute(w, r, data)
+}
+
+// parseSelectedAdjustments reads the selected adjustments, each submitted as "clientId:adjustmentId", ignoring any
+// that are malformed
+func parseSelectedAdjustments(values []string) []shared.ClientInvoiceAdjustment {
+	var adjustments []shared.ClientInvoiceAdjustment
+	for _, value := range values {
+		clientId, adjustmentId, ok := strings.Cut(value, ":")
+		if !ok {
+			continue
+		}
+		cid, err := strconv.Atoi(clientId)
+		if err != nil {
+			continue
+		}
+		aid, err := strconv.Atoi(adjustmentId)
+		if err != nil {
+			continue
+		}
+		adjustments = append(adjustments, shared.ClientInvoiceAdjustment{ClientId: cid, AdjustmentId: aid})
+	}
+	return adjustments
+}
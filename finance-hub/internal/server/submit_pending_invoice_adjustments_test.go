@@ -0,0 +1,100 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-hub/internal/api"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubmitPendingInvoiceAdjustments(t *testing.T) {
+	failed := shared.InvoiceAdjustmentDecision{
+		ClientInvoiceAdjustment: shared.ClientInvoiceAdjustment{ClientId: 2, AdjustmentId: 5},
+		Error:                   "Unable to update the adjustment",
+	}
+	client := mockApiClient{
+		decisions: shared.InvoiceAdjustmentDecisions{
+			{ClientInvoiceAdjustment: shared.ClientInvoiceAdjustment{ClientId: 1, AdjustmentId: 4}},
+			failed,
+		},
+		pendingAdjustments: shared.PendingInvoiceAdjustments{
+			{Id: 5, ClientId: 2, AdjustmentType: shared.AdjustmentTypeCreditMemo, Amount: 100, CreatedBy: 99},
+		},
+		User: shared.User{ID: 99, DisplayName: "Colette Creator"},
+	}
+	ro := &mockRoute{client: client}
+
+	form := url.Values{
+		"status":     {"approved"},
+		"adjustment": {"1:4", "2:5"},
+		"type":       {"CREDIT MEMO"},
+	}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodPost, "/invoice-adjustments", strings.NewReader(form.Encode()))
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	sut := SubmitPendingInvoiceAdjustmentsHandler{ro}
+	err := sut.render(AppVars{}, w, r)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	data := ro.data.(*PendingInvoiceAdjustmentsPage)
+	assert.Equal(t, &AdjustmentDecisionOutcome{
+		Status:    "approved",
+		Succeeded: 1,
+		Failed:    shared.InvoiceAdjustmentDecisions{failed},
+	}, data.Outcome)
+	assert.Equal(t, "CREDIT MEMO", data.Filter.AdjustmentType)
+	assert.Equal(t, "Unable to update the adjustment", data.Adjustments[0].Error)
+}
+
+func TestSubmitPendingInvoiceAdjustments_noneSelected(t *testing.T) {
+	client := mockApiClient{}
+	ro := &mockRoute{client: client}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodPost, "/invoice-adjustments", strings.NewReader("status=approved"))
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	sut := SubmitPendingInvoiceAdjustmentsHandler{ro}
+	err := sut.render(AppVars{}, w, r)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	data := ro.data.(*PendingInvoiceAdjustmentsPage)
+	assert.Equal(t, apierror.ValidationErrors{"adjustments": {"required": "Select at least one adjustment"}}, data.Errors)
+	assert.Nil(t, data.Outcome)
+}
+
+func TestSubmitPendingInvoiceAdjustments_statusError(t *testing.T) {
+	client := mockApiClient{}
+	client.error = api.StatusError{Code: http.StatusForbidden, URL: "/invoice-adjustments", Method: http.MethodPut}
+	ro := &mockRoute{client: client}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodPost, "/invoice-adjustments", strings.NewReader("status=approved&adjustment=1:4"))
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	sut := SubmitPendingInvoiceAdjustmentsHandler{ro}
+	err := sut.render(AppVars{}, w, r)
+
+	// the mock returns the same error when the list is re-fetched, so it is passed on to the error handler
+	assert.Equal(t, client.error, err)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestParseSelectedAdjustments(t *testing.T) {
+	assert.Equal(t, []shared.ClientInvoiceAdjustment{
+		{ClientId: 1, AdjustmentId: 2},
+		{ClientId: 3, AdjustmentId: 4},
+	}, parseSelectedAdjustments([]string{"1:2", "bad", "x:1", "3:4", "1:y"}))
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-hub/internal/api"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+type SubmitPendingRefundsHandler struct {
+	router
+}
+
+func (h *SubmitPendingRefundsHandler) render(v AppVars, w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	// Limit request body size to 10MB to prevent memory exhaustion
+	r.Body = http.MaxBytesReader(w, r.Body, 10<<20)
+
+	if err := r.ParseForm(); err != nil {
+		return err
+	}
+
+	var (
+		status  = strings.ToUpper(r.PostFormValue("status"))
+		refunds = parseSelectedRefunds(r.PostForm["refund"])
+		outcome *RefundDecisionOutcome
+	)
+
+	if len(refunds) == 0 {
+		v.Errors = apierror.ValidationErrors{"refunds": {"required": "Select at least one refund"}}
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	} else {
+		decisions, err := h.Client().UpdateRefundDecisions(ctx, refunds, status)
+		if err != nil {
+			var stErr api.StatusError
+			if !errors.As(err, &stErr) {
+				return err
+			}
+			v.Error = stErr.Error()
+			v.Code = stErr.Code
+			w.WriteHeader(stErr.Code)
+		} else {
+			outcome = &RefundDecisionOutcome{Status: strings.ToLower(status)}
+			for _, decision := range decisions {
+				if decision.Error == "" {
+					outcome.Succeeded++
+				} else {
+					outcome.Failed = append(outcome.Failed, decision)
+				}
+			}
+		}
+	}
+
+	data, err := newPendingRefundsPage(ctx, h.Client(), v)
+	if err != nil {
+		return err
+	}
+
+	if outcome != nil {
+		data.Outcome = outcome
+		for i, refund := range data.Refunds {
+			for _, failed := range outcome.Failed {
+				if failed.ClientId == refund.ClientId && failed.RefundId == refund.ID {
+					data.Refunds[i].Error = failed.Error
+				}
+			}
+		}
+	}
+
+	return h.execute(w, r, data)
+}
+
+// parseSelectedRefunds reads the selected refunds, each submitted as "clientId:refundId", ignoring any that are
+// malformed
+func parseSelectedRefunds(values []string) []shared.ClientRefund {
+	var refunds []shared.ClientRefund
+	for _, value := range values {
+		clientId, refundId, ok := strings.Cut(value, ":")
+		if !ok {
+			continue
+		}
+		cid, err := strconv.Atoi(clientId)
+		if err != nil {
+			continue
+		}
+		rid, err := strconv.Atoi(refundId)
+		if err != nil {
+			continue
+		}
+		refunds = append(refunds, shared.ClientRefund{ClientId: cid, RefundId: rid})
+	}
+	return refunds
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubmitPendingRefunds(t *testing.T) {
+	failed := shared.RefundDecision{
+		ClientRefund: shared.ClientRefund{ClientId: 2, RefundId: 5},
+		Error:        "Refund is no longer pending",
+	}
+	client := mockApiClient{
+		refundDecisions: shared.RefundDecisions{
+			{ClientRefund: shared.ClientRefund{ClientId: 1, RefundId: 4}},
+			failed,
+		},
+		pendingRefunds: shared.PendingRefunds{
+			{ID: 5, ClientId: 2, Status: shared.RefundStatusApproved, CreatedBy: 99},
+		},
+	}
+	ro := &mockRoute{client: client}
+
+	form := url.Values{
+		"status": {"rejected"},
+		"refund": {"1:4", "2:5"},
+	}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodPost, "/refunds", strings.NewReader(form.Encode()))
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	sut := SubmitPendingRefundsHandler{ro}
+	err := sut.render(AppVars{}, w, r)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	data := ro.data.(*PendingRefundsPage)
+	assert.Equal(t, &RefundDecisionOutcome{
+		Status:    "rejected",
+		Succeeded: 1,
+		Failed:    shared.RefundDecisions{failed},
+	}, data.Outcome)
+	assert.Equal(t, "Refund is no longer pending", data.Refunds[0].Error)
+}
+
+func TestSubmitPendingRefunds_noneSelected(t *testing.T) {
+	client := mockApiClient{}
+	ro := &mockRoute{client: client}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodPost, "/refunds", strings.NewReader("status=approved"))
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	sut := SubmitPendingRefundsHandler{ro}
+	err := sut.render(AppVars{}, w, r)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	data := ro.data.(*PendingRefundsPage)
+	assert.Equal(t, apierror.ValidationErrors{"refunds": {"required": "Select at least one refund"}}, data.Errors)
+	assert.Nil(t, data.Outcome)
+}
+
+func TestParseSelectedRefunds(t *testing.T) {
+	assert.Equal(t, []shared.ClientRefund{
+		{ClientId: 1, RefundId: 2},
+		{ClientId: 3, RefundId: 4},
+	}, parseSelectedRefunds([]string{"1:2", "bad", "3:4", "x:1"}))
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-hub/internal/api"
+)
+
+type SubmitSuspenseAllocationHandler struct {
+	router
+}
+
+func (h *SubmitSuspenseAllocationHandler) render(v AppVars, w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	// Limit request body size to 10MB to prevent memory exhaustion
+	r.Body = http.MaxBytesReader(w, r.Body, 10<<20)
+
+	var (
+		suspenseId, _ = strconv.Atoi(r.PathValue("suspenseId"))
+		courtRef      = strings.TrimSpace(r.PostFormValue("courtRef"))
+		failure       string
+		allocated     *SuspenseAllocation
+	)
+
+	err := h.Client().AllocateSuspenseItem(ctx, suspenseId, courtRef)
+	if err == nil {
+		allocated = &SuspenseAllocation{ID: suspenseId, CourtRef: courtRef}
+	} else {
+		var (
+			ve    apierror.ValidationError
+			br    apierror.BadRequest
+			stErr api.StatusError
+		)
+		switch {
+		case errors.As(err, &ve):
+			failure = "Enter a court reference"
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		case errors.As(err, &br):
+			failure = br.Reason
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		case errors.As(err, &stErr):
+			v.Error = stErr.Error()
+			v.Code = stErr.Code
+			w.WriteHeader(stErr.Code)
+		default:
+			return err
+		}
+	}
+
+	data, err := newSuspensePage(ctx, h.Client(), v)
+	if err != nil {
+		return err
+	}
+
+	data.Allocated = allocated
+	for i, item := range data.Items {
+		if item.ID == suspenseId && failure != "" {
+			data.Items[i].AllocateTo = courtRef
+			data.Items[i].Error = failure
+		}
+	}
+
+	return h.execute(w, r, data)
+}
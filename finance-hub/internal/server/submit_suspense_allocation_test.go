@@ -0,0 +1,94 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-hub/internal/api"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubmitSuspenseAllocation(t *testing.T) {
+	client := mockApiClient{}
+	ro := &mockRoute{client: client}
+
+	form := url.Values{"courtRef": {" 12345678 "}}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodPost, "/suspense/3/allocate", strings.NewReader(form.Encode()))
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	r.SetPathValue("suspenseId", "3")
+
+	sut := SubmitSuspenseAllocationHandler{ro}
+	err := sut.render(AppVars{}, w, r)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	data := ro.data.(*SuspensePage)
+	assert.Equal(t, &SuspenseAllocation{ID: 3, CourtRef: "12345678"}, data.Allocated)
+}
+
+func TestSubmitSuspenseAllocation_failures(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		expectedCode  int
+		expectedError string
+		expectedApp   AppVars
+	}{
+		{
+			name:          "missing court reference",
+			err:           apierror.ValidationError{Errors: apierror.ValidationErrors{"CourtRef": {"required": "required"}}},
+			expectedCode:  http.StatusUnprocessableEntity,
+			expectedError: "Enter a court reference",
+		},
+		{
+			name:          "unknown court reference",
+			err:           apierror.BadRequest{Field: "courtRef", Reason: "Could not find a client with this court reference"},
+			expectedCode:  http.StatusUnprocessableEntity,
+			expectedError: "Could not find a client with this court reference",
+		},
+		{
+			name:         "already allocated",
+			err:          api.StatusError{Code: http.StatusNotFound, URL: "/suspense/3/allocate", Method: http.MethodPost},
+			expectedCode: http.StatusNotFound,
+			expectedApp:  AppVars{Error: "POST /suspense/3/allocate returned 404", Code: http.StatusNotFound},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := mockApiClient{
+				allocationError: tt.err,
+				suspenseItems:   shared.SuspenseItems{{ID: 3, CourtRef: "99999999"}},
+			}
+			ro := &mockRoute{client: client}
+
+			form := url.Values{"courtRef": {"12345678"}}
+
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest(http.MethodPost, "/suspense/3/allocate", strings.NewReader(form.Encode()))
+			r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+			r.SetPathValue("suspenseId", "3")
+
+			sut := SubmitSuspenseAllocationHandler{ro}
+			err := sut.render(AppVars{}, w, r)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expectedCode, w.Code)
+
+			data := ro.data.(*SuspensePage)
+			assert.Nil(t, data.Allocated)
+			assert.Equal(t, tt.expectedError, data.Items[0].Error)
+			assert.Equal(t, tt.expectedApp, data.AppVars)
+			if tt.expectedError != "" {
+				assert.Equal(t, "12345678", data.Items[0].AllocateTo)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-hub/internal/api"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-hub/internal/util"
+)
+
+type SubmitVoidInvoiceHandler struct {
+	router
+}
+
+func (h *SubmitVoidInvoiceHandler) render(v AppVars, w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	clientID := getClientID(r)
+
+	// Limit request body size to 10MB to prevent memory exhaustion
+	r.Body = http.MaxBytesReader(w, r.Body, 10<<20)
+
+	var (
+		invoiceId, _ = strconv.Atoi(r.PathValue("invoiceId"))
+		voidReason   = r.PostFormValue("voidReason")
+	)
+
+	err := h.Client().VoidInvoice(ctx, clientID, invoiceId, voidReason)
+
+	if err == nil {
+		w.Header().Add("HX-Redirect", fmt.Sprintf("%s/clients/%d/invoices?success=invoice-void", v.EnvironmentVars.Prefix, clientID))
+		return nil
+	}
+
+	var (
+		ve    apierror.ValidationError
+		br    apierror.BadRequest
+		stErr api.StatusError
+		data  AppVars
+	)
+	switch {
+	case errors.As(err, &ve):
+		{
+			data = AppVars{Errors: util.RenameErrors(ve.Errors)}
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}
+	case errors.As(err, &br):
+		{
+			data = AppVars{Errors: apierror.ValidationErrors{"VoidReason": map[string]string{"voided": br.Reason}}}
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}
+	case errors.As(err, &stErr):
+		{
+			data = AppVars{Error: stErr.Error(), Code: stErr.Code}
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}
+	default:
+		data = AppVars{Error: err.Error()}
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	return h.execute(w, r, data)
+}
@@ -0,0 +1,111 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/apierror"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/finance-hub/internal/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubmitVoidInvoiceSuccess(t *testing.T) {
+	form := url.Values{
+		"voidReason": {"Raised in error"},
+	}
+
+	client := mockApiClient{}
+	ro := &mockRoute{client: client}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodPost, "/invoices/2/void", strings.NewReader(form.Encode()))
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	r.SetPathValue("clientId", "1")
+	r.SetPathValue("invoiceId", "2")
+
+	appVars := AppVars{
+		Path: "/invoices/2/void",
+	}
+
+	appVars.EnvironmentVars.Prefix = "prefix"
+
+	sut := SubmitVoidInvoiceHandler{ro}
+
+	err := sut.render(appVars, w, r)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "prefix/clients/1/invoices?success=invoice-void", w.Header().Get("HX-Redirect"))
+}
+
+func TestSubmitVoidInvoiceValidationErrors(t *testing.T) {
+	client := &mockApiClient{}
+	ro := &mockRoute{client: client}
+
+	client.error = apierror.ValidationError{
+		Errors: apierror.ValidationErrors{
+			"VoidReason": {
+				"required": "This field VoidReason needs to be looked at required",
+			},
+		},
+	}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodPost, "/invoices/2/void", strings.NewReader(""))
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	r.SetPathValue("clientId", "1")
+	r.SetPathValue("invoiceId", "2")
+
+	sut := SubmitVoidInvoiceHandler{ro}
+	err := sut.render(AppVars{Path: "/invoices/2/void"}, w, r)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, AppVars{Errors: map[string]map[string]string{
+		"VoidReason": {"required": "Enter a reason for voiding the invoice"},
+	}}, ro.data)
+}
+
+func TestSubmitVoidInvoiceAlreadyVoided(t *testing.T) {
+	client := &mockApiClient{}
+	ro := &mockRoute{client: client}
+
+	client.error = apierror.BadRequest{Field: "invoice", Reason: "Invoice AD11111/24 has already been voided"}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodPost, "/invoices/2/void", strings.NewReader(""))
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	r.SetPathValue("clientId", "1")
+	r.SetPathValue("invoiceId", "2")
+
+	sut := SubmitVoidInvoiceHandler{ro}
+	err := sut.render(AppVars{}, w, r)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, AppVars{Errors: map[string]map[string]string{
+		"VoidReason": {"voided": "Invoice AD11111/24 has already been voided"},
+	}}, ro.data)
+}
+
+func TestSubmitVoidInvoiceStatusError(t *testing.T) {
+	client := &mockApiClient{}
+	ro := &mockRoute{client: client}
+
+	client.error = api.StatusError{Code: http.StatusNotFound, URL: "/clients/1/invoices/2/void", Method: http.MethodPost}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodPost, "/invoices/2/void", strings.NewReader(""))
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	r.SetPathValue("clientId", "1")
+	r.SetPathValue("invoiceId", "2")
+
+	sut := SubmitVoidInvoiceHandler{ro}
+	err := sut.render(AppVars{}, w, r)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, AppVars{Error: "POST /clients/1/invoices/2/void returned 404", Code: http.StatusNotFound}, ro.data)
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+)
+
+type SuspenseRows []SuspenseRow
+
+type SuspenseRow struct {
+	ID            int
+	CourtRef      string
+	Amount        int
+	PaymentType   string
+	BankDate      shared.Date
+	ReceivedDate  shared.Date
+	UploadDate    shared.Date
+	CreatedByName string
+	AllocateTo    string
+	Error         string
+}
+
+type SuspenseAllocation struct {
+	ID       int
+	CourtRef string
+}
+
+type SuspensePage struct {
+	Items     SuspenseRows
+	Allocated *SuspenseAllocation
+	AppVars
+}
+
+type SuspenseHandler struct {
+	router
+}
+
+func (h *SuspenseHandler) render(v AppVars, w http.ResponseWriter, r *http.Request) error {
+	data, err := newSuspensePage(r.Context(), h.Client(), v)
+	if err != nil {
+		return err
+	}
+
+	return h.execute(w, r, data)
+}
+
+func newSuspensePage(ctx context.Context, client ApiClient, v AppVars) (*SuspensePage, error) {
+	items, err := client.GetSuspenseItems(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	creators := map[int]shared.User{}
+
+	var rows SuspenseRows
+	for _, item := range items {
+		creator, ok := creators[item.CreatedBy]
+		if !ok {
+			creator, err = client.GetUser(ctx, item.CreatedBy)
+			if err != nil {
+				return nil, err
+			}
+			creators[item.CreatedBy] = creator
+		}
+
+		rows = append(rows, SuspenseRow{
+			ID:            item.ID,
+			CourtRef:      item.CourtRef,
+			Amount:        item.Amount,
+			PaymentType:   item.LedgerType.String(),
+			BankDate:      item.BankDate,
+			ReceivedDate:  item.ReceivedDate,
+			UploadDate:    item.CreatedDate,
+			CreatedByName: creator.DisplayName,
+		})
+	}
+
+	return &SuspensePage{Items: rows, AppVars: v}, nil
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuspense(t *testing.T) {
+	data := shared.SuspenseItems{
+		{
+			ID:           3,
+			CourtRef:     "99999999",
+			Amount:       12345,
+			LedgerType:   shared.TransactionTypeMotoCardPayment,
+			BankDate:     shared.NewDate("01/04/2222"),
+			ReceivedDate: shared.NewDate("31/03/2222"),
+			UploadType:   shared.ReportTypeUploadPaymentsMOTOCard,
+			CreatedDate:  shared.NewDate("02/04/2222"),
+			CreatedBy:    99,
+		},
+	}
+
+	client := mockApiClient{suspenseItems: data, User: shared.User{ID: 99, DisplayName: "Ulrich Uploader"}}
+	ro := &mockRoute{client: client}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodGet, "/suspense", nil)
+
+	appVars := AppVars{Path: "/path/"}
+
+	sut := SuspenseHandler{ro}
+	err := sut.render(appVars, w, r)
+
+	assert.Nil(t, err)
+	assert.True(t, ro.executed)
+
+	expected := &SuspensePage{
+		Items: SuspenseRows{
+			{
+				ID:            3,
+				CourtRef:      "99999999",
+				Amount:        12345,
+				PaymentType:   "MOTO card payment",
+				BankDate:      shared.NewDate("01/04/2222"),
+				ReceivedDate:  shared.NewDate("31/03/2222"),
+				UploadDate:    shared.NewDate("02/04/2222"),
+				CreatedByName: "Ulrich Uploader",
+			},
+		},
+		AppVars: appVars,
+	}
+
+	assert.Equal(t, expected, ro.data)
+}
+
+func TestSuspense_error(t *testing.T) {
+	client := mockApiClient{error: errors.New("this has failed")}
+	ro := &mockRoute{client: client}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodGet, "/suspense", nil)
+
+	sut := SuspenseHandler{ro}
+	err := sut.render(AppVars{}, w, r)
+
+	assert.Equal(t, "this has failed", err.Error())
+	assert.False(t, ro.executed)
+}
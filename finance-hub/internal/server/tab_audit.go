@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"golang.org/x/exp/maps"
+)
+
+type AuditLogs []AuditLog
+
+type AuditLog struct {
+	Date    string
+	User    string
+	Action  string
+	Entity  string
+	Changes []AuditChange
+}
+
+type AuditChange struct {
+	Field  string
+	Before string
+	After  string
+}
+
+type AuditTab struct {
+	AuditLogs AuditLogs
+	AppVars
+}
+
+type AuditHandler struct {
+	router
+}
+
+func (h *AuditHandler) render(v AppVars, w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	clientID := getClientID(r)
+
+	auditLogs, err := h.Client().GetAuditLogs(ctx, clientID)
+	if err != nil {
+		return err
+	}
+
+	data := &AuditTab{h.transform(ctx, auditLogs), v}
+	data.selectTab("audit")
+	return h.execute(w, r, data)
+}
+
+func (h *AuditHandler) transform(ctx context.Context, in shared.AuditLogs) AuditLogs {
+	logger := h.logger(ctx)
+
+	var out AuditLogs
+	for _, al := range in {
+		user, err := h.Client().GetUser(ctx, al.CreatedBy)
+		if err != nil {
+			logger.Error("error fetching user from cache", "error", err)
+		}
+
+		out = append(out, AuditLog{
+			Date:    al.CreatedAt.Format("02/01/2006 15:04"),
+			User:    user.DisplayName,
+			Action:  sentenceCase(al.Action),
+			Entity:  sentenceCase(al.EntityType) + " " + strconv.Itoa(al.EntityID),
+			Changes: auditChanges(al.Before, al.After),
+		})
+	}
+
+	return out
+}
+
+// auditChanges lists each field recorded against an audit log in a stable order, with its before and after values
+func auditChanges(before map[string]string, after map[string]string) []AuditChange {
+	fields := maps.Keys(before)
+	for field := range after {
+		if _, ok := before[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	slices.Sort(fields)
+
+	var changes []AuditChange
+	for _, field := range fields {
+		changes = append(changes, AuditChange{
+			Field:  field,
+			Before: before[field],
+			After:  after[field],
+		})
+	}
+	return changes
+}
+
+func sentenceCase(s string) string {
+	if s == "" {
+		return s
+	}
+	lower := strings.ToLower(s)
+	return strings.ToUpper(lower[:1]) + lower[1:]
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ministryofjustice/opg-go-common/telemetry"
+	"github.com/ministryofjustice/opg-sirius-supervision-finance-hub/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAudit(t *testing.T) {
+	data := shared.AuditLogs{
+		{
+			ID:         1,
+			Action:     shared.AuditActionFeeReductionCancelled,
+			EntityType: shared.AuditEntityFeeReduction,
+			EntityID:   3,
+			Before:     map[string]string{"cancelled": "false"},
+			After:      map[string]string{"cancelled": "true", "cancellationReason": "Awarded in error"},
+			CreatedAt:  time.Date(2025, 6, 4, 9, 30, 0, 0, time.UTC),
+			CreatedBy:  10,
+		},
+	}
+
+	client := mockApiClient{
+		auditLogs: data,
+		User:      shared.User{DisplayName: "Mr Testman"},
+	}
+	ro := &mockRoute{client: client}
+
+	w := httptest.NewRecorder()
+	ctx := telemetry.ContextWithLogger(context.Background(), telemetry.NewLogger("opg-sirius-supervision-finance-hub"))
+	r, _ := http.NewRequestWithContext(ctx, http.MethodGet, "test-url/1", nil)
+	r.SetPathValue("clientId", "1")
+
+	appVars := AppVars{Path: "/path/"}
+
+	sut := AuditHandler{ro}
+	err := sut.render(appVars, w, r)
+
+	assert.Nil(t, err)
+	assert.True(t, ro.executed)
+
+	expected := &AuditTab{
+		AuditLogs: AuditLogs{
+			{
+				Date:   "04/06/2025 09:30",
+				User:   "Mr Testman",
+				Action: "Fee reduction cancelled",
+				Entity: "Fee reduction 3",
+				Changes: []AuditChange{
+					{Field: "cancellationReason", After: "Awarded in error"},
+					{Field: "cancelled", Before: "false", After: "true"},
+				},
+			},
+		},
+		AppVars: appVars,
+	}
+
+	assert.Equal(t, expected, ro.data)
+}
+
+func TestAuditErrors(t *testing.T) {
+	client := mockApiClient{}
+	client.error = errors.New("this has failed")
+	ro := &mockRoute{client: client}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodGet, "", nil)
+	r.SetPathValue("clientId", "1")
+
+	appVars := AppVars{Path: "/path/"}
+
+	sut := AuditHandler{ro}
+	err := sut.render(appVars, w, r)
+
+	assert.Equal(t, "this has failed", err.Error())
+	assert.False(t, ro.executed)
+}
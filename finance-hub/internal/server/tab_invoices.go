@@ -40,6 +40,7 @@ type Invoice struct {
 	RaisedDate         string
 	Received           int
 	OutstandingBalance int
+	Voided             bool
 	Ledgers            LedgerAllocations
 	SupervisionLevels  SupervisionLevels
 	ClientId           int
@@ -82,6 +83,7 @@ func (h *InvoicesHandler) transform(in shared.Invoices, clientId int) Invoices {
 			RaisedDate:         invoice.RaisedDate.String(),
 			Received:           invoice.Received,
 			OutstandingBalance: invoice.OutstandingBalance,
+			Voided:             invoice.Voided,
 			Ledgers:            h.transformLedgers(invoice.Ledgers, caser),
 			SupervisionLevels:  h.transformSupervisionLevels(invoice.SupervisionLevels, caser),
 			ClientId:           clientId,
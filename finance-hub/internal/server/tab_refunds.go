@@ -27,9 +27,10 @@ type BankDetails struct {
 }
 
 type RefundsTab struct {
-	Refunds       Refunds
-	ShowAddRefund bool
-	ClientId      string
+	Refunds            Refunds
+	ShowAddRefund      bool
+	ShowTransferCredit bool
+	ClientId           string
 	AppVars
 }
 
@@ -46,7 +47,7 @@ func (h *RefundsHandler) render(v AppVars, w http.ResponseWriter, r *http.Reques
 		return err
 	}
 
-	data := &RefundsTab{Refunds: h.transform(refunds), ShowAddRefund: h.shouldShowAddRefund(refunds), ClientId: strconv.Itoa(clientID), AppVars: v}
+	data := &RefundsTab{Refunds: h.transform(refunds), ShowAddRefund: h.shouldShowAddRefund(refunds), ShowTransferCredit: refunds.CreditBalance > 0, ClientId: strconv.Itoa(clientID), AppVars: v}
 	data.selectTab("refunds")
 
 	return h.execute(w, r, data)
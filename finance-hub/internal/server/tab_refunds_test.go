@@ -68,10 +68,11 @@ func TestRefunds(t *testing.T) {
 	}
 
 	expected := &RefundsTab{
-		Refunds:       out,
-		ShowAddRefund: true,
-		ClientId:      "1",
-		AppVars:       appVars,
+		Refunds:            out,
+		ShowAddRefund:      true,
+		ShowTransferCredit: true,
+		ClientId:           "1",
+		AppVars:            appVars,
 	}
 
 	assert.Equal(t, expected, ro.data)
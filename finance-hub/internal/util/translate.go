@@ -23,6 +23,8 @@ var validationMappings = map[string]map[string]pair{
 		"required_if":      pair{"Amount", "Enter an amount"},
 		"nillable-int-lte": pair{"Amount", "Amount can't be above £320"},
 		"nillable-int-gt":  pair{"Amount", "Enter an amount"},
+		"gt":               pair{"Amount", "Enter an amount"},
+		"credit-available": pair{"Amount", "The amount is more than the credit available to transfer"},
 	},
 	"FeeType": {
 		"required": pair{"FeeType", "A fee reduction type must be selected"},
@@ -52,10 +54,18 @@ var validationMappings = map[string]map[string]pair{
 		"required":                 pair{"Notes", "Enter a reason for the refund"},
 		"thousand-character-limit": pair{"Notes", "Reason for the refund must be 1000 characters or less"},
 	},
+	"TransferNotes": {
+		"required":                 pair{"Notes", "Enter a reason for the credit transfer"},
+		"thousand-character-limit": pair{"Notes", "Reason for the credit transfer must be 1000 characters or less"},
+	},
 	"CancellationReason": {
 		"required":                 pair{"CancellationReason", "Enter a reason for cancelling fee reduction"},
 		"thousand-character-limit": pair{"CancellationReason", "Reason for cancellation must be 1000 characters or less"},
 	},
+	"VoidReason": {
+		"required":                 pair{"VoidReason", "Enter a reason for voiding the invoice"},
+		"thousand-character-limit": pair{"VoidReason", "Reason for voiding the invoice must be 1000 characters or less"},
+	},
 	"Overlap": {
 		"start-or-end-date": pair{"start-or-end-date", "A fee reduction already exists for the period specified"},
 	},
@@ -108,6 +118,33 @@ var validationMappings = map[string]map[string]pair{
 	"AccountDetails": {
 		"invalid": pair{"AccountDetails", "The account number and sort code are not a valid combination."},
 	},
+	"PaymentType": {
+		"required":   pair{"PaymentType", "Select the payment type"},
+		"valid-enum": pair{"PaymentType", "Select the payment type"},
+	},
+	"BankDate": {
+		"required":         pair{"BankDate", "Enter the bank date"},
+		"date-in-the-past": pair{"BankDate", "Bank date must be today or in the past"},
+	},
+	"ReceivedDate": {
+		"required":         pair{"ReceivedDate", "Enter the received date"},
+		"date-in-the-past": pair{"ReceivedDate", "Received date must be today or in the past"},
+	},
+	"PisNumber": {
+		"required": pair{"PisNumber", "Enter a PIS number for a cheque payment"},
+		"gt":       pair{"PisNumber", "Enter a valid PIS number"},
+	},
+	"ToCourtRef": {
+		"required":    pair{"ToCourtRef", "Enter the court reference of the client receiving the credit"},
+		"same-client": pair{"ToCourtRef", "Credit cannot be transferred to the same client"},
+		"not-found":   pair{"ToCourtRef", "Could not find a client with this court reference"},
+	},
+	"InvoiceReference": {
+		"unpaid-invoice": pair{"InvoiceReference", "Enter the reference of an unpaid invoice for this client"},
+	},
+	"Duplicate": {
+		"duplicate": pair{"Duplicate", "A payment with these details has already been added"},
+	},
 	"Allpay": {
 		"invalid": pair{"Allpay", "Direct Debit cannot be setup due to an unexpected response from AllPay. Please try again later."},
 	},
@@ -0,0 +1,26 @@
+-- +goose Up
+CREATE TABLE upload_job
+(
+    id                INTEGER   NOT NULL PRIMARY KEY,
+    upload_type       VARCHAR   NOT NULL,
+    filename          VARCHAR   NOT NULL,
+    file_hash         VARCHAR   NOT NULL,
+    status            VARCHAR   NOT NULL,
+    line_count        INTEGER,
+    failed_line_count INTEGER,
+    failed_lines      JSONB,
+    error             VARCHAR,
+    started_at        TIMESTAMP NOT NULL,
+    ended_at          TIMESTAMP,
+    created_by        INTEGER   NOT NULL
+);
+
+CREATE INDEX idx_upload_job_started_at ON upload_job (started_at);
+CREATE INDEX idx_upload_job_file_hash ON upload_job (file_hash);
+CREATE SEQUENCE upload_job_id_seq;
+
+-- +goose Down
+DROP INDEX idx_upload_job_started_at;
+DROP INDEX idx_upload_job_file_hash;
+DROP SEQUENCE upload_job_id_seq;
+DROP TABLE upload_job;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE upload_job ADD COLUMN upload_date DATE;
+ALTER TABLE upload_job ADD COLUMN email_address VARCHAR;
+
+-- +goose Down
+ALTER TABLE upload_job DROP COLUMN upload_date;
+ALTER TABLE upload_job DROP COLUMN email_address;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE upload_job ADD COLUMN processed_line_count INTEGER;
+
+-- +goose Down
+ALTER TABLE upload_job DROP COLUMN processed_line_count;
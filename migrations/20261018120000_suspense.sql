@@ -0,0 +1,30 @@
+-- +goose Up
+CREATE TABLE suspense
+(
+    id                  INTEGER      NOT NULL PRIMARY KEY,
+    court_ref           VARCHAR(255),
+    amount              INTEGER      NOT NULL,
+    type                VARCHAR(255) NOT NULL,
+    bank_date           DATE,
+    received_date       TIMESTAMP,
+    pis_number          INTEGER,
+    upload_type         VARCHAR(255) NOT NULL,
+    line                JSONB        NOT NULL,
+    created_at          TIMESTAMP    NOT NULL,
+    created_by          INTEGER      NOT NULL,
+    allocated_court_ref VARCHAR(255),
+    ledger_id           INTEGER REFERENCES ledger (id),
+    allocated_at        TIMESTAMP,
+    allocated_by        INTEGER
+);
+
+CREATE INDEX idx_suspense_court_ref ON suspense (court_ref);
+CREATE SEQUENCE suspense_id_seq;
+
+INSERT INTO account VALUES (1816102007, 'CA - TRADE RECEIVABLES - SUSPENSE – SIRIUS SUPERVISION', 99999999);
+
+-- +goose Down
+DELETE FROM account WHERE code = 1816102007;
+DROP SEQUENCE suspense_id_seq;
+DROP INDEX idx_suspense_court_ref;
+DROP TABLE suspense;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE ledger ADD COLUMN allocation_strategy VARCHAR(255);
+
+-- +goose Down
+ALTER TABLE ledger DROP COLUMN allocation_strategy;
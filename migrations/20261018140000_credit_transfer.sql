@@ -0,0 +1,29 @@
+-- +goose Up
+CREATE TABLE credit_transfer
+(
+    id                     INTEGER      NOT NULL PRIMARY KEY,
+    from_finance_client_id INTEGER      NOT NULL REFERENCES finance_client (id),
+    to_finance_client_id   INTEGER      NOT NULL REFERENCES finance_client (id),
+    amount                 INTEGER      NOT NULL,
+    notes                  TEXT         NOT NULL,
+    status                 VARCHAR(255) NOT NULL,
+    created_at             TIMESTAMP    NOT NULL,
+    created_by             INTEGER      NOT NULL,
+    decision_at            TIMESTAMP,
+    decision_by            INTEGER,
+    from_ledger_id         INTEGER REFERENCES ledger (id),
+    to_ledger_id           INTEGER REFERENCES ledger (id)
+);
+
+CREATE INDEX idx_credit_transfer_status ON credit_transfer (status);
+CREATE SEQUENCE credit_transfer_id_seq;
+
+INSERT INTO transaction_type (fee_type, supervision_level, ledger_type, account_code, description, line_description, is_receipt)
+VALUES ('CTO', '', '', 1816102003, 'Credit transferred to another client', 'Credit transfer out', false),
+       ('CTI', '', '', 1816102003, 'Credit transferred from another client', 'Credit transfer in', false);
+
+-- +goose Down
+DELETE FROM transaction_type WHERE fee_type IN ('CTO', 'CTI');
+DROP SEQUENCE credit_transfer_id_seq;
+DROP INDEX idx_credit_transfer_status;
+DROP TABLE credit_transfer;
@@ -0,0 +1,23 @@
+-- +goose Up
+ALTER TABLE invoice
+    ADD COLUMN voided_at      TIMESTAMP,
+    ADD COLUMN voided_by      INTEGER,
+    ADD COLUMN void_reason    TEXT,
+    ADD COLUMN void_ledger_id INTEGER REFERENCES ledger (id);
+
+INSERT INTO transaction_type (fee_type, supervision_level, ledger_type, account_code, description, line_description, is_receipt)
+VALUES ('IV', 'AD', 'INVOICE VOID', 4481102093, 'Invoice void', 'AD Invoice void', false),
+       ('IV', 'GENERAL', 'INVOICE VOID', 4481102094, 'Invoice void', 'Gen Invoice void', false),
+       ('IV', 'MINIMAL', 'INVOICE VOID', 4481102099, 'Invoice void', 'Min Invoice void', false),
+       ('IV', 'GA', 'INVOICE VOID', 4481102107, 'Invoice void', 'GA Invoice void', false),
+       ('IV', 'GS', 'INVOICE VOID', 4481102107, 'Invoice void', 'GS Invoice void', false),
+       ('IV', 'GT', 'INVOICE VOID', 4481102107, 'Invoice void', 'GT Invoice void', false);
+
+-- +goose Down
+DELETE FROM transaction_type WHERE fee_type = 'IV';
+
+ALTER TABLE invoice
+    DROP COLUMN void_ledger_id,
+    DROP COLUMN void_reason,
+    DROP COLUMN voided_by,
+    DROP COLUMN voided_at;
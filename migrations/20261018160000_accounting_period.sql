@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE accounting_period
+(
+    id           INTEGER   NOT NULL PRIMARY KEY,
+    period_start DATE      NOT NULL UNIQUE,
+    closed_at    TIMESTAMP NOT NULL,
+    closed_by    INTEGER   NOT NULL
+);
+
+CREATE SEQUENCE accounting_period_id_seq;
+
+-- +goose Down
+DROP SEQUENCE accounting_period_id_seq;
+DROP TABLE accounting_period;
@@ -0,0 +1,38 @@
+-- +goose Up
+CREATE TABLE audit_log
+(
+    id                INTEGER      NOT NULL PRIMARY KEY,
+    finance_client_id INTEGER REFERENCES finance_client (id),
+    action            VARCHAR(255) NOT NULL,
+    entity_type       VARCHAR(255) NOT NULL,
+    entity_id         INTEGER      NOT NULL,
+    before            JSONB,
+    after             JSONB,
+    created_at        TIMESTAMP    NOT NULL,
+    created_by        INTEGER      NOT NULL
+);
+
+CREATE INDEX idx_audit_log_finance_client_id ON audit_log (finance_client_id);
+CREATE SEQUENCE audit_log_id_seq;
+
+-- +goose StatementBegin
+CREATE FUNCTION audit_log_immutable() RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER audit_log_immutable
+    BEFORE UPDATE OR DELETE OR TRUNCATE
+    ON audit_log
+    FOR EACH STATEMENT
+EXECUTE FUNCTION audit_log_immutable();
+
+-- +goose Down
+DROP TRIGGER audit_log_immutable ON audit_log;
+DROP FUNCTION audit_log_immutable;
+DROP SEQUENCE audit_log_id_seq;
+DROP INDEX idx_audit_log_finance_client_id;
+DROP TABLE audit_log;
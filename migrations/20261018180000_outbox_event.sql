@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE outbox_event
+(
+    id               INTEGER      NOT NULL PRIMARY KEY,
+    ordering_key     VARCHAR(255) NOT NULL,
+    event_type       VARCHAR(255) NOT NULL,
+    detail           JSONB        NOT NULL,
+    created_at       TIMESTAMP    NOT NULL,
+    attempts         INTEGER      NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMP    NOT NULL,
+    last_error       TEXT,
+    published_at     TIMESTAMP,
+    dead_lettered_at TIMESTAMP
+);
+
+CREATE INDEX idx_outbox_event_unpublished ON outbox_event (id) WHERE published_at IS NULL AND dead_lettered_at IS NULL;
+CREATE INDEX idx_outbox_event_dead_lettered ON outbox_event (id) WHERE dead_lettered_at IS NOT NULL;
+CREATE SEQUENCE outbox_event_id_seq;
+
+-- +goose Down
+DROP SEQUENCE outbox_event_id_seq;
+DROP INDEX idx_outbox_event_dead_lettered;
+DROP INDEX idx_outbox_event_unpublished;
+DROP TABLE outbox_event;
@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE processed_event
+(
+    id           INTEGER      NOT NULL PRIMARY KEY,
+    source       VARCHAR(255) NOT NULL,
+    detail_type  VARCHAR(255) NOT NULL,
+    event_key    VARCHAR(255) NOT NULL,
+    payload      JSONB        NOT NULL,
+    status       VARCHAR      NOT NULL,
+    attempts     INTEGER      NOT NULL DEFAULT 1,
+    error        VARCHAR,
+    received_at  TIMESTAMP    NOT NULL,
+    started_at   TIMESTAMP    NOT NULL,
+    processed_at TIMESTAMP,
+    CONSTRAINT processed_event_unique UNIQUE (source, detail_type, event_key)
+);
+
+CREATE INDEX idx_processed_event_status ON processed_event (status);
+CREATE SEQUENCE processed_event_id_seq;
+
+-- +goose Down
+DROP INDEX idx_processed_event_status;
+DROP SEQUENCE processed_event_id_seq;
+DROP TABLE processed_event;
@@ -0,0 +1,23 @@
+-- +goose Up
+CREATE TABLE report_subscription
+(
+    id               INTEGER      NOT NULL PRIMARY KEY,
+    name             VARCHAR(255) NOT NULL,
+    report_request   JSONB        NOT NULL,
+    recipients       VARCHAR[]    NOT NULL,
+    cadence          VARCHAR(20)  NOT NULL,
+    transaction_date VARCHAR(50),
+    from_date        VARCHAR(50),
+    to_date          VARCHAR(50),
+    last_run_on      DATE,
+    created_at       TIMESTAMP    NOT NULL,
+    created_by       INTEGER      NOT NULL,
+    cancelled_at     TIMESTAMP,
+    cancelled_by     INTEGER
+);
+
+CREATE SEQUENCE report_subscription_id_seq;
+
+-- +goose Down
+DROP SEQUENCE report_subscription_id_seq;
+DROP TABLE report_subscription;
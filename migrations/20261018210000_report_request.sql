@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE TABLE report_request
+(
+    id             INTEGER   NOT NULL PRIMARY KEY,
+    report_request JSONB     NOT NULL,
+    status         VARCHAR   NOT NULL,
+    requested_at   TIMESTAMP NOT NULL,
+    requested_by   INTEGER   NOT NULL,
+    completed_at   TIMESTAMP,
+    row_count      INTEGER,
+    s3_key         VARCHAR,
+    s3_version_id  VARCHAR,
+    error          VARCHAR
+);
+
+CREATE INDEX idx_report_request_requested_by ON report_request (requested_by, requested_at);
+CREATE SEQUENCE report_request_id_seq;
+
+-- +goose Down
+DROP INDEX idx_report_request_requested_by;
+DROP SEQUENCE report_request_id_seq;
+DROP TABLE report_request;
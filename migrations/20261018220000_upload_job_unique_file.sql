@@ -0,0 +1,8 @@
+-- +goose Up
+ALTER TABLE upload_job ADD COLUMN posts_ledgers BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE UNIQUE INDEX idx_upload_job_unique_file ON upload_job (file_hash, upload_type) WHERE posts_ledgers AND status != 'FAILED';
+
+-- +goose Down
+DROP INDEX idx_upload_job_unique_file;
+ALTER TABLE upload_job DROP COLUMN posts_ledgers;
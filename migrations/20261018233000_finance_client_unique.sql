@@ -0,0 +1,8 @@
+-- +goose Up
+CREATE UNIQUE INDEX idx_finance_client_client_id ON finance_client (client_id);
+
+CREATE UNIQUE INDEX idx_billing_period_finance_client_order ON billing_period (finance_client_id, order_id);
+
+-- +goose Down
+DROP INDEX idx_billing_period_finance_client_order;
+DROP INDEX idx_finance_client_client_id;
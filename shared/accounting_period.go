@@ -0,0 +1,25 @@
+package shared
+
+const (
+	AccountingPeriodStatusOpen   = "OPEN"
+	AccountingPeriodStatusClosed = "CLOSED"
+)
+
+type AccountingPeriods []AccountingPeriod
+
+// AccountingPeriod is a calendar month of postings. Once closed, no further ledgers can be posted into it, so that
+// journals already submitted to the general ledger do not change.
+type AccountingPeriod struct {
+	Period   Date   `json:"period"`
+	Status   string `json:"status"`
+	ClosedAt *Date  `json:"closedAt,omitempty"`
+	ClosedBy int    `json:"closedBy,omitempty"`
+}
+
+type CloseAccountingPeriod struct {
+	Period *Date `json:"period,omitempty" validate:"required"`
+}
+
+type ReopenAccountingPeriod struct {
+	Period *Date `json:"period,omitempty" validate:"required"`
+}
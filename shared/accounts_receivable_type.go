@@ -11,6 +11,7 @@ var AccountsReceivableTypeMap = map[string]AccountsReceivableType{
 	"BadDebtWriteOff":    AccountsReceivableTypeBadDebtWriteOff,
 	"FeeAccrual":         AccountsReceivableTypeFeeAccrual,
 	"InvoiceAdjustments": AccountsReceivableTypeInvoiceAdjustments,
+	"SuspenseAccount":    AccountsReceivableTypeSuspenseAccount,
 }
 
 type AccountsReceivableType int
@@ -25,6 +26,7 @@ const (
 	AccountsReceivableTypeBadDebtWriteOff
 	AccountsReceivableTypeFeeAccrual
 	AccountsReceivableTypeInvoiceAdjustments
+	AccountsReceivableTypeSuspenseAccount
 )
 
 func (a AccountsReceivableType) String() string {
@@ -49,6 +51,8 @@ func (a AccountsReceivableType) Translation() string {
 		return "Fee Accrual"
 	case AccountsReceivableTypeInvoiceAdjustments:
 		return "Invoice Adjustments"
+	case AccountsReceivableTypeSuspenseAccount:
+		return "Suspense Account"
 	default:
 		return ""
 	}
@@ -72,6 +76,8 @@ func (a AccountsReceivableType) Key() string {
 		return "FeeAccrual"
 	case AccountsReceivableTypeInvoiceAdjustments:
 		return "InvoiceAdjustments"
+	case AccountsReceivableTypeSuspenseAccount:
+		return "SuspenseAccount"
 	default:
 		return ""
 	}
@@ -0,0 +1,21 @@
+package shared
+
+// AddManualPayment is a single payment posted directly to a client's account
+type AddManualPayment struct {
+	PaymentType  TransactionType `json:"paymentType" validate:"required,valid-enum"`
+	Amount       int32           `json:"amount" validate:"required,gt=0"`
+	BankDate     *Date           `json:"bankDate,omitempty" validate:"required,date-in-the-past"`
+	ReceivedDate *Date           `json:"receivedDate,omitempty" validate:"required,date-in-the-past"`
+	PisNumber    int             `json:"pisNumber,omitempty" validate:"omitempty,gt=0"`
+	// InvoiceReference optionally targets the payment at a specific unpaid invoice
+	InvoiceReference string `json:"invoiceReference,omitempty"`
+}
+
+// ManualPaymentTypes are the payment types that can be added to a client individually, rather than through an upload
+var ManualPaymentTypes = []TransactionType{
+	TransactionTypeMotoCardPayment,
+	TransactionTypeOnlineCardPayment,
+	TransactionTypeSupervisionBACSPayment,
+	TransactionTypeOPGBACSPayment,
+	TransactionTypeSupervisionChequePayment,
+}
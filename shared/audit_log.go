@@ -0,0 +1,36 @@
+package shared
+
+import "time"
+
+const (
+	AuditActionRefundDecision            = "REFUND DECISION"
+	AuditActionInvoiceAdjustmentDecision = "INVOICE ADJUSTMENT DECISION"
+	AuditActionFeeReductionCancelled     = "FEE REDUCTION CANCELLED"
+	AuditActionPaymentMethodChanged      = "PAYMENT METHOD CHANGED"
+	AuditActionDirectDebitMandateCreated = "DIRECT DEBIT MANDATE CREATED"
+	AuditActionDecisionOverride          = "DECISION OVERRIDE"
+	AuditActionAccountingPeriodReopened  = "ACCOUNTING PERIOD REOPENED"
+)
+
+const (
+	AuditEntityRefund            = "REFUND"
+	AuditEntityInvoiceAdjustment = "INVOICE ADJUSTMENT"
+	AuditEntityFeeReduction      = "FEE REDUCTION"
+	AuditEntityFinanceClient     = "FINANCE CLIENT"
+	AuditEntityCreditTransfer    = "CREDIT TRANSFER"
+	AuditEntityAccountingPeriod  = "ACCOUNTING PERIOD"
+)
+
+type AuditLogs []AuditLog
+
+// AuditLog is an immutable record of a change made to a client's finances, with the values before and after it
+type AuditLog struct {
+	ID         int               `json:"id"`
+	Action     string            `json:"action"`
+	EntityType string            `json:"entityType"`
+	EntityID   int               `json:"entityId"`
+	Before     map[string]string `json:"before,omitempty"`
+	After      map[string]string `json:"after,omitempty"`
+	CreatedAt  time.Time         `json:"createdAt"`
+	CreatedBy  int               `json:"createdBy"`
+}
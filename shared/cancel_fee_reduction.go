@@ -2,4 +2,5 @@ package shared
 
 type CancelFeeReduction struct {
 	CancellationReason string `json:"cancellationReason" validate:"required,thousand-character-limit"`
+	ReverseCredits     bool   `json:"reverseCredits"`
 }
@@ -0,0 +1,27 @@
+package shared
+
+// AddCreditTransfer requests that unapplied credit is moved from one client to the client with the given court reference
+type AddCreditTransfer struct {
+	ToCourtRef    string `json:"toCourtRef" validate:"required"`
+	Amount        int32  `json:"amount" validate:"required,gt=0"`
+	TransferNotes string `json:"notes" validate:"required,thousand-character-limit"`
+}
+
+type CreditTransfers []CreditTransfer
+
+// CreditTransfer is a request to move credit between clients that is awaiting a decision
+type CreditTransfer struct {
+	ID           int    `json:"id"`
+	FromClientId int    `json:"fromClientId"`
+	FromCourtRef string `json:"fromCourtRef"`
+	ToClientId   int    `json:"toClientId"`
+	ToCourtRef   string `json:"toCourtRef"`
+	Amount       int    `json:"amount"`
+	Notes        string `json:"notes"`
+	CreatedDate  Date   `json:"createdDate"`
+	CreatedBy    int    `json:"createdBy"`
+}
+
+type UpdateCreditTransfer struct {
+	Status AdjustmentStatus `json:"status" validate:"valid-enum,oneof=2 3"` // APPROVED, REJECTED
+}
@@ -10,6 +10,7 @@ const (
 	EventSourceFinanceAdhoc      = "opg.supervision.finance.adhoc"
 	EventSourceInfra             = "opg.supervision.infra"
 	EventSourceFinance           = "opg.supervision.finance"
+	EventSourceFinanceAdmin      = "opg.supervision.finance.admin"
 	DetailTypeFinanceAdhoc       = "finance-adhoc"
 	DetailTypeInvoiceCreated     = "invoice-created"
 	DetailTypeClientUpdated      = "client-updated"
@@ -18,10 +19,14 @@ const (
 	DetailTypeFinanceAdminUpload = "finance-admin-upload"
 	DetailTypeScheduleToRemove   = "schedule-to-remove"
 	DetailTypeScheduledEvent     = "scheduled-event"
-	ScheduledEventRefundExpiry   = "refund-expiry"
+
+	ScheduledEventRefundExpiry                 = "refund-expiry"
+	ScheduledEventFailedDirectDebitCollections = "failed-direct-debit-collections"
+	ScheduledEventReportSubscriptions          = "report-subscriptions"
 )
 
 type Event struct {
+	ID           string      `json:"id,omitempty"`
 	Source       string      `json:"source"`
 	EventBusName string      `json:"event-bus-name"`
 	DetailType   string      `json:"detail-type"`
@@ -116,7 +121,11 @@ type ClientUpdatedEvent struct {
 }
 
 type OrderCreatedEvent struct {
-	ClientID int32 `json:"clientId"`
+	ClientID  int32  `json:"clientId"`
+	OrderID   int32  `json:"orderId"`
+	CourtRef  string `json:"courtRef"`
+	SopNumber string `json:"sopNumber"`
+	OrderDate Date   `json:"orderDate"`
 }
 
 type ClientMadeInactiveEvent struct {
@@ -134,10 +143,12 @@ type FinanceAdminUploadEvent struct {
 }
 
 type ScheduleToRemoveEvent struct {
-	CourtRef string `json:"courtRef"`
-	Surname  string `json:"surname"`
-	Amount   int    `json:"amount"`
-	Date     Date   `json:"date"`
+	CourtRef   string `json:"courtRef"`
+	Surname    string `json:"surname"`
+	Amount     int    `json:"amount"`
+	Date       Date   `json:"date"`
+	ClientID   int    `json:"clientId"`
+	Reschedule bool   `json:"reschedule,omitempty"`
 }
 
 type AdhocEvent struct {
@@ -172,6 +183,12 @@ func (e *ScheduledEvent) UnmarshalJSON(data []byte) error {
 	switch e.Trigger {
 	case ScheduledEventRefundExpiry:
 		e.Override = nil
+	case ScheduledEventFailedDirectDebitCollections, ScheduledEventReportSubscriptions:
+		var override DateOverride
+		if err := json.Unmarshal(raw.Override, &override); err != nil {
+			return err
+		}
+		e.Override = override
 	default:
 		return fmt.Errorf("unknown trigger type: %s", e.Trigger)
 	}
@@ -10,6 +10,7 @@ type Invoice struct {
 	RaisedDate         Date               `json:"raisedDate"`
 	Received           int                `json:"received"`
 	OutstandingBalance int                `json:"outstandingBalance"`
+	Voided             bool               `json:"voided"`
 	Ledgers            []Ledger           `json:"ledgers"`
 	SupervisionLevels  []SupervisionLevel `json:"supervisionLevels"`
 }
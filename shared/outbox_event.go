@@ -0,0 +1,16 @@
+package shared
+
+import "time"
+
+type OutboxEvents []OutboxEvent
+
+// OutboxEvent is an outbound event that could not be published to the event bus, and has stopped being retried
+type OutboxEvent struct {
+	ID             int       `json:"id"`
+	EventType      string    `json:"eventType"`
+	OrderingKey    string    `json:"orderingKey"`
+	Attempts       int       `json:"attempts"`
+	Error          string    `json:"error,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	DeadLetteredAt time.Time `json:"deadLetteredAt"`
+}
@@ -12,6 +12,8 @@ type PaymentDetails struct {
 	ReceivedDate pgtype.Timestamp
 	CreatedBy    pgtype.Int4
 	PisNumber    pgtype.Int4
+	// InvoiceReference optionally targets the payment at a specific invoice before the remainder is allocated oldest-first
+	InvoiceReference pgtype.Text
 }
 
 type ReversalDetails struct {
@@ -0,0 +1,33 @@
+package shared
+
+type PendingInvoiceAdjustments []PendingInvoiceAdjustment
+
+type PendingInvoiceAdjustment struct {
+	Id             int            `json:"id"`
+	ClientId       int            `json:"clientId"`
+	CourtRef       string         `json:"courtRef"`
+	InvoiceRef     string         `json:"invoiceRef"`
+	RaisedDate     Date           `json:"raisedDate"`
+	AdjustmentType AdjustmentType `json:"adjustmentType"`
+	Amount         int            `json:"amount"`
+	Notes          string         `json:"notes"`
+	CreatedBy      int            `json:"createdBy"`
+}
+
+type UpdateInvoiceAdjustments struct {
+	Status      AdjustmentStatus          `json:"status" validate:"valid-enum,oneof=2 3"` // APPROVED, REJECTED
+	Adjustments []ClientInvoiceAdjustment `json:"adjustments" validate:"required"`
+}
+
+type ClientInvoiceAdjustment struct {
+	ClientId     int `json:"clientId"`
+	AdjustmentId int `json:"adjustmentId"`
+}
+
+type InvoiceAdjustmentDecisions []InvoiceAdjustmentDecision
+
+// InvoiceAdjustmentDecision is the outcome of updating a single adjustment as part of a bulk decision
+type InvoiceAdjustmentDecision struct {
+	ClientInvoiceAdjustment
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,33 @@
+package shared
+
+type PendingRefunds []PendingRefund
+
+type PendingRefund struct {
+	ID              int          `json:"id"`
+	ClientId        int          `json:"clientId"`
+	CourtRef        string       `json:"courtRef"`
+	RaisedDate      Date         `json:"raisedDate"`
+	Amount          int          `json:"amount"`
+	Status          RefundStatus `json:"status"`
+	Notes           string       `json:"notes"`
+	CreatedBy       int          `json:"createdBy"`
+	DaysUntilExpiry int          `json:"daysUntilExpiry"`
+}
+
+type UpdateRefundDecisions struct {
+	Status  RefundStatus   `json:"status" validate:"valid-enum,oneof=2 3"` // APPROVED, REJECTED
+	Refunds []ClientRefund `json:"refunds" validate:"required"`
+}
+
+type ClientRefund struct {
+	ClientId int `json:"clientId"`
+	RefundId int `json:"refundId"`
+}
+
+type RefundDecisions []RefundDecision
+
+// RefundDecision is the outcome of deciding a single refund as part of a bulk decision
+type RefundDecision struct {
+	ClientRefund
+	Error string `json:"error,omitempty"`
+}
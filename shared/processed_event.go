@@ -0,0 +1,39 @@
+package shared
+
+import "time"
+
+const (
+	ProcessedEventStatusProcessing = "PROCESSING"
+	ProcessedEventStatusProcessed  = "PROCESSED"
+	ProcessedEventStatusFailed     = "FAILED"
+
+	// EventReplayStatusSkipped is returned for an event that was selected for replay but had not failed
+	EventReplayStatusSkipped = "SKIPPED"
+)
+
+type ProcessedEvents []ProcessedEvent
+
+// ProcessedEvent is an inbound event that has been received from the event bus, along with the outcome of processing it
+type ProcessedEvent struct {
+	ID          int                 `json:"id"`
+	Source      string              `json:"source"`
+	DetailType  string              `json:"detailType"`
+	EventKey    string              `json:"eventKey"`
+	Attempts    int                 `json:"attempts"`
+	Error       string              `json:"error,omitempty"`
+	ReceivedAt  time.Time           `json:"receivedAt"`
+	ProcessedAt Nillable[time.Time] `json:"processedAt"`
+}
+
+type ReplayEvents struct {
+	IDs []int `json:"ids" validate:"required,min=1"`
+}
+
+type EventReplays []EventReplay
+
+// EventReplay is the outcome of replaying a single failed event
+type EventReplay struct {
+	ID     int    `json:"id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
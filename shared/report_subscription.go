@@ -0,0 +1,47 @@
+package shared
+
+import "time"
+
+const (
+	ReportCadenceDaily    = "DAILY"
+	ReportCadenceWeekly   = "WEEKLY"
+	ReportCadenceMonthEnd = "MONTH_END"
+)
+
+// RelativeDate is a report date that is resolved against the date a subscribed report runs on
+type RelativeDate string
+
+const (
+	RelativeDateRunDate            = RelativeDate("RUN_DATE")
+	RelativeDatePreviousWorkingDay = RelativeDate("PREVIOUS_WORKING_DAY")
+	RelativeDateStartOfMonth       = RelativeDate("START_OF_MONTH")
+)
+
+type ReportSubscriptions []ReportSubscription
+
+// ReportSubscription is a saved report request that is run for each recipient on the working days given by its cadence.
+// Daily reports run every working day, weekly reports on the first working day of the week, and month-end reports on
+// the last working day of the month.
+type ReportSubscription struct {
+	ID              int           `json:"id"`
+	Name            string        `json:"name"`
+	ReportRequest   ReportRequest `json:"reportRequest"`
+	Recipients      []string      `json:"recipients"`
+	Cadence         string        `json:"cadence"`
+	TransactionDate *RelativeDate `json:"transactionDate,omitempty"`
+	FromDate        *RelativeDate `json:"fromDate,omitempty"`
+	ToDate          *RelativeDate `json:"toDate,omitempty"`
+	LastRunOn       *Date         `json:"lastRunOn,omitempty"`
+	CreatedAt       time.Time     `json:"createdAt"`
+	CreatedBy       int           `json:"createdBy"`
+}
+
+type AddReportSubscription struct {
+	Name            string        `json:"name" validate:"required,max=255"`
+	ReportRequest   ReportRequest `json:"reportRequest"`
+	Recipients      []string      `json:"recipients" validate:"required,min=1,dive,email"`
+	Cadence         string        `json:"cadence" validate:"oneof=DAILY WEEKLY MONTH_END"`
+	TransactionDate *RelativeDate `json:"transactionDate,omitempty" validate:"omitempty,oneof=RUN_DATE PREVIOUS_WORKING_DAY START_OF_MONTH"`
+	FromDate        *RelativeDate `json:"fromDate,omitempty" validate:"omitempty,oneof=RUN_DATE PREVIOUS_WORKING_DAY START_OF_MONTH"`
+	ToDate          *RelativeDate `json:"toDate,omitempty" validate:"omitempty,oneof=RUN_DATE PREVIOUS_WORKING_DAY START_OF_MONTH"`
+}
@@ -259,6 +259,11 @@ func (u ReportUploadType) IsRefundReversal() bool {
 	return u == ReportTypeUploadReverseFulfilledRefunds
 }
 
+// PostsLedgers returns true for uploads that create ledgers when processed
+func (u ReportUploadType) PostsLedgers() bool {
+	return u.IsPayment() || u.IsReversal() || u.IsRefund() || u.IsRefundReversal()
+}
+
 func (u ReportUploadType) IsDirectUpload() bool {
 	return u == ReportTypeUploadDebtChase
 }
@@ -267,6 +272,10 @@ func (u ReportUploadType) IsScheduleRemoval() bool {
 	return u == ReportTypeUploadRemoveSchedules
 }
 
+func (u ReportUploadType) IsDeputySchedule() bool {
+	return u == ReportTypeUploadDeputySchedule
+}
+
 func (u ReportUploadType) HasHeader() bool {
 	return !slices.Contains(reportUploadNoHeaderTypes, u)
 }
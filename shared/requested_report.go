@@ -0,0 +1,34 @@
+package shared
+
+import "time"
+
+const (
+	RequestedReportStatusRunning     = "RUNNING"
+	RequestedReportStatusCompleted   = "COMPLETED"
+	RequestedReportStatusFailed      = "FAILED"
+	RequestedReportStatusInterrupted = "INTERRUPTED"
+)
+
+// GeneratedReport is the file a report was uploaded to, and the number of rows it contains
+type GeneratedReport struct {
+	Key       string
+	VersionID string
+	Rows      int
+}
+
+type RequestedReports []RequestedReport
+
+// RequestedReport is a report that has been requested, along with the outcome of generating it
+type RequestedReport struct {
+	ID            int                 `json:"id"`
+	ReportRequest ReportRequest       `json:"reportRequest"`
+	Status        string              `json:"status"`
+	RequestedAt   time.Time           `json:"requestedAt"`
+	RequestedBy   int                 `json:"requestedBy"`
+	CompletedAt   Nillable[time.Time] `json:"completedAt"`
+	DurationMs    int64               `json:"durationMs,omitempty"`
+	RowCount      int                 `json:"rowCount"`
+	Key           string              `json:"key,omitempty"`
+	VersionID     string              `json:"versionId,omitempty"`
+	Error         string              `json:"error,omitempty"`
+}
@@ -0,0 +1,23 @@
+package shared
+
+type SuspenseItems []SuspenseItem
+
+// SuspenseItem is a payment line that could not be matched to a client when it was uploaded, and is held in suspense
+// until it is allocated to a court reference
+type SuspenseItem struct {
+	ID           int              `json:"id"`
+	CourtRef     string           `json:"courtRef"`
+	Amount       int              `json:"amount"`
+	LedgerType   TransactionType  `json:"ledgerType"`
+	BankDate     Date             `json:"bankDate"`
+	ReceivedDate Date             `json:"receivedDate"`
+	PisNumber    int              `json:"pisNumber,omitempty"`
+	UploadType   ReportUploadType `json:"uploadType"`
+	Line         []string         `json:"line"`
+	CreatedDate  Date             `json:"createdDate"`
+	CreatedBy    int              `json:"createdBy"`
+}
+
+type AllocateSuspense struct {
+	CourtRef string `json:"courtRef" validate:"required"`
+}
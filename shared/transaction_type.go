@@ -43,6 +43,8 @@ const (
 	TransactionTypeSOPUnallocatedPayment
 	TransactionTypeRefund
 	TransactionTypeLegacyCardPayment
+	TransactionTypeCreditTransfer
+	TransactionTypeInvoiceVoid
 )
 
 var TransactionTypeMap = map[string]TransactionType{
@@ -67,6 +69,8 @@ var TransactionTypeMap = map[string]TransactionType{
 	"SOP UNALLOCATED":            TransactionTypeSOPUnallocatedPayment,
 	"REFUND":                     TransactionTypeRefund,
 	"CARD PAYMENT":               TransactionTypeLegacyCardPayment,
+	"CREDIT TRANSFER":            TransactionTypeCreditTransfer,
+	"INVOICE VOID":               TransactionTypeInvoiceVoid,
 }
 
 func (t TransactionType) String() string {
@@ -107,6 +111,10 @@ func (t TransactionType) String() string {
 		return "Refund"
 	case TransactionTypeLegacyCardPayment:
 		return "(Legacy) Card payment"
+	case TransactionTypeCreditTransfer:
+		return "Credit transfer"
+	case TransactionTypeInvoiceVoid:
+		return "Invoice void"
 	default:
 		return ""
 	}
@@ -150,6 +158,10 @@ func (t TransactionType) Key() string {
 		return "REFUND"
 	case TransactionTypeLegacyCardPayment:
 		return "CARD PAYMENT"
+	case TransactionTypeCreditTransfer:
+		return "CREDIT TRANSFER"
+	case TransactionTypeInvoiceVoid:
+		return "INVOICE VOID"
 	default:
 		return ""
 	}
@@ -0,0 +1,29 @@
+package shared
+
+import "time"
+
+const (
+	UploadJobStatusProcessing = "PROCESSING"
+	UploadJobStatusCompleted  = "COMPLETED"
+	UploadJobStatusFailed     = "FAILED"
+)
+
+type UploadJobs []UploadJob
+
+type UploadJob struct {
+	ID                 int                 `json:"id"`
+	UploadType         ReportUploadType    `json:"uploadType"`
+	Filename           string              `json:"filename"`
+	FileHash           string              `json:"fileHash"`
+	Status             string              `json:"status"`
+	LineCount          Nillable[int]       `json:"lineCount"`
+	FailedLineCount    Nillable[int]       `json:"failedLineCount"`
+	FailedLines        map[int]string      `json:"failedLines,omitempty"`
+	Error              string              `json:"error,omitempty"`
+	StartedAt          time.Time           `json:"startedAt"`
+	EndedAt            Nillable[time.Time] `json:"endedAt"`
+	CreatedBy          int                 `json:"createdBy"`
+	UploadDate         Nillable[Date]      `json:"uploadDate"`
+	EmailAddress       string              `json:"emailAddress"`
+	ProcessedLineCount Nillable[int]       `json:"processedLineCount"`
+}
@@ -0,0 +1,28 @@
+package shared
+
+type UploadPreview struct {
+	UploadType ReportUploadType    `json:"uploadType"`
+	Lines      []UploadPreviewLine `json:"lines"`
+}
+
+type UploadPreviewLine struct {
+	Line    int                   `json:"line"`
+	Error   string                `json:"error,omitempty"`
+	Ledgers []UploadPreviewLedger `json:"ledgers,omitempty"`
+}
+
+type UploadPreviewLedger struct {
+	ClientID           int                       `json:"clientId"`
+	CourtRef           string                    `json:"courtRef"`
+	LedgerType         string                    `json:"ledgerType"`
+	Amount             int                       `json:"amount"`
+	Allocations        []UploadPreviewAllocation `json:"allocations"`
+	OutstandingBalance int                       `json:"outstandingBalance"`
+	CreditBalance      int                       `json:"creditBalance"`
+}
+
+type UploadPreviewAllocation struct {
+	InvoiceReference string `json:"invoiceReference,omitempty"`
+	Amount           int    `json:"amount"`
+	Status           string `json:"status"`
+}
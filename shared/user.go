@@ -5,7 +5,10 @@ const (
 	RoleFinanceManager   = "Finance Manager"
 	RoleFinanceReporting = "Finance Reporting"
 	RoleCorporateFinance = "Corporate Finance"
-	RoleAny              = ""
+	// RoleFinanceDecisionOverride allows a user to approve refunds and adjustments they created themselves. Each use
+	// is logged, so it should only be granted where segregation of duties cannot otherwise be met.
+	RoleFinanceDecisionOverride = "Finance Decision Override"
+	RoleAny                     = ""
 )
 
 type User struct {
@@ -30,6 +33,16 @@ func (u User) IsCorporateFinance() bool {
 	return contains(u.Roles, RoleCorporateFinance)
 }
 
+func (u User) IsFinanceDecisionOverride() bool {
+	return contains(u.Roles, RoleFinanceDecisionOverride)
+}
+
+// CanApprove reports whether the user may approve something created by the given user. A user may not approve their
+// own work unless they hold the decision override role.
+func (u User) CanApprove(createdBy int) bool {
+	return int(u.ID) != createdBy || u.IsFinanceDecisionOverride()
+}
+
 func (u User) HasRole(role string) bool {
 	if role == RoleAny {
 		return true
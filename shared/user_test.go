@@ -0,0 +1,25 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUser_CanApprove(t *testing.T) {
+	tests := []struct {
+		name      string
+		user      User
+		createdBy int
+		want      bool
+	}{
+		{name: "another user", user: User{ID: 1, Roles: []string{RoleFinanceManager}}, createdBy: 2, want: true},
+		{name: "creator", user: User{ID: 1, Roles: []string{RoleFinanceManager}}, createdBy: 1, want: false},
+		{name: "creator with override", user: User{ID: 1, Roles: []string{RoleFinanceManager, RoleFinanceDecisionOverride}}, createdBy: 1, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.user.CanApprove(tt.createdBy))
+		})
+	}
+}
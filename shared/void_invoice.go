@@ -0,0 +1,5 @@
+package shared
+
+type VoidInvoice struct {
+	VoidReason string `json:"voidReason" validate:"required,thousand-character-limit"`
+}